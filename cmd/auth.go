@@ -3,7 +3,7 @@ package cmd
 import (
 	"bufio"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/lox/notion-cli/internal/api"
 	"github.com/lox/notion-cli/internal/cli"
 	"github.com/lox/notion-cli/internal/config"
 	"github.com/lox/notion-cli/internal/mcp"
@@ -24,6 +25,7 @@ type AuthCmd struct {
 	Status  AuthStatusCmd  `cmd:"" default:"withargs" help:"Show authentication status"`
 	List    AuthListCmd    `cmd:"" help:"List profiles and authentication state"`
 	Use     AuthUseCmd     `cmd:"" help:"Set the active profile"`
+	Whoami  AuthWhoamiCmd  `cmd:"" help:"Show which workspace each profile is connected to"`
 	Logout  AuthLogoutCmd  `cmd:"" help:"Clear stored credentials"`
 	API     AuthAPICmd     `cmd:"" name:"api" help:"Official API token commands"`
 }
@@ -49,6 +51,11 @@ type authProfileStatus struct {
 type AuthLoginCmd struct{}
 
 func (c *AuthLoginCmd) Run(ctx *Context) error {
+	if ctx.NonInteractive {
+		err := &output.UserError{Message: "--non-interactive: auth login needs a browser; log in once interactively, or pass an access token with NOTION_ACCESS_TOKEN"}
+		output.PrintError(err)
+		return err
+	}
 	tokenStore, err := mcp.NewFileTokenStore(ctx.Profile)
 	if err != nil {
 		output.PrintError(err)
@@ -61,12 +68,36 @@ func (c *AuthLoginCmd) Run(ctx *Context) error {
 		return err
 	}
 
+	printLoginSummary(bgCtx, ctx)
 	return nil
 }
 
-type AuthRefreshCmd struct{}
+// printLoginSummary reports the workspace and capabilities behind the
+// profile's official API token after login. It is best effort: failures
+// only produce a hint, since the OAuth login itself already succeeded.
+func printLoginSummary(bgCtx context.Context, ctx *Context) {
+	row, err := inspectProfileWorkspace(bgCtx, ctx.Profile, officialAPIOverrides(ctx), true)
+	if err != nil {
+		return
+	}
+	switch row.Status {
+	case "no_api_token":
+		output.PrintInfo("Run 'notion-cli auth api setup' to see which workspace and capabilities the integration has")
+	case "ok":
+		_, _ = fmt.Fprintf(authAPIOutput, "Workspace: %s\n", row.Workspace)
+		if row.Capabilities != nil {
+			printAuthCapabilities(authAPIOutput, row.Profile, row.Capabilities)
+		}
+	}
+}
+
+type AuthRefreshCmd struct {
+	JSON bool `help:"Output as JSON" short:"j"`
+}
 
 func (c *AuthRefreshCmd) Run(ctx *Context) error {
+	ctx.JSON = ctx.JSON || c.JSON
+
 	tokenStore, err := mcp.NewFileTokenStore(ctx.Profile)
 	if err != nil {
 		output.PrintError(err)
@@ -95,8 +126,24 @@ func (c *AuthRefreshCmd) Run(ctx *Context) error {
 		return err
 	}
 
+	account, err := config.ResolveProfile(ctx.Profile)
+	if err != nil {
+		account = ctx.Profile
+	}
+	return printAuthRefresh(os.Stdout, account, newToken.ExpiresAt, ctx.JSON)
+}
+
+func printAuthRefresh(w io.Writer, account string, expiresAt time.Time, asJSON bool) error {
+	if asJSON {
+		return output.WriteStructured(w, map[string]any{
+			"account":    account,
+			"expires_at": expiresAt,
+			"refreshed":  true,
+		})
+	}
+
 	output.PrintSuccess("Token refreshed")
-	fmt.Printf("Expires: %s\n", newToken.ExpiresAt.Format("2 Jan 2006 15:04"))
+	_, _ = fmt.Fprintf(w, "Expires: %s\n", expiresAt.Format("2 Jan 2006 15:04"))
 	return nil
 }
 
@@ -105,7 +152,7 @@ type AuthStatusCmd struct {
 }
 
 func (c *AuthStatusCmd) Run(ctx *Context) error {
-	ctx.JSON = c.JSON
+	ctx.JSON = ctx.JSON || c.JSON
 
 	status, err := inspectProfileStatus(ctx.Profile)
 	if err != nil {
@@ -124,9 +171,7 @@ func (c *AuthStatusCmd) Run(ctx *Context) error {
 		if status.OAuthExpiresAt != nil {
 			payload["expires_at"] = status.OAuthExpiresAt
 		}
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(payload)
+		return output.WriteStructured(os.Stdout, payload)
 	}
 
 	labelStyle := color.New(color.Faint)
@@ -157,10 +202,13 @@ func (c *AuthStatusCmd) Run(ctx *Context) error {
 }
 
 type AuthListCmd struct {
-	JSON bool `help:"Output as JSON" short:"j"`
+	JSON        bool `help:"Output as JSON" short:"j"`
+	ExpiredOnly bool `help:"Show only profiles whose OAuth token is expired or missing, to find those that need auth login" name:"expired-only" aliases:"show-expired"`
 }
 
 func (c *AuthListCmd) Run(ctx *Context) error {
+	ctx.JSON = ctx.JSON || c.JSON
+
 	profiles, err := config.ListProfiles()
 	if err != nil {
 		output.PrintError(err)
@@ -174,13 +222,18 @@ func (c *AuthListCmd) Run(ctx *Context) error {
 			output.PrintError(err)
 			return err
 		}
+		if c.ExpiredOnly && row.OAuthStatus == "valid" {
+			continue
+		}
 		rows = append(rows, row)
 	}
 
-	if c.JSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(rows)
+	if ctx.JSON {
+		return output.WriteStructured(os.Stdout, rows)
+	}
+	if c.ExpiredOnly && len(rows) == 0 {
+		output.PrintSuccess("Every profile has a valid OAuth token")
+		return nil
 	}
 
 	labelStyle := color.New(color.Faint)
@@ -241,9 +294,20 @@ func (c *AuthUseCmd) Run(ctx *Context) error {
 	return nil
 }
 
-type AuthLogoutCmd struct{}
+type AuthLogoutCmd struct {
+	All bool `help:"Log out of every profile"`
+	Yes bool `help:"With --all, log out without asking for confirmation" short:"y"`
+}
+
+var (
+	authLogoutInput  io.Reader = os.Stdin
+	authLogoutOutput io.Writer = os.Stderr
+)
 
 func (c *AuthLogoutCmd) Run(ctx *Context) error {
+	if c.All {
+		return runAuthLogoutAll(ctx, c.Yes)
+	}
 	tokenStore, err := mcp.NewFileTokenStore(ctx.Profile)
 	if err != nil {
 		output.PrintError(err)
@@ -259,17 +323,60 @@ func (c *AuthLogoutCmd) Run(ctx *Context) error {
 	return nil
 }
 
+// runAuthLogoutAll clears the OAuth token of every profile, after asking
+// unless yes is set. Saved official API tokens are kept.
+func runAuthLogoutAll(ctx *Context, yes bool) error {
+	profiles, err := config.ListProfiles()
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if !yes {
+		question := fmt.Sprintf("Log out of all %d profiles?", len(profiles))
+		if err := confirmAction(ctx, authLogoutInput, authLogoutOutput, question, "log out of every profile", "logout"); err != nil {
+			output.PrintError(err)
+			return err
+		}
+	}
+
+	for _, profile := range profiles {
+		tokenStore, err := mcp.NewFileTokenStore(profile)
+		if err == nil {
+			err = tokenStore.Clear()
+		}
+		if err != nil {
+			err = fmt.Errorf("profile %s: %w", profile, err)
+			output.PrintError(err)
+			return err
+		}
+	}
+
+	output.PrintSuccess(fmt.Sprintf("Logged out of %d profiles", len(profiles)))
+	return nil
+}
+
 type AuthAPICmd struct {
 	Setup  AuthAPISetupCmd  `cmd:"" help:"Set up official Notion API token"`
 	Status AuthAPIStatusCmd `cmd:"" help:"Show official API token status"`
 	Verify AuthAPIVerifyCmd `cmd:"" help:"Verify official API token"`
 	Unset  AuthAPIUnsetCmd  `cmd:"" help:"Remove saved official API token"`
+	Rotate AuthAPIRotateCmd `cmd:"" help:"Replace the saved official API token, keeping the old one unless the new one verifies"`
 }
 
-type AuthAPISetupCmd struct{}
+type AuthAPISetupCmd struct {
+	DryRun   bool          `help:"Verify the token and show what would be saved without writing config" name:"dry-run"`
+	NoVerify bool          `help:"With --dry-run, skip verifying the token against the API" name:"no-verify"`
+	Timeout  time.Duration `help:"How long to wait for each verification attempt" default:"10s"`
+}
 
 func (c *AuthAPISetupCmd) Run(ctx *Context) error {
-	token, err := readOfficialAPIToken(authAPIInput, authAPIOutput, authAPIError)
+	if c.NoVerify && !c.DryRun {
+		err := &output.UserError{Message: "--no-verify requires --dry-run"}
+		output.PrintError(err)
+		return err
+	}
+
+	token, err := readOfficialAPIToken(ctx, authAPIInput, authAPIOutput, authAPIError)
 	if err != nil {
 		output.PrintError(err)
 		return err
@@ -279,6 +386,9 @@ func (c *AuthAPISetupCmd) Run(ctx *Context) error {
 		output.PrintError(err)
 		return err
 	}
+	if c.DryRun {
+		return runAuthAPISetupDryRun(ctx, token, !c.NoVerify, c.Timeout)
+	}
 	if err := config.SetAPITokenForProfile(ctx.Profile, token); err != nil {
 		output.PrintError(err)
 		return err
@@ -289,6 +399,59 @@ func (c *AuthAPISetupCmd) Run(ctx *Context) error {
 	return nil
 }
 
+// runAuthAPISetupDryRun optionally verifies token and reports where it
+// would be saved, leaving config untouched.
+func runAuthAPISetupDryRun(ctx *Context, token string, verify bool, timeout time.Duration) error {
+	if verify {
+		if err := verifyOfficialAPIToken(ctx, token, timeout); err != nil {
+			output.PrintError(err)
+			return err
+		}
+		output.PrintSuccess("Official API token verified")
+	}
+
+	_, _ = fmt.Fprintln(authAPIOutput, "Dry run: config not changed. Would save:")
+	_, _ = fmt.Fprintf(authAPIOutput, "Profile:     %s\n", ctx.Profile)
+	_, _ = fmt.Fprintf(authAPIOutput, "Config path: %s\n", mustConfigPath(ctx.Profile))
+	_, _ = fmt.Fprintf(authAPIOutput, "Token:       %s\n", maskToken(token))
+	return nil
+}
+
+// verifyOfficialAPIToken checks token against the API with VerifyToken,
+// using the profile's base URL and Notion version.
+func verifyOfficialAPIToken(ctx *Context, token string, timeout time.Duration) error {
+	overrides := officialAPIOverrides(ctx)
+	overrides.Token = token
+	client, err := cli.RequireOfficialAPIClient(overrides)
+	if err != nil {
+		return err
+	}
+	_, err = client.VerifyToken(context.Background(), timeout)
+	return verifyTokenError(err)
+}
+
+// verifyTokenError turns a VerifyToken failure into a user-facing error
+// that says whether the token or the connection is at fault.
+func verifyTokenError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, api.ErrInvalidToken):
+		return &output.UserError{Message: err.Error() + "; check it at " + officialAPIIntegrationsURL}
+	case errors.Is(err, api.ErrUnreachable):
+		return &output.UserError{Message: err.Error() + "; the token was not checked, try again or raise --timeout"}
+	}
+	return fmt.Errorf("verify official API token: %w", err)
+}
+
+// maskToken hides all but the last four characters of a token.
+func maskToken(token string) string {
+	if len(token) <= 4 {
+		return strings.Repeat("*", len(token))
+	}
+	return strings.Repeat("*", len(token)-4) + token[len(token)-4:]
+}
+
 type AuthAPIStatusCmd struct {
 	JSON bool `help:"Output as JSON" short:"j"`
 }
@@ -306,7 +469,7 @@ func officialAPIOverrides(ctx *Context) config.APIOverrides {
 }
 
 func (c *AuthAPIStatusCmd) Run(ctx *Context) error {
-	ctx.JSON = c.JSON
+	ctx.JSON = ctx.JSON || c.JSON
 
 	loaded, err := cli.LoadOfficialAPIConfig(officialAPIOverrides(ctx))
 	if err != nil {
@@ -317,11 +480,12 @@ func (c *AuthAPIStatusCmd) Run(ctx *Context) error {
 }
 
 type AuthAPIVerifyCmd struct {
-	JSON bool `help:"Output as JSON" short:"j"`
+	JSON    bool          `help:"Output as JSON" short:"j"`
+	Timeout time.Duration `help:"How long to wait for each verification attempt" default:"10s"`
 }
 
 func (c *AuthAPIVerifyCmd) Run(ctx *Context) error {
-	ctx.JSON = c.JSON
+	ctx.JSON = ctx.JSON || c.JSON
 
 	loaded, err := cli.LoadOfficialAPIConfig(officialAPIOverrides(ctx))
 	if err != nil {
@@ -334,32 +498,34 @@ func (c *AuthAPIVerifyCmd) Run(ctx *Context) error {
 		return err
 	}
 
-	self, err := client.GetSelf(context.Background())
-	if err != nil {
+	v, err := client.VerifyToken(context.Background(), c.Timeout)
+	if err := verifyTokenError(err); err != nil {
 		output.PrintError(err)
 		return err
 	}
+	self := v.Self
 
 	if ctx.JSON {
-		enc := json.NewEncoder(authAPIOutput)
-		enc.SetIndent("", "  ")
-		return enc.Encode(map[string]any{
-			"verified":       true,
-			"profile":        loaded.Profile,
-			"token_source":   loaded.APITokenSource,
-			"config_path":    loaded.ConfigPath,
-			"base_url":       loaded.Config.API.BaseURL,
-			"notion_version": loaded.Config.API.NotionVersion,
-			"self":           self,
+		return output.WriteStructured(authAPIOutput, map[string]any{
+			"verified":              true,
+			"profile":               loaded.Profile,
+			"token_source":          loaded.APITokenSource,
+			"config_path":           loaded.ConfigPath,
+			"base_url":              loaded.Config.API.BaseURL,
+			"notion_version":        loaded.Config.API.NotionVersion,
+			"server_notion_version": v.ServerVersion,
+			"latency_ms":            v.Latency.Milliseconds(),
+			"attempts":              v.Attempts,
+			"self":                  self,
 		})
 	}
 
-	output.PrintSuccess("Official API token verified")
+	output.PrintSuccess(verificationSummary(v))
 	_, _ = fmt.Fprintf(authAPIOutput, "Profile:        %s\n", loaded.Profile)
 	_, _ = fmt.Fprintf(authAPIOutput, "Token source:   %s\n", loaded.APITokenSource)
 	_, _ = fmt.Fprintf(authAPIOutput, "Config path:    %s\n", loaded.ConfigPath)
 	_, _ = fmt.Fprintf(authAPIOutput, "Base URL:       %s\n", loaded.Config.API.BaseURL)
-	_, _ = fmt.Fprintf(authAPIOutput, "Notion version: %s\n", loaded.Config.API.NotionVersion)
+	_, _ = fmt.Fprintf(authAPIOutput, "Notion version: %s\n", notionVersionStatus(v))
 	if self.Name != "" {
 		_, _ = fmt.Fprintf(authAPIOutput, "Actor:          %s\n", self.Name)
 	}
@@ -369,7 +535,78 @@ func (c *AuthAPIVerifyCmd) Run(ctx *Context) error {
 	return nil
 }
 
-type AuthAPIUnsetCmd struct{}
+// verificationSummary is the one-line result of a successful check, such
+// as "ok (142ms, bot: My Integration)". Retries are mentioned since they
+// hint at a flaky connection.
+func verificationSummary(v *api.Verification) string {
+	parts := []string{fmt.Sprintf("%dms", v.Latency.Milliseconds())}
+	if v.Self.Name != "" {
+		actor := v.Self.Type
+		if actor == "" {
+			actor = "actor"
+		}
+		parts = append(parts, actor+": "+v.Self.Name)
+	}
+	if v.Attempts > 1 {
+		parts = append(parts, fmt.Sprintf("after %d attempts", v.Attempts))
+	}
+	return "ok (" + strings.Join(parts, ", ") + ")"
+}
+
+// notionVersionStatus reports the Notion-Version sent and whether the server
+// acknowledged it.
+func notionVersionStatus(v *api.Verification) string {
+	switch v.ServerVersion {
+	case "":
+		return v.RequestedVersion + " (not echoed by server)"
+	case v.RequestedVersion:
+		return v.RequestedVersion + " (acknowledged by server)"
+	}
+	return v.RequestedVersion + " (server answered with " + v.ServerVersion + ")"
+}
+
+type AuthAPIRotateCmd struct {
+	NewToken string        `help:"New official API token (prompted for when omitted)" name:"new-token"`
+	Timeout  time.Duration `help:"How long to wait for each verification attempt" default:"10s"`
+}
+
+func (c *AuthAPIRotateCmd) Run(ctx *Context) error {
+	token := strings.TrimSpace(c.NewToken)
+	if token == "" {
+		var err error
+		token, err = readOfficialAPIToken(ctx, authAPIInput, authAPIOutput, authAPIError)
+		if err != nil {
+			output.PrintError(err)
+			return err
+		}
+	}
+	if token == "" {
+		err := fmt.Errorf("official API token cannot be empty")
+		output.PrintError(err)
+		return err
+	}
+
+	if err := verifyOfficialAPIToken(ctx, token, c.Timeout); err != nil {
+		output.PrintError(err)
+		_, _ = fmt.Fprintln(authAPIOutput, "Saved token left unchanged.")
+		return err
+	}
+	if err := config.SetAPITokenForProfile(ctx.Profile, token); err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	output.PrintSuccess("Official API token rotated")
+	_, _ = fmt.Fprintf(authAPIOutput, "Config path: %s\n", mustConfigPath(ctx.Profile))
+	if strings.TrimSpace(os.Getenv("NOTION_API_TOKEN")) != "" {
+		_, _ = fmt.Fprintln(authAPIOutput, "Effective token still comes from NOTION_API_TOKEN.")
+	}
+	return nil
+}
+
+type AuthAPIUnsetCmd struct {
+	Yes bool `help:"Remove the token without asking for confirmation" short:"y"`
+}
 
 func (c *AuthAPIUnsetCmd) Run(ctx *Context) error {
 	loaded, err := cli.LoadOfficialAPIConfig(officialAPIOverrides(ctx))
@@ -387,6 +624,12 @@ func (c *AuthAPIUnsetCmd) Run(ctx *Context) error {
 		_, _ = fmt.Fprintf(authAPIOutput, "Config path: %s\n", loaded.ConfigPath)
 		return nil
 	}
+	if !c.Yes {
+		if err := confirmAction(ctx, authAPIInput, authAPIError, "Remove the saved official API token?", "remove the saved API token", "removal"); err != nil {
+			output.PrintError(err)
+			return err
+		}
+	}
 
 	if err := config.UnsetAPITokenForProfile(ctx.Profile); err != nil {
 		output.PrintError(err)
@@ -405,16 +648,18 @@ func (c *AuthAPIUnsetCmd) Run(ctx *Context) error {
 func printAuthAPIStatus(ctx *Context, loaded *cli.OfficialAPIConfig) error {
 	hasToken := strings.TrimSpace(loaded.Config.API.Token) != ""
 	if ctx.JSON {
-		enc := json.NewEncoder(authAPIOutput)
-		enc.SetIndent("", "  ")
-		return enc.Encode(map[string]any{
+		status := map[string]any{
 			"configured":     hasToken,
 			"profile":        loaded.Profile,
 			"token_source":   loaded.APITokenSource,
 			"config_path":    loaded.ConfigPath,
 			"base_url":       loaded.Config.API.BaseURL,
 			"notion_version": loaded.Config.API.NotionVersion,
-		})
+		}
+		if len(loaded.Warnings) > 0 {
+			status["warnings"] = loaded.Warnings
+		}
+		return output.WriteStructured(authAPIOutput, status)
 	}
 
 	if hasToken {
@@ -428,14 +673,21 @@ func printAuthAPIStatus(ctx *Context, loaded *cli.OfficialAPIConfig) error {
 	_, _ = fmt.Fprintf(authAPIOutput, "Config path:    %s\n", loaded.ConfigPath)
 	_, _ = fmt.Fprintf(authAPIOutput, "Base URL:       %s\n", loaded.Config.API.BaseURL)
 	_, _ = fmt.Fprintf(authAPIOutput, "Notion version: %s\n", loaded.Config.API.NotionVersion)
+	for _, w := range loaded.Warnings {
+		output.PrintWarning(w)
+	}
 	if !hasToken {
 		_, _ = fmt.Fprintln(authAPIOutput, "Run 'notion-cli auth api setup' or set NOTION_API_TOKEN.")
 	}
 	return nil
 }
 
-func readOfficialAPIToken(in io.Reader, out, errOut io.Writer) (string, error) {
-	if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+// readOfficialAPIToken prompts for a token with hidden input on a terminal,
+// and otherwise reads the first line of in. With --non-interactive a
+// terminal is never read from, since nobody is there to type.
+func readOfficialAPIToken(ctx *Context, in io.Reader, out, errOut io.Writer) (string, error) {
+	if isInteractiveTerminal(ctx, in) {
+		f := in.(*os.File)
 		printOfficialAPITokenSetupHint(errOut, true)
 		_, _ = fmt.Fprint(errOut, "Official API token: ")
 		secret, err := term.ReadPassword(int(f.Fd()))
@@ -450,6 +702,10 @@ func readOfficialAPIToken(in io.Reader, out, errOut io.Writer) (string, error) {
 		return token, nil
 	}
 
+	if isTerminalInput(in) {
+		return "", &output.UserError{Message: "--non-interactive: cannot prompt for the official API token; pipe it on stdin instead"}
+	}
+
 	_, _ = fmt.Fprint(out, "Official API token: ")
 	reader := bufio.NewReader(in)
 	line, err := reader.ReadString('\n')
@@ -8,7 +8,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/lox/notion-cli/internal/api"
 	"github.com/lox/notion-cli/internal/config"
 )
 
@@ -40,7 +42,7 @@ func captureStdout(t *testing.T, fn func()) string {
 }
 
 func TestReadOfficialAPITokenFromReader(t *testing.T) {
-	token, err := readOfficialAPIToken(strings.NewReader(" secret-token \n"), &bytes.Buffer{}, &bytes.Buffer{})
+	token, err := readOfficialAPIToken(&Context{}, strings.NewReader(" secret-token \n"), &bytes.Buffer{}, &bytes.Buffer{})
 	if err != nil {
 		t.Fatalf("readOfficialAPIToken: %v", err)
 	}
@@ -49,6 +51,20 @@ func TestReadOfficialAPITokenFromReader(t *testing.T) {
 	}
 }
 
+func TestReadOfficialAPITokenNonInteractiveReadsPipedInput(t *testing.T) {
+	token, err := readOfficialAPIToken(&Context{NonInteractive: true}, strings.NewReader("secret-token\n"), &bytes.Buffer{}, &bytes.Buffer{})
+	if err != nil || token != "secret-token" {
+		t.Fatalf("readOfficialAPIToken = %q, %v", token, err)
+	}
+}
+
+func TestAuthLoginRefusesNonInteractive(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := (&AuthLoginCmd{}).Run(&Context{NonInteractive: true}); err == nil {
+		t.Fatal("expected auth login to fail with --non-interactive")
+	}
+}
+
 func TestPrintOfficialAPITokenSetupHintIncludesURL(t *testing.T) {
 	var out bytes.Buffer
 
@@ -133,6 +149,25 @@ func TestAuthAPIStatusJSONUsesLoadedConfig(t *testing.T) {
 	}
 }
 
+func TestAuthAPIStatusJSONWarnsOnMalformedNotionVersion(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var out bytes.Buffer
+	oldOut := authAPIOutput
+	authAPIOutput = &out
+	t.Cleanup(func() {
+		authAPIOutput = oldOut
+	})
+
+	cmd := &AuthAPIStatusCmd{JSON: true}
+	if err := cmd.Run(&Context{APINotionVersion: "2026-3-11"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !strings.Contains(out.String(), `"warnings": [`) || !strings.Contains(out.String(), "not a YYYY-MM-DD date") {
+		t.Fatalf("expected notion_version warning: %s", out.String())
+	}
+}
+
 func TestAuthAPIVerifyJSON(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet || r.URL.Path != "/v1/users/me" {
@@ -163,6 +198,9 @@ func TestAuthAPIVerifyJSON(t *testing.T) {
 	if !strings.Contains(out.String(), `"workspace_name": "Workspace"`) {
 		t.Fatalf("unexpected output: %s", out.String())
 	}
+	if !strings.Contains(out.String(), `"latency_ms": `) || !strings.Contains(out.String(), `"attempts": 1`) {
+		t.Fatalf("missing probe details: %s", out.String())
+	}
 }
 
 func TestAuthAPIUnsetWarnsWhenEnvTokenStillActive(t *testing.T) {
@@ -179,7 +217,7 @@ func TestAuthAPIUnsetWarnsWhenEnvTokenStillActive(t *testing.T) {
 		authAPIOutput = oldOut
 	})
 
-	cmd := &AuthAPIUnsetCmd{}
+	cmd := &AuthAPIUnsetCmd{Yes: true}
 	stdout := captureStdout(t, func() {
 		if err := cmd.Run(&Context{APIToken: "env-token"}); err != nil {
 			t.Fatalf("Run: %v", err)
@@ -237,3 +275,149 @@ func TestAuthAPIUnsetWarnsWhenOnlyEnvTokenExists(t *testing.T) {
 		t.Fatalf("unexpected stat error: %v", err)
 	}
 }
+
+func TestAuthAPIUnsetAsksForConfirmation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("NOTION_API_TOKEN", "")
+	if err := config.SetAPIToken("stored-token"); err != nil {
+		t.Fatalf("SetAPIToken: %v", err)
+	}
+
+	var prompt bytes.Buffer
+	oldIn, oldErr := authAPIInput, authAPIError
+	authAPIInput, authAPIError = strings.NewReader("n\n"), &prompt
+	t.Cleanup(func() { authAPIInput, authAPIError = oldIn, oldErr })
+
+	if err := (&AuthAPIUnsetCmd{}).Run(&Context{}); err == nil {
+		t.Fatal("expected removal to be cancelled")
+	}
+	if !strings.Contains(prompt.String(), "Remove the saved official API token?") {
+		t.Fatalf("prompt = %q", prompt.String())
+	}
+	if err := (&AuthAPIUnsetCmd{}).Run(&Context{NonInteractive: true}); err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("non-interactive err = %v, want refusal mentioning --yes", err)
+	}
+	loaded, err := config.LoadWithMeta(config.APIOverrides{})
+	if err != nil {
+		t.Fatalf("LoadWithMeta: %v", err)
+	}
+	if !loaded.HasConfigToken {
+		t.Fatal("token removed without confirmation")
+	}
+
+	if err := (&AuthAPIUnsetCmd{Yes: true}).Run(&Context{NonInteractive: true}); err != nil {
+		t.Fatalf("Run --yes: %v", err)
+	}
+}
+
+func TestAuthAPISetupDryRunVerifiesWithoutSaving(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/users/me" || r.Header.Get("Authorization") != "Bearer new-token-1234" {
+			t.Fatalf("unexpected request: %s %s (%s)", r.Method, r.URL.Path, r.Header.Get("Authorization"))
+		}
+		_, _ = w.Write([]byte(`{"object":"user","id":"user_123","type":"bot"}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	var out bytes.Buffer
+	oldIn, oldOut := authAPIInput, authAPIOutput
+	authAPIInput = strings.NewReader("new-token-1234\n")
+	authAPIOutput = &out
+	t.Cleanup(func() {
+		authAPIInput, authAPIOutput = oldIn, oldOut
+	})
+
+	cmd := &AuthAPISetupCmd{DryRun: true}
+	captureStdout(t, func() {
+		if err := cmd.Run(&Context{APIBaseURL: srv.URL + "/v1"}); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	})
+	if !strings.Contains(out.String(), "Dry run") || !strings.Contains(out.String(), "**********1234") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+	if _, err := os.Stat(mustConfigPath("")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("config written during dry run: %v", err)
+	}
+}
+
+func TestAuthAPISetupNoVerifyRequiresDryRun(t *testing.T) {
+	cmd := &AuthAPISetupCmd{NoVerify: true}
+	if err := cmd.Run(&Context{}); err == nil {
+		t.Fatal("expected error for --no-verify without --dry-run")
+	}
+}
+
+func TestAuthAPIRotateKeepsOldTokenWhenVerificationFails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer good-token" {
+			_, _ = w.Write([]byte(`{"object":"user","id":"user_123","type":"bot"}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"object":"error","status":401,"code":"unauthorized","message":"API token is invalid."}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("NOTION_API_TOKEN", "")
+	if err := config.SetAPITokenForProfile("", "old-token"); err != nil {
+		t.Fatalf("SetAPITokenForProfile: %v", err)
+	}
+	var out bytes.Buffer
+	oldOut := authAPIOutput
+	authAPIOutput = &out
+	t.Cleanup(func() {
+		authAPIOutput = oldOut
+	})
+
+	savedToken := func() string {
+		loaded, err := config.LoadWithMeta(config.APIOverrides{})
+		if err != nil {
+			t.Fatalf("LoadWithMeta: %v", err)
+		}
+		return loaded.Config.API.Token
+	}
+	ctx := &Context{APIBaseURL: srv.URL + "/v1"}
+
+	if err := (&AuthAPIRotateCmd{NewToken: "bad-token"}).Run(ctx); err == nil {
+		t.Fatal("expected verification error")
+	}
+	if got := savedToken(); got != "old-token" {
+		t.Fatalf("token after failed rotate = %q, want old-token", got)
+	}
+
+	captureStdout(t, func() {
+		if err := (&AuthAPIRotateCmd{NewToken: "good-token"}).Run(ctx); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	})
+	if got := savedToken(); got != "good-token" {
+		t.Fatalf("token after rotate = %q, want good-token", got)
+	}
+}
+
+func TestVerificationSummary(t *testing.T) {
+	v := &api.Verification{
+		Self:             &api.Self{Type: "bot", Name: "My Integration"},
+		Latency:          142 * time.Millisecond,
+		Attempts:         1,
+		RequestedVersion: "2026-03-11",
+	}
+	if got := verificationSummary(v); got != "ok (142ms, bot: My Integration)" {
+		t.Fatalf("summary = %q", got)
+	}
+	if got := notionVersionStatus(v); got != "2026-03-11 (not echoed by server)" {
+		t.Fatalf("version status = %q", got)
+	}
+
+	v.Attempts = 2
+	v.ServerVersion = "2025-09-03"
+	if got := verificationSummary(v); got != "ok (142ms, bot: My Integration, after 2 attempts)" {
+		t.Fatalf("summary = %q", got)
+	}
+	if got := notionVersionStatus(v); got != "2026-03-11 (server answered with 2025-09-03)" {
+		t.Fatalf("version status = %q", got)
+	}
+}
@@ -1,7 +1,12 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -75,6 +80,45 @@ func TestAuthListJSONShowsProfilesAndActiveState(t *testing.T) {
 	}
 }
 
+func TestAuthListExpiredOnly(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for profile, expiresAt := range map[string]time.Time{
+		"work":     time.Now().Add(time.Hour),
+		"personal": time.Now().Add(-time.Hour),
+	} {
+		store, err := mcp.NewFileTokenStore(profile)
+		if err != nil {
+			t.Fatalf("NewFileTokenStore: %v", err)
+		}
+		if err := store.SaveToken(context.Background(), &transport.Token{AccessToken: profile + "-token", TokenType: "Bearer", ExpiresAt: expiresAt}); err != nil {
+			t.Fatalf("SaveToken: %v", err)
+		}
+	}
+	if err := config.SetAPITokenForProfile("api-only", "api-token"); err != nil {
+		t.Fatalf("SetAPITokenForProfile: %v", err)
+	}
+
+	cmd := &AuthListCmd{JSON: true, ExpiredOnly: true}
+	stdout := captureStdout(t, func() {
+		if err := cmd.Run(&Context{}); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	})
+
+	var rows []authProfileStatus
+	if err := json.Unmarshal([]byte(stdout), &rows); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, stdout)
+	}
+	got := map[string]string{}
+	for _, row := range rows {
+		got[row.Profile] = row.OAuthStatus
+	}
+	if len(got) != 3 || got["personal"] != "expired" || got["api-only"] != "missing" || got["default"] != "missing" {
+		t.Fatalf("rows = %#v", rows)
+	}
+}
+
 func TestAuthStatusJSONReportsMissingTokenForProfile(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
 
@@ -92,3 +136,158 @@ func TestAuthStatusJSONReportsMissingTokenForProfile(t *testing.T) {
 		t.Fatalf("unexpected output: %s", stdout)
 	}
 }
+
+func TestAuthWhoamiAllMapsProfilesToWorkspaces(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer work-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"API token is invalid."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"object":"user","id":"bot_1","type":"bot","name":"CLI","bot":{"workspace_name":"Work"}}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	if err := config.SetAPITokenForProfile("work", "work-token"); err != nil {
+		t.Fatalf("SetAPITokenForProfile: %v", err)
+	}
+	if err := config.SetAPITokenForProfile("stale", "stale-token"); err != nil {
+		t.Fatalf("SetAPITokenForProfile: %v", err)
+	}
+
+	var out bytes.Buffer
+	oldOut := authAPIOutput
+	authAPIOutput = &out
+	t.Cleanup(func() {
+		authAPIOutput = oldOut
+	})
+
+	cmd := &AuthWhoamiCmd{All: true, JSON: true}
+	if err := cmd.Run(&Context{APIToken: "env-token", APIBaseURL: srv.URL + "/v1"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	var rows []authWhoamiRow
+	if err := json.Unmarshal(out.Bytes(), &rows); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, out.String())
+	}
+	byProfile := make(map[string]authWhoamiRow, len(rows))
+	for _, row := range rows {
+		byProfile[row.Profile] = row
+	}
+	if got := byProfile["work"]; got.Status != "ok" || got.Workspace != "Work" {
+		t.Fatalf("work row = %+v", got)
+	}
+	if got := byProfile["stale"]; got.Status != "error" || !strings.Contains(got.Error, "API token is invalid") {
+		t.Fatalf("stale row = %+v", got)
+	}
+	if got := byProfile["default"]; got.Status != "no_api_token" {
+		t.Fatalf("default row = %+v", got)
+	}
+}
+
+func TestAuthWhoamiCapabilitiesReportsOwnerAndReadAccess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/users/me":
+			_, _ = w.Write([]byte(`{"object":"user","id":"bot_1","type":"bot","name":"CLI","bot":{"owner":{"type":"workspace","workspace":true},"workspace_name":"Work","workspace_limits":{"max_file_upload_size_in_bytes":5242880}}}`))
+		case "/v1/search":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"Insufficient permissions for this endpoint."}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv("HOME", t.TempDir())
+
+	var out bytes.Buffer
+	oldOut := authAPIOutput
+	authAPIOutput = &out
+	t.Cleanup(func() {
+		authAPIOutput = oldOut
+	})
+
+	cmd := &AuthWhoamiCmd{Capabilities: true, JSON: true}
+	if err := cmd.Run(&Context{Profile: "default", APIToken: "work-token", APIBaseURL: srv.URL + "/v1"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	var row authWhoamiRow
+	if err := json.Unmarshal(out.Bytes(), &row); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, out.String())
+	}
+	caps := row.Capabilities
+	if caps == nil || caps.Owner != "workspace" || caps.MaxFileUploadBytes != 5242880 {
+		t.Fatalf("capabilities = %+v", caps)
+	}
+	if caps.ReadContent == nil || *caps.ReadContent {
+		t.Fatalf("read_content = %v, want false", caps.ReadContent)
+	}
+}
+
+func TestPrintAuthRefreshJSON(t *testing.T) {
+	expiresAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	if err := printAuthRefresh(&buf, "work", expiresAt, true); err != nil {
+		t.Fatalf("printAuthRefresh: %v", err)
+	}
+
+	var got struct {
+		Account   string    `json:"account"`
+		ExpiresAt time.Time `json:"expires_at"`
+		Refreshed bool      `json:"refreshed"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, buf.String())
+	}
+	if got.Account != "work" || !got.ExpiresAt.Equal(expiresAt) || !got.Refreshed {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestAuthLogoutAllConfirms(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for _, profile := range []string{"default", "work"} {
+		store, err := mcp.NewFileTokenStore(profile)
+		if err != nil {
+			t.Fatalf("NewFileTokenStore: %v", err)
+		}
+		if err := store.SaveToken(context.Background(), &transport.Token{AccessToken: "oauth-" + profile}); err != nil {
+			t.Fatalf("SaveToken: %v", err)
+		}
+	}
+	hasToken := func(profile string) bool {
+		store, err := mcp.NewFileTokenStore(profile)
+		if err != nil {
+			t.Fatalf("NewFileTokenStore: %v", err)
+		}
+		_, err = store.GetToken(context.Background())
+		return err == nil
+	}
+
+	var prompt bytes.Buffer
+	authLogoutInput, authLogoutOutput = strings.NewReader("n\n"), &prompt
+	t.Cleanup(func() { authLogoutInput, authLogoutOutput = os.Stdin, os.Stderr })
+
+	if err := (&AuthLogoutCmd{All: true}).Run(&Context{}); err == nil {
+		t.Fatal("expected logout to be cancelled")
+	}
+	if !strings.Contains(prompt.String(), "Log out of all 2 profiles?") || !hasToken("work") {
+		t.Fatalf("prompt = %q, work token kept = %v", prompt.String(), hasToken("work"))
+	}
+
+	if err := (&AuthLogoutCmd{All: true}).Run(&Context{NonInteractive: true}); err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("non-interactive err = %v, want refusal mentioning --yes", err)
+	}
+
+	if err := (&AuthLogoutCmd{All: true, Yes: true}).Run(&Context{NonInteractive: true}); err != nil {
+		t.Fatalf("Run --yes: %v", err)
+	}
+	if hasToken("default") || hasToken("work") {
+		t.Fatal("tokens remain after logout --all --yes")
+	}
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lox/notion-cli/internal/api"
+	"github.com/lox/notion-cli/internal/config"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+type AuthWhoamiCmd struct {
+	All          bool `help:"Show the workspace for every profile"`
+	Capabilities bool `help:"Also report what the integration can access"`
+	JSON         bool `help:"Output as JSON" short:"j"`
+}
+
+type authWhoamiRow struct {
+	Profile     string `json:"profile"`
+	Active      bool   `json:"active"`
+	OAuthStatus string `json:"oauth_status"`
+	Status      string `json:"status"`
+	Workspace   string `json:"workspace,omitempty"`
+	Actor       string `json:"actor,omitempty"`
+	Error       string `json:"error,omitempty"`
+
+	Capabilities *authCapabilities `json:"capabilities,omitempty"`
+}
+
+// authCapabilities is what can be learned about an integration's grant.
+// The API only reports the owner and workspace limits; read access is
+// probed, and insert, update, and comment capabilities are not exposed.
+type authCapabilities struct {
+	Owner                 string `json:"owner,omitempty"`
+	ReadContent           *bool  `json:"read_content,omitempty"`
+	MaxFileUploadBytes    int64  `json:"max_file_upload_bytes,omitempty"`
+	ReadContentProbeError string `json:"read_content_probe_error,omitempty"`
+}
+
+func (c *AuthWhoamiCmd) Run(ctx *Context) error {
+	ctx.JSON = ctx.JSON || c.JSON
+
+	profiles := []string{ctx.Profile}
+	if c.All {
+		all, err := config.ListProfiles()
+		if err != nil {
+			output.PrintError(err)
+			return err
+		}
+		profiles = all
+	}
+
+	bgCtx := context.Background()
+	rows := make([]authWhoamiRow, 0, len(profiles))
+	for _, profile := range profiles {
+		overrides := officialAPIOverrides(ctx)
+		overrides.Profile = profile
+		if c.All {
+			// An env token belongs to whichever profile is selected, not to every profile.
+			overrides.Token = ""
+		}
+		row, err := inspectProfileWorkspace(bgCtx, profile, overrides, c.Capabilities)
+		if err != nil {
+			output.PrintError(err)
+			return err
+		}
+		rows = append(rows, row)
+	}
+
+	if ctx.JSON {
+		if c.All {
+			return output.WriteStructured(authAPIOutput, rows)
+		}
+		return output.WriteStructured(authAPIOutput, rows[0])
+	}
+
+	table := output.NewTable("PROFILE", "OAUTH", "WORKSPACE", "ACTOR")
+	for _, row := range rows {
+		profile := row.Profile
+		if row.Active {
+			profile += " *"
+		}
+		workspace := row.Workspace
+		switch row.Status {
+		case "no_api_token":
+			workspace = "(no API token)"
+		case "error":
+			workspace = "(error: " + row.Error + ")"
+		}
+		table.AddRow(profile, row.OAuthStatus, workspace, row.Actor)
+	}
+	table.Render()
+
+	for _, row := range rows {
+		if row.Capabilities != nil {
+			_, _ = fmt.Fprintln(authAPIOutput)
+			printAuthCapabilities(authAPIOutput, row.Profile, row.Capabilities)
+		}
+	}
+	return nil
+}
+
+func printAuthCapabilities(w io.Writer, profile string, caps *authCapabilities) {
+	_, _ = fmt.Fprintf(w, "Capabilities (%s):\n", profile)
+	if caps.Owner != "" {
+		_, _ = fmt.Fprintf(w, "  Owner:            %s\n", caps.Owner)
+	}
+	switch {
+	case caps.ReadContent == nil:
+		_, _ = fmt.Fprintf(w, "  Read content:     unknown (%s)\n", caps.ReadContentProbeError)
+	case *caps.ReadContent:
+		_, _ = fmt.Fprintln(w, "  Read content:     yes")
+	default:
+		_, _ = fmt.Fprintln(w, "  Read content:     no")
+	}
+	_, _ = fmt.Fprintln(w, "  Insert/update:    not reported by the Notion API")
+	_, _ = fmt.Fprintln(w, "  Comments:         not reported by the Notion API")
+	if caps.MaxFileUploadBytes > 0 {
+		_, _ = fmt.Fprintf(w, "  Max file upload:  %s\n", formatByteSize(caps.MaxFileUploadBytes))
+	}
+}
+
+func formatByteSize(n int64) string {
+	const mb = 1024 * 1024
+	if n >= mb && n%mb == 0 {
+		return fmt.Sprintf("%d MB", n/mb)
+	}
+	return fmt.Sprintf("%d bytes", n)
+}
+
+func inspectProfileWorkspace(ctx context.Context, profile string, overrides config.APIOverrides, capabilities bool) (authWhoamiRow, error) {
+	status, err := inspectProfileStatus(profile)
+	if err != nil {
+		return authWhoamiRow{}, err
+	}
+	row := authWhoamiRow{
+		Profile:     status.Profile,
+		Active:      status.Active,
+		OAuthStatus: status.OAuthStatus,
+	}
+
+	loaded, err := config.LoadWithMeta(overrides)
+	if err != nil {
+		return authWhoamiRow{}, err
+	}
+	if strings.TrimSpace(loaded.Config.API.Token) == "" {
+		row.Status = "no_api_token"
+		return row, nil
+	}
+
+	client, err := api.NewClient(loaded.Config.API, loaded.Config.API.Token)
+	if err != nil {
+		row.Status = "error"
+		row.Error = err.Error()
+		return row, nil
+	}
+	self, err := client.GetSelf(ctx)
+	if err != nil {
+		row.Status = "error"
+		row.Error = err.Error()
+		return row, nil
+	}
+
+	row.Status = "ok"
+	row.Actor = self.Name
+	if self.Bot != nil {
+		row.Workspace = self.Bot.WorkspaceName
+	}
+	if row.Workspace == "" {
+		row.Workspace = fmt.Sprintf("(unknown, %s)", self.Type)
+	}
+	if capabilities {
+		row.Capabilities = inspectCapabilities(ctx, client, self)
+	}
+	return row, nil
+}
+
+func inspectCapabilities(ctx context.Context, client *api.Client, self *api.Self) *authCapabilities {
+	caps := &authCapabilities{}
+	if self.Bot != nil {
+		if self.Bot.Owner != nil {
+			caps.Owner = self.Bot.Owner.Type
+		}
+		if self.Bot.WorkspaceLimits != nil {
+			caps.MaxFileUploadBytes = self.Bot.WorkspaceLimits.MaxFileUploadSizeInBytes
+		}
+	}
+	canRead, err := client.CanReadContent(ctx)
+	if err != nil {
+		caps.ReadContentProbeError = err.Error()
+	} else {
+		caps.ReadContent = &canRead
+	}
+	return caps
+}
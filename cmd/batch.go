@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// printBatchSummary reports a bulk command's outcome: a summary line, then
+// each failure. In JSON mode only the failures are printed, on stderr, so
+// stdout stays valid JSON.
+func printBatchSummary(result *cli.BatchResult, asJSON bool) {
+	if !asJSON {
+		if result.Failed() == 0 {
+			output.PrintSuccess(result.Summary())
+		} else {
+			printWarningFn(result.Summary())
+		}
+	}
+	for _, f := range result.Failures {
+		output.PrintError(fmt.Errorf("%s: %w", f.Item, f.Err))
+	}
+}
+
+// validateBatchFlags rejects --continue-on-error and --ignore-failures where
+// they cannot apply. multiTarget is set when page sync writes a file to its
+// notion-targets list, which is a batch of its own.
+func validateBatchFlags(opts pageFileOptions, multiTarget bool) error {
+	if opts.IgnoreFailures && !opts.ContinueOnError {
+		return &output.UserError{Message: "--ignore-failures requires --continue-on-error"}
+	}
+	if opts.ContinueOnError && opts.SplitOn == "" && !multiTarget {
+		return &output.UserError{Message: "--continue-on-error only applies to --split-on batches and files with a notion-targets list"}
+	}
+	if opts.DedupeImages && opts.SplitOn == "" && opts.HeadingSplit == "" {
+		return &output.UserError{Message: "--dedupe-images only applies to --split-on and --heading-split batches"}
+	}
+	return nil
+}
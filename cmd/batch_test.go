@@ -0,0 +1,22 @@
+package cmd
+
+import "testing"
+
+func TestValidateBatchFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        pageFileOptions
+		multiTarget bool
+		wantErr     bool
+	}{
+		{"split", pageFileOptions{SplitOn: "^---$", ContinueOnError: true, IgnoreFailures: true}, false, false},
+		{"targets", pageFileOptions{ContinueOnError: true, IgnoreFailures: true}, true, false},
+		{"single page", pageFileOptions{ContinueOnError: true}, false, true},
+		{"ignore without continue", pageFileOptions{IgnoreFailures: true}, true, true},
+	}
+	for _, tt := range tests {
+		if err := validateBatchFlags(tt.opts, tt.multiTarget); (err != nil) != tt.wantErr {
+			t.Errorf("%s: validateBatchFlags error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
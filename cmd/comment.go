@@ -2,6 +2,10 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
 	"strings"
 
 	"github.com/lox/notion-cli/internal/cli"
@@ -18,19 +22,22 @@ type CommentCmd struct {
 type CommentListCmd struct {
 	Page     string `arg:"" help:"Page URL, name, or ID"`
 	Resolved bool   `help:"Include resolved discussions"`
+	SinceID  string `help:"Only show comments created after this comment ID; the latest ID is printed to stderr for the next poll" name:"since-id" placeholder:"ID"`
 	JSON     bool   `help:"Output as JSON" short:"j"`
 }
 
+var commentCursorOutput io.Writer = os.Stderr
+
 type commentsGetter interface {
 	GetComments(context.Context, mcp.GetCommentsRequest) (*mcp.CommentsResponse, error)
 }
 
 func (c *CommentListCmd) Run(ctx *Context) error {
-	ctx.JSON = c.JSON
-	return runCommentList(ctx, c.Page, c.Resolved)
+	ctx.JSON = ctx.JSON || c.JSON
+	return runCommentList(ctx, c.Page, c.Resolved, c.SinceID)
 }
 
-func runCommentList(ctx *Context, page string, includeResolved bool) error {
+func runCommentList(ctx *Context, page string, includeResolved bool, sinceID string) error {
 	client, err := cli.RequireClient()
 	if err != nil {
 		return err
@@ -44,7 +51,9 @@ func runCommentList(ctx *Context, page string, includeResolved bool) error {
 		return err
 	}
 
-	req := buildCommentListRequest(pageID, includeResolved)
+	// The --since-id cursor may sit in a discussion resolved since the last
+	// poll, so look it up among all comments and drop resolved ones after.
+	req := buildCommentListRequest(pageID, includeResolved || sinceID != "")
 
 	mcpComments, err := loadAllComments(bgCtx, client, req)
 	if err != nil {
@@ -53,6 +62,18 @@ func runCommentList(ctx *Context, page string, includeResolved bool) error {
 	}
 
 	comments := convertComments(mcpComments)
+	if sinceID != "" {
+		latest := latestCommentID(comments)
+		comments, err = commentsSince(comments, sinceID)
+		if err != nil {
+			output.PrintError(err)
+			return err
+		}
+		_, _ = fmt.Fprintf(commentCursorOutput, "Latest comment ID: %s\n", latest)
+		if !includeResolved {
+			comments = unresolvedComments(comments)
+		}
+	}
 	if len(comments) > 0 {
 		if pageResult, err := client.FetchWithDiscussions(bgCtx, pageID); err == nil {
 			hydrateCommentContextsFromPageContent(pageResult.Content, comments)
@@ -62,6 +83,64 @@ func runCommentList(ctx *Context, page string, includeResolved bool) error {
 	return output.PrintComments(comments, ctx.JSON)
 }
 
+// commentOrder returns comment indexes sorted oldest first, keeping the
+// server's order for comments created at the same time.
+func commentOrder(comments []output.Comment) []int {
+	order := make([]int, len(comments))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return comments[order[a]].CreatedTime.Before(comments[order[b]].CreatedTime)
+	})
+	return order
+}
+
+// commentsSince returns the comments created after the comment sinceID,
+// keeping their original (discussion-grouped) order.
+func commentsSince(comments []output.Comment, sinceID string) ([]output.Comment, error) {
+	order := commentOrder(comments)
+	rank := make(map[int]int, len(order))
+	sinceRank := -1
+	for r, i := range order {
+		rank[i] = r
+		if comments[i].ID == sinceID {
+			sinceRank = r
+		}
+	}
+	if sinceRank < 0 {
+		return nil, &output.UserError{Message: "comment " + sinceID + " not found on this page; it may have been deleted"}
+	}
+
+	newer := make([]output.Comment, 0)
+	for i, c := range comments {
+		if rank[i] > sinceRank {
+			newer = append(newer, c)
+		}
+	}
+	return newer, nil
+}
+
+// unresolvedComments returns the comments whose discussion is still open.
+func unresolvedComments(comments []output.Comment) []output.Comment {
+	open := make([]output.Comment, 0, len(comments))
+	for _, c := range comments {
+		if !c.Resolved {
+			open = append(open, c)
+		}
+	}
+	return open
+}
+
+// latestCommentID returns the ID of the most recently created comment.
+func latestCommentID(comments []output.Comment) string {
+	order := commentOrder(comments)
+	if len(order) == 0 {
+		return ""
+	}
+	return comments[order[len(order)-1]].ID
+}
+
 func buildCommentListRequest(pageID string, includeResolved bool) mcp.GetCommentsRequest {
 	return mcp.GetCommentsRequest{
 		PageID:           pageID,
@@ -146,6 +225,25 @@ func hydrateCommentAuthors(ctx context.Context, client *mcp.Client, comments []o
 	}
 }
 
+// mentionUserNames looks up the users mentioned in page content without a
+// name, so they render as @Name instead of @user. Users that cannot be
+// looked up are left out.
+func mentionUserNames(ctx context.Context, client *mcp.Client, content string) map[string]string {
+	ids := output.UnnamedMentionUserIDs(content)
+	if len(ids) == 0 {
+		return nil
+	}
+	names := make(map[string]string, len(ids))
+	for _, id := range ids {
+		user, err := client.GetUser(ctx, id)
+		if err != nil || user == nil || user.Name == "" {
+			continue
+		}
+		names[id] = user.Name
+	}
+	return names
+}
+
 func hydrateCommentContextsFromPageContent(pageContent string, comments []output.Comment) {
 	contextByDiscussion := extractDiscussionContexts(pageContent)
 	if len(contextByDiscussion) == 0 {
@@ -261,7 +359,7 @@ type CommentCreateCmd struct {
 }
 
 func (c *CommentCreateCmd) Run(ctx *Context) error {
-	ctx.JSON = c.JSON
+	ctx.JSON = ctx.JSON || c.JSON
 	return runCommentCreate(ctx, c.Page, c.Content)
 }
 
@@ -289,6 +387,7 @@ func runCommentCreate(ctx *Context, page, content string) error {
 		output.PrintError(err)
 		return err
 	}
+	recordAudit(ctx, "comment.create", pageID, comment.ID)
 
 	if ctx.JSON {
 		outComments := []output.Comment{{
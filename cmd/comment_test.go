@@ -5,6 +5,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/lox/notion-cli/internal/mcp"
 	"github.com/lox/notion-cli/internal/output"
@@ -203,3 +204,48 @@ func TestResolveCommentPageID(t *testing.T) {
 		})
 	}
 }
+
+func TestCommentsSinceKeepsNewerCommentsInThreadOrder(t *testing.T) {
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	comments := []output.Comment{
+		{ID: "a1", DiscussionID: "a", CreatedTime: base},
+		{ID: "a2", DiscussionID: "a", CreatedTime: base.Add(3 * time.Hour)},
+		{ID: "b1", DiscussionID: "b", CreatedTime: base.Add(time.Hour)},
+		{ID: "b2", DiscussionID: "b", CreatedTime: base.Add(2 * time.Hour)},
+	}
+
+	got, err := commentsSince(comments, "b1")
+	if err != nil {
+		t.Fatalf("commentsSince: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "a2" || got[1].ID != "b2" {
+		t.Fatalf("unexpected comments: %+v", got)
+	}
+	if latest := latestCommentID(comments); latest != "a2" {
+		t.Fatalf("latestCommentID = %q, want a2", latest)
+	}
+
+	var userErr *output.UserError
+	if _, err := commentsSince(comments, "missing"); !errors.As(err, &userErr) {
+		t.Fatalf("error = %v, want UserError", err)
+	}
+}
+
+func TestCommentsSinceFindsCursorInResolvedDiscussion(t *testing.T) {
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	comments := []output.Comment{
+		{ID: "a1", DiscussionID: "a", CreatedTime: base, Resolved: true},
+		{ID: "a2", DiscussionID: "a", CreatedTime: base.Add(2 * time.Hour), Resolved: true},
+		{ID: "b1", DiscussionID: "b", CreatedTime: base.Add(time.Hour)},
+		{ID: "b2", DiscussionID: "b", CreatedTime: base.Add(3 * time.Hour)},
+	}
+
+	got, err := commentsSince(comments, "a1")
+	if err != nil {
+		t.Fatalf("commentsSince: %v", err)
+	}
+	got = unresolvedComments(got)
+	if len(got) != 2 || got[0].ID != "b1" || got[1].ID != "b2" {
+		t.Fatalf("unexpected comments: %+v", got)
+	}
+}
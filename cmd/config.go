@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/lox/notion-cli/internal/config"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+type ConfigCmd struct {
+	Migrate ConfigMigrateCmd `cmd:"" help:"Upgrade config files to the current schema"`
+}
+
+type ConfigMigrateCmd struct {
+	All    bool `help:"Migrate every profile's config file"`
+	DryRun bool `help:"Report what would change without writing" name:"dry-run"`
+	JSON   bool `help:"Output as JSON" short:"j"`
+}
+
+var configOutput io.Writer = os.Stdout
+
+func (c *ConfigMigrateCmd) Run(ctx *Context) error {
+	ctx.JSON = ctx.JSON || c.JSON
+
+	profiles := []string{ctx.Profile}
+	if c.All {
+		all, err := config.ListProfiles()
+		if err != nil {
+			output.PrintError(err)
+			return err
+		}
+		profiles = all
+	}
+
+	results := make([]config.MigrationResult, 0, len(profiles))
+	for _, profile := range profiles {
+		result, err := config.Migrate(profile, c.DryRun)
+		if err != nil {
+			err = fmt.Errorf("migrate %s: %w", result.Path, err)
+			output.PrintError(err)
+			return err
+		}
+		results = append(results, result)
+	}
+	return printMigrationResults(configOutput, results, c.DryRun, ctx.JSON)
+}
+
+func printMigrationResults(w io.Writer, results []config.MigrationResult, dryRun, asJSON bool) error {
+	if asJSON {
+		return output.WriteStructured(w, results)
+	}
+
+	for _, result := range results {
+		if len(result.Changes) == 0 {
+			if _, err := fmt.Fprintf(w, "%s is up to date\n", result.Path); err != nil {
+				return err
+			}
+			continue
+		}
+		verb := "Migrated"
+		if dryRun {
+			verb = "Would migrate"
+		}
+		if _, err := fmt.Fprintf(w, "%s %s:\n", verb, result.Path); err != nil {
+			return err
+		}
+		for _, change := range result.Changes {
+			if _, err := fmt.Fprintf(w, "  %s\n", change); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
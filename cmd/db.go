@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"io"
 	"os"
 	"strings"
 
@@ -12,8 +13,10 @@ import (
 
 type DBCmd struct {
 	List   DBListCmd   `cmd:"" help:"List databases"`
+	View   DBViewCmd   `cmd:"" help:"Show a database's schema and views"`
 	Query  DBQueryCmd  `cmd:"" help:"Query a database"`
 	Create DBCreateCmd `cmd:"" help:"Create an entry in a database"`
+	Update DBUpdateCmd `cmd:"" help:"Update properties on every matching row of a database"`
 }
 
 type DBListCmd struct {
@@ -23,7 +26,7 @@ type DBListCmd struct {
 }
 
 func (c *DBListCmd) Run(ctx *Context) error {
-	ctx.JSON = c.JSON
+	ctx.JSON = ctx.JSON || c.JSON
 	return runDBList(ctx, c.Query, c.Limit)
 }
 
@@ -65,30 +68,110 @@ func filterDatabases(results []mcp.SearchResult, limit int) []output.Database {
 }
 
 type DBQueryCmd struct {
-	ID   string `arg:"" help:"Database URL or ID"`
-	JSON bool   `help:"Output as JSON" short:"j"`
+	ID       string `arg:"" help:"Database URL or ID"`
+	JSON     bool   `help:"Output as JSON" short:"j"`
+	Flatten  bool   `help:"With --json, print every row with its properties as plain key/value pairs (uses the official API)"`
+	RichText string `help:"With --flatten, give title and text properties as plain text or as markdown keeping bold, italic, strikethrough, code, and links" name:"rich-text" enum:"plain,markdown" default:"plain"`
 }
 
 func (c *DBQueryCmd) Run(ctx *Context) error {
-	ctx.JSON = c.JSON
+	ctx.JSON = ctx.JSON || c.JSON
+	if c.Flatten && !ctx.JSON {
+		err := &output.UserError{Message: "--flatten requires --json"}
+		output.PrintError(err)
+		return err
+	}
+	if c.Flatten {
+		return runDBQueryRows(ctx, c.ID, c.RichText == "markdown")
+	}
+	if c.RichText != "plain" {
+		err := &output.UserError{Message: "--rich-text requires --flatten"}
+		output.PrintError(err)
+		return err
+	}
 	return runDBQuery(ctx, c.ID)
 }
 
+type DBViewCmd struct {
+	Database string `arg:"" help:"Database URL, ID, or name"`
+	JSON     bool   `help:"Output as JSON" short:"j"`
+}
+
+type dbViewJSON struct {
+	ID      string                  `json:"id"`
+	Title   string                  `json:"title"`
+	URL     string                  `json:"url,omitempty"`
+	Columns []output.DatabaseColumn `json:"columns"`
+	Views   []output.DatabaseView   `json:"views"`
+}
+
+func (c *DBViewCmd) Run(ctx *Context) error {
+	ctx.JSON = ctx.JSON || c.JSON
+	return runDBView(ctx, c.Database)
+}
+
+func runDBView(ctx *Context, database string) error {
+	client, err := cli.RequireClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	bgCtx := context.Background()
+	dbID, err := cli.ResolveDatabaseID(bgCtx, client, database)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	result, err := client.Fetch(bgCtx, dbID)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if !output.IsDatabaseContent(result.Content) {
+		err := &output.UserError{Message: "not a database: " + database + " (use 'notion-cli page view' for pages)"}
+		output.PrintError(err)
+		return err
+	}
+
+	if ctx.JSON {
+		return printDBView(os.Stdout, dbID, result)
+	}
+	return output.RenderPage(result.Content)
+}
+
+func printDBView(w io.Writer, dbID string, result *mcp.FetchResult) error {
+	schema := output.ParseDatabaseSchema(result.Content)
+	title := result.Title
+	if title == "" {
+		title = output.DatabaseTitle(result.Content)
+	}
+	return output.WriteStructured(w, dbViewJSON{
+		ID:      dbID,
+		Title:   title,
+		URL:     result.URL,
+		Columns: schema.Columns,
+		Views:   schema.Views,
+	})
+}
+
 type DBCreateCmd struct {
-	Database string   `arg:"" help:"Database URL, ID, or name"`
-	Title    string   `help:"Entry title" short:"t" required:""`
-	Prop     []string `help:"Property key=value (repeatable)" short:"P"`
-	Content  string   `help:"Inline markdown body" short:"c" xor:"body"`
-	File     string   `help:"Read body from markdown file" short:"f" type:"existingfile" xor:"body"`
-	JSON     bool     `help:"Output as JSON" short:"j"`
+	Database      string   `arg:"" help:"Database URL, ID, or name"`
+	Title         string   `help:"Entry title" short:"t" required:""`
+	TitleProperty string   `help:"Name of the database's title property (default: detected from the schema)" name:"title-property"`
+	Prop          []string `help:"Property key=value (repeatable)" short:"P"`
+	Content       string   `help:"Inline markdown body" short:"c" xor:"body"`
+	File          string   `help:"Read body from markdown file" short:"f" type:"existingfile" xor:"body"`
+	JSON          bool     `help:"Output as JSON" short:"j"`
 }
 
 func (c *DBCreateCmd) Run(ctx *Context) error {
-	ctx.JSON = c.JSON
-	return runDBCreate(ctx, c.Database, c.Title, c.Prop, c.Content, c.File)
+	ctx.JSON = ctx.JSON || c.JSON
+	return runDBCreate(ctx, c.Database, c.Title, c.TitleProperty, c.Prop, c.Content, c.File)
 }
 
-func runDBCreate(ctx *Context, database, title string, props []string, content, file string) error {
+func runDBCreate(ctx *Context, database, title, titleProperty string, props []string, content, file string) error {
 	if file != "" {
 		data, err := os.ReadFile(file)
 		if err != nil {
@@ -112,13 +195,12 @@ func runDBCreate(ctx *Context, database, title string, props []string, content,
 		return err
 	}
 
-	dbID, err = client.ResolveDataSourceID(bgCtx, dbID)
-	if err != nil {
-		output.PrintError(err)
-		return err
+	dbID, schema := resolveDataSource(bgCtx, client, dbID)
+	if titleProperty == "" {
+		titleProperty = schemaTitleProperty(schema)
 	}
 
-	properties := make(map[string]string)
+	properties := make(map[string]any)
 	for _, p := range props {
 		k, v, ok := strings.Cut(p, "=")
 		if !ok {
@@ -127,12 +209,21 @@ func runDBCreate(ctx *Context, database, title string, props []string, content,
 		}
 		properties[k] = v
 	}
+	if err := expandDateProperties(properties, schema); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if err := resolveRelationProperties(bgCtx, client, cli.ResolvePageID, properties, schema); err != nil {
+		output.PrintError(err)
+		return err
+	}
 
 	req := mcp.CreatePageRequest{
 		ParentDatabaseID: dbID,
 		Title:            title,
 		Content:          content,
 		Properties:       properties,
+		TitleProperty:    titleProperty,
 	}
 
 	resp, err := client.CreatePage(bgCtx, req)
@@ -140,6 +231,7 @@ func runDBCreate(ctx *Context, database, title string, props []string, content,
 		output.PrintError(err)
 		return err
 	}
+	recordAudit(ctx, "db.create", pageIDFromCreateResponse(resp), title)
 
 	if ctx.JSON {
 		outPage := output.Page{
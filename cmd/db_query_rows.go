@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/lox/notion-cli/internal/api"
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// dbQueryRow is one row of db query --json --flatten. Properties are nested
+// rather than merged into the row so a column named "id" or "url" cannot
+// clash with the page's own fields.
+type dbQueryRow struct {
+	ID         string         `json:"id"`
+	URL        string         `json:"url,omitempty"`
+	Properties map[string]any `json:"properties"`
+}
+
+var dbQueryOutput io.Writer = os.Stdout
+
+// runDBQueryRows prints every row of a database, with properties decoded
+// by cli.FlattenProperties, through the official API query endpoint. With
+// markdown, title and text properties keep their annotations as markdown.
+func runDBQueryRows(ctx *Context, database string, markdown bool) error {
+	client, err := cli.RequireClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	bgCtx := context.Background()
+	dbID, err := cli.ResolveDatabaseID(bgCtx, client, database)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	dataSourceID, _ := resolveDataSource(bgCtx, client, dbID)
+
+	apiClient, err := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx), api.FeatureDataSources)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	pages, err := apiClient.QueryDataSource(bgCtx, dataSourceID, nil, 0)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if err := completeRelations(bgCtx, apiClient, pages); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	return output.WriteStructured(dbQueryOutput, flattenQueryRows(pages, markdown))
+}
+
+func flattenQueryRows(pages []api.QueriedPage, markdown bool) []dbQueryRow {
+	flatten := cli.FlattenProperties
+	if markdown {
+		flatten = cli.FlattenPropertiesMarkdown
+	}
+	rows := make([]dbQueryRow, 0, len(pages))
+	for _, p := range pages {
+		rows = append(rows, dbQueryRow{
+			ID:         p.ID,
+			URL:        p.URL,
+			Properties: flatten(p.Properties),
+		})
+	}
+	return rows
+}
+
+type propertyItemLister interface {
+	RetrievePagePropertyItems(ctx context.Context, pageID, propertyID string) ([]any, error)
+}
+
+// completeRelations fills in the relations a query response cut short
+// (Notion lists at most 25 related pages and sets has_more), reading every
+// related page through the property items endpoint.
+func completeRelations(ctx context.Context, lister propertyItemLister, pages []api.QueriedPage) error {
+	for i := range pages {
+		for name, prop := range pages[i].Properties {
+			if prop.Type != "relation" || !prop.HasMore {
+				continue
+			}
+			items, err := lister.RetrievePagePropertyItems(ctx, pages[i].ID, prop.ID)
+			if err != nil {
+				return fmt.Errorf("read relation %q of %s: %w", name, pages[i].ID, err)
+			}
+			prop.Relation = relationItems(items)
+			prop.HasMore = false
+			pages[i].Properties[name] = prop
+		}
+	}
+	return nil
+}
+
+// relationItems picks the related page IDs out of relation property items.
+func relationItems(items []any) []api.Relation {
+	relations := make([]api.Relation, 0, len(items))
+	for _, item := range items {
+		fields, _ := item.(map[string]any)
+		relation, _ := fields["relation"].(map[string]any)
+		if id, _ := relation["id"].(string); id != "" {
+			relations = append(relations, api.Relation{ID: id})
+		}
+	}
+	return relations
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/api"
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+func TestPrintDBViewJSONIncludesSchemaAndViews(t *testing.T) {
+	content := `<database url="{{https://www.notion.so/db123}}">
+The title of this Database is: Tasks
+<data-source-state>
+{"name":"Tasks","schema":{"Status":{"name":"Status","type":"status","options":[{"name":"Done"}]},"Name":{"name":"Name","type":"title"}}}
+</data-source-state>
+<views>
+<view url="{{view://1}}">
+{"name":"Board","type":"board"}
+</view>
+</views>
+</database>`
+
+	var buf bytes.Buffer
+	if err := printDBView(&buf, "db-123", &mcp.FetchResult{Content: content}); err != nil {
+		t.Fatalf("printDBView: %v", err)
+	}
+
+	var got dbViewJSON
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, buf.String())
+	}
+	if got.ID != "db-123" || got.Title != "Tasks" {
+		t.Fatalf("unexpected header: %+v", got)
+	}
+	if len(got.Columns) != 2 || got.Columns[0].Name != "Name" || got.Columns[1].Type != "status" {
+		t.Fatalf("unexpected columns: %+v", got.Columns)
+	}
+	if len(got.Columns[1].Options) != 1 || got.Columns[1].Options[0] != "Done" {
+		t.Fatalf("unexpected options: %+v", got.Columns[1])
+	}
+	if len(got.Views) != 1 || got.Views[0].Name != "Board" {
+		t.Fatalf("unexpected views: %+v", got.Views)
+	}
+}
+
+func TestSchemaTitlePropertyDetectsTitleColumn(t *testing.T) {
+	content := `<database url="{{https://www.notion.so/db123}}">
+<data-source url="{{collection://2f0a1b2c-3d4e-5f60-7182-93a4b5c6d7e8}}">
+<data-source-state>
+{"name":"Tasks","schema":{"Status":{"name":"Status","type":"status"},"Task name":{"name":"Task name","type":"title"}}}
+</data-source-state>
+</data-source>
+</database>`
+
+	if got := schemaTitleProperty(output.ParseDatabaseSchema(content)); got != "Task name" {
+		t.Fatalf("schemaTitleProperty = %q, want %q", got, "Task name")
+	}
+	if got := mcp.DataSourceIDFromContent(content); got != "2f0a1b2c-3d4e-5f60-7182-93a4b5c6d7e8" {
+		t.Fatalf("DataSourceIDFromContent = %q", got)
+	}
+	if got := schemaTitleProperty(output.ParseDatabaseSchema("<database></database>")); got != "" {
+		t.Fatalf("schemaTitleProperty without schema = %q, want empty", got)
+	}
+}
+
+func TestExpandDatePropertiesForDateColumns(t *testing.T) {
+	schema := output.DatabaseSchema{Columns: []output.DatabaseColumn{
+		{Name: "Due", Type: "date"},
+		{Name: "Sprint", Type: "date"},
+		{Name: "Status", Type: "status"},
+	}}
+	props := map[string]any{
+		"Due":    "2024-03-01T09:30",
+		"Sprint": "2024-03-01..2024-03-05",
+		"Status": "2024-03-01",
+	}
+	if err := expandDateProperties(props, schema); err != nil {
+		t.Fatalf("expandDateProperties: %v", err)
+	}
+
+	want := map[string]any{
+		"date:Due:start":          "2024-03-01T09:30",
+		"date:Due:is_datetime":    1,
+		"date:Sprint:start":       "2024-03-01",
+		"date:Sprint:end":         "2024-03-05",
+		"date:Sprint:is_datetime": 0,
+		"Status":                  "2024-03-01",
+	}
+	if len(props) != len(want) {
+		t.Fatalf("props = %#v, want %#v", props, want)
+	}
+	for k, v := range want {
+		if props[k] != v {
+			t.Fatalf("props[%q] = %#v, want %#v", k, props[k], v)
+		}
+	}
+}
+
+func TestExpandDatePropertiesRejectsInvalidDate(t *testing.T) {
+	schema := output.DatabaseSchema{Columns: []output.DatabaseColumn{{Name: "Due", Type: "date"}}}
+	err := expandDateProperties(map[string]any{"Due": "next friday"}, schema)
+	var userErr *output.UserError
+	if !errors.As(err, &userErr) {
+		t.Fatalf("error = %v, want UserError", err)
+	}
+}
+
+func TestResolveRelationPropertiesBuildsPageURLs(t *testing.T) {
+	schema := output.DatabaseSchema{Columns: []output.DatabaseColumn{
+		{Name: "Project", Type: "relation"},
+		{Name: "Raw", Type: "relation"},
+		{Name: "Notes", Type: "text"},
+	}}
+	props := map[string]any{
+		"Project": "Launch plan, 12345678abcdef1234567890abcdef12",
+		"Raw":     `["https://www.notion.so/abc"]`,
+		"Notes":   "Launch plan",
+	}
+	resolve := func(_ context.Context, _ *mcp.Client, input string) (string, error) {
+		switch input {
+		case "Launch plan":
+			return "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee", nil
+		case "12345678abcdef1234567890abcdef12":
+			return "12345678-abcd-ef12-3456-7890abcdef12", nil
+		}
+		return "", fmt.Errorf("unexpected input %q", input)
+	}
+
+	if err := resolveRelationProperties(context.Background(), nil, resolve, props, schema); err != nil {
+		t.Fatalf("resolveRelationProperties: %v", err)
+	}
+	want := `["https://www.notion.so/aaaaaaaabbbbccccddddeeeeeeeeeeee","https://www.notion.so/12345678abcdef1234567890abcdef12"]`
+	if props["Project"] != want {
+		t.Fatalf("Project = %#v, want %s", props["Project"], want)
+	}
+	if props["Raw"] != `["https://www.notion.so/abc"]` || props["Notes"] != "Launch plan" {
+		t.Fatalf("unexpected props: %#v", props)
+	}
+}
+
+func TestResolveRelationPropertiesReportsUnresolvedPage(t *testing.T) {
+	schema := output.DatabaseSchema{Columns: []output.DatabaseColumn{{Name: "Project", Type: "relation"}}}
+	resolve := func(_ context.Context, _ *mcp.Client, input string) (string, error) {
+		return "", &output.UserError{Message: "page not found: " + input}
+	}
+	err := resolveRelationProperties(context.Background(), nil, resolve, map[string]any{"Project": "Missing"}, schema)
+	var userErr *output.UserError
+	if !errors.As(err, &userErr) {
+		t.Fatalf("error = %v, want UserError", err)
+	}
+}
+
+func TestDBQueryFlattenFlagsNeedJSON(t *testing.T) {
+	var userErr *output.UserError
+	if err := (&DBQueryCmd{ID: "db", Flatten: true, RichText: "plain"}).Run(&Context{}); !errors.As(err, &userErr) || userErr.Message != "--flatten requires --json" {
+		t.Fatalf("--flatten without --json: err = %v", err)
+	}
+	if err := (&DBQueryCmd{ID: "db", JSON: true, RichText: "markdown"}).Run(&Context{}); !errors.As(err, &userErr) || userErr.Message != "--rich-text requires --flatten" {
+		t.Fatalf("--rich-text without --flatten: err = %v", err)
+	}
+}
+
+type fakePropertyItemLister map[string][]any
+
+func (f fakePropertyItemLister) RetrievePagePropertyItems(_ context.Context, pageID, propertyID string) ([]any, error) {
+	items, ok := f[pageID+"/"+propertyID]
+	if !ok {
+		return nil, errors.New("unexpected property read: " + pageID + "/" + propertyID)
+	}
+	return items, nil
+}
+
+func TestCompleteRelationsReadsTruncatedRelations(t *testing.T) {
+	pages := []api.QueriedPage{{
+		ID: "row",
+		Properties: map[string]api.PageProperty{
+			"Tasks":  {ID: "abc", Type: "relation", Relation: []api.Relation{{ID: "t1"}}, HasMore: true},
+			"Owners": {ID: "def", Type: "relation", Relation: []api.Relation{{ID: "o1"}}},
+		},
+	}}
+	lister := fakePropertyItemLister{"row/abc": {
+		map[string]any{"type": "relation", "relation": map[string]any{"id": "t1"}},
+		map[string]any{"type": "relation", "relation": map[string]any{"id": "t2"}},
+	}}
+	if err := completeRelations(context.Background(), lister, pages); err != nil {
+		t.Fatalf("completeRelations: %v", err)
+	}
+	row := flattenQueryRows(pages, false)[0]
+	if got := fmt.Sprint(row.Properties["Tasks"], row.Properties["Owners"]); got != "[t1 t2] [o1]" {
+		t.Fatalf("relations = %s", got)
+	}
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/lox/notion-cli/internal/api"
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+type DBUpdateCmd struct {
+	Database string   `arg:"" help:"Database URL, ID, or name"`
+	Where    []string `help:"Only update rows whose property equals a value, NAME=VALUE (repeatable, all must match)" placeholder:"NAME=VALUE"`
+	Set      []string `help:"Property to set, NAME=VALUE (repeatable)" required:"" placeholder:"NAME=VALUE"`
+	DryRun   bool     `help:"List the rows that would be updated without changing them" name:"dry-run"`
+	Yes      bool     `help:"Update without asking for confirmation" short:"y"`
+	JSON     bool     `help:"Output as JSON" short:"j"`
+}
+
+var (
+	dbUpdateInput  io.Reader = os.Stdin
+	dbUpdateOutput io.Writer = os.Stderr
+)
+
+func (c *DBUpdateCmd) Run(ctx *Context) error {
+	ctx.JSON = ctx.JSON || c.JSON
+	return runDBUpdate(ctx, c.Database, c.Where, c.Set, c.DryRun, c.Yes)
+}
+
+// dbUpdateRow is one matched row in db update's JSON output.
+type dbUpdateRow struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	URL    string `json:"url,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type pagePropertiesUpdater interface {
+	UpdatePageProperties(ctx context.Context, pageID string, properties map[string]any) error
+}
+
+func runDBUpdate(ctx *Context, database string, where, set []string, dryRun, yes bool) error {
+	client, err := cli.RequireClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	bgCtx := context.Background()
+	dbID, err := cli.ResolveDatabaseID(bgCtx, client, database)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	dataSourceID, _ := resolveDataSource(bgCtx, client, dbID)
+
+	apiClient, err := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx), api.FeatureDataSources)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	ds, err := apiClient.GetDataSource(bgCtx, dataSourceID)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	filter, err := buildWhereFilter(ds, where)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	updates, err := buildPropertyUpdates(ds, set)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	pages, err := apiClient.QueryDataSource(bgCtx, dataSourceID, filter, 0)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	if dryRun || len(pages) == 0 {
+		return printDBUpdateRows(ctx, pages, dryRun)
+	}
+	if !yes {
+		if err := confirmBulkUpdate(ctx, dbUpdateInput, dbUpdateOutput, len(pages)); err != nil {
+			output.PrintError(err)
+			return err
+		}
+	}
+
+	rows, result := applyBulkUpdate(bgCtx, apiClient, pages, updates)
+	for _, row := range rows {
+		if row.Status == "updated" {
+			recordAudit(ctx, "db.update", row.ID, strings.Join(set, ", "))
+		}
+	}
+	if ctx.JSON {
+		if err := output.WriteStructured(os.Stdout, rows); err != nil {
+			return err
+		}
+	}
+	printBatchSummary(result, ctx.JSON)
+	return result.Err(false)
+}
+
+// buildWhereFilter turns --where NAME=VALUE pairs into a query filter; more
+// than one pair must all match. No pairs means every row.
+func buildWhereFilter(ds *api.DataSource, where []string) (map[string]any, error) {
+	filters := make([]map[string]any, 0, len(where))
+	for _, w := range where {
+		name, value, err := parsePropertyPair("--where", w)
+		if err != nil {
+			return nil, err
+		}
+		schemaName, prop, ok := lookupDataSourceProperty(ds, name)
+		if !ok {
+			return nil, &output.UserError{Message: "database has no property named " + name}
+		}
+		filter, err := api.PropertyEqualsFilter(schemaName, prop, value)
+		if err != nil {
+			return nil, &output.UserError{Message: err.Error()}
+		}
+		filters = append(filters, filter)
+	}
+
+	switch len(filters) {
+	case 0:
+		return nil, nil
+	case 1:
+		return filters[0], nil
+	default:
+		return map[string]any{"and": filters}, nil
+	}
+}
+
+// buildPropertyUpdates converts --set NAME=VALUE pairs into API property
+// values using the database schema.
+func buildPropertyUpdates(ds *api.DataSource, set []string) (map[string]any, error) {
+	updates := make(map[string]any, len(set))
+	for _, s := range set {
+		name, value, err := parsePropertyPair("--set", s)
+		if err != nil {
+			return nil, err
+		}
+		schemaName, prop, ok := lookupDataSourceProperty(ds, name)
+		if !ok {
+			return nil, &output.UserError{Message: "database has no property named " + name}
+		}
+		v, err := api.PropertyValue(schemaName, prop, value)
+		if err != nil {
+			return nil, &output.UserError{Message: err.Error()}
+		}
+		updates[schemaName] = v
+	}
+	return updates, nil
+}
+
+func parsePropertyPair(flag, s string) (string, string, error) {
+	k, v, ok := strings.Cut(s, "=")
+	k = strings.TrimSpace(k)
+	if !ok || k == "" {
+		return "", "", &output.UserError{Message: "invalid " + flag + " format (expected NAME=VALUE): " + s}
+	}
+	return k, strings.TrimSpace(v), nil
+}
+
+// confirmBulkUpdate asks before changing n rows. Without a terminal to ask
+// on, or with --non-interactive, the update is refused unless --yes was
+// given.
+func confirmBulkUpdate(ctx *Context, in io.Reader, out io.Writer, n int) error {
+	return confirmAction(ctx, in, out, fmt.Sprintf("Update %d rows?", n), fmt.Sprintf("update %d rows", n), "update")
+}
+
+// applyBulkUpdate patches every page with the same property values, carrying
+// on past failures so one bad row does not stop the batch.
+func applyBulkUpdate(ctx context.Context, updater pagePropertiesUpdater, pages []api.QueriedPage, updates map[string]any) ([]dbUpdateRow, *cli.BatchResult) {
+	result := &cli.BatchResult{}
+	rows := make([]dbUpdateRow, 0, len(pages))
+	for _, p := range pages {
+		row := dbUpdateRow{ID: p.ID, Title: p.Title(), URL: p.URL, Status: "updated"}
+		if err := updater.UpdatePageProperties(ctx, p.ID, updates); err != nil {
+			row.Status = "failed"
+			row.Error = err.Error()
+			result.Fail(dbUpdateRowLabel(row), err)
+		} else {
+			result.Success()
+		}
+		rows = append(rows, row)
+	}
+	return rows, result
+}
+
+func printDBUpdateRows(ctx *Context, pages []api.QueriedPage, dryRun bool) error {
+	status := "matched"
+	if dryRun {
+		status = "would update"
+	}
+	rows := make([]dbUpdateRow, 0, len(pages))
+	for _, p := range pages {
+		rows = append(rows, dbUpdateRow{ID: p.ID, Title: p.Title(), URL: p.URL, Status: status})
+	}
+	if ctx.JSON {
+		return output.WriteStructured(os.Stdout, rows)
+	}
+
+	if len(rows) == 0 {
+		output.PrintInfo("No rows match")
+		return nil
+	}
+	for _, row := range rows {
+		fmt.Println(dbUpdateRowLabel(row))
+	}
+	output.PrintInfo(fmt.Sprintf("Dry run: %d rows would be updated", len(rows)))
+	return nil
+}
+
+func dbUpdateRowLabel(row dbUpdateRow) string {
+	if row.Title == "" {
+		return row.ID
+	}
+	return row.Title + " (" + row.ID + ")"
+}
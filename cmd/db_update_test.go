@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/api"
+)
+
+func dbUpdateTestSchema() *api.DataSource {
+	return &api.DataSource{Properties: map[string]api.DataSourceProperty{
+		"Name":   {Type: "title"},
+		"Status": {Type: "status"},
+		"Owner":  {Type: "rich_text"},
+	}}
+}
+
+func TestBuildWhereFilter(t *testing.T) {
+	ds := dbUpdateTestSchema()
+
+	filter, err := buildWhereFilter(ds, nil)
+	if err != nil || filter != nil {
+		t.Fatalf("no --where = %#v, %v", filter, err)
+	}
+
+	filter, err = buildWhereFilter(ds, []string{"status=In review"})
+	if err != nil {
+		t.Fatalf("buildWhereFilter: %v", err)
+	}
+	if filter["property"] != "Status" {
+		t.Fatalf("single filter = %#v", filter)
+	}
+
+	filter, err = buildWhereFilter(ds, []string{"Status=Done", "Owner=Ana"})
+	if err != nil {
+		t.Fatalf("buildWhereFilter: %v", err)
+	}
+	if and, ok := filter["and"].([]map[string]any); !ok || len(and) != 2 {
+		t.Fatalf("compound filter = %#v", filter)
+	}
+
+	if _, err := buildWhereFilter(ds, []string{"Missing=x"}); err == nil {
+		t.Fatal("expected error for unknown property")
+	}
+	if _, err := buildWhereFilter(ds, []string{"Status"}); err == nil {
+		t.Fatal("expected error without =")
+	}
+}
+
+func TestBuildPropertyUpdates(t *testing.T) {
+	updates, err := buildPropertyUpdates(dbUpdateTestSchema(), []string{"status=Done"})
+	if err != nil {
+		t.Fatalf("buildPropertyUpdates: %v", err)
+	}
+	status, ok := updates["Status"].(map[string]any)
+	if !ok {
+		t.Fatalf("updates = %#v", updates)
+	}
+	if inner, _ := status["status"].(map[string]any); inner["name"] != "Done" {
+		t.Fatalf("status = %#v", status)
+	}
+}
+
+func TestConfirmBulkUpdate(t *testing.T) {
+	var out bytes.Buffer
+	if err := confirmBulkUpdate(&Context{}, strings.NewReader("y\n"), &out, 3); err != nil {
+		t.Fatalf("confirm yes: %v", err)
+	}
+	if !strings.Contains(out.String(), "Update 3 rows?") {
+		t.Fatalf("prompt = %q", out.String())
+	}
+	if err := confirmBulkUpdate(&Context{}, strings.NewReader("\n"), &out, 3); err == nil {
+		t.Fatal("expected cancel on empty answer")
+	}
+}
+
+func TestConfirmBulkUpdateRefusesNonInteractive(t *testing.T) {
+	var out bytes.Buffer
+	err := confirmBulkUpdate(&Context{NonInteractive: true}, strings.NewReader("y\n"), &out, 3)
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("err = %v, want refusal mentioning --yes", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("prompted in non-interactive mode: %q", out.String())
+	}
+}
+
+type fakePropertiesUpdater struct {
+	fail    map[string]bool
+	updated []string
+}
+
+func (f *fakePropertiesUpdater) UpdatePageProperties(_ context.Context, pageID string, _ map[string]any) error {
+	if f.fail[pageID] {
+		return errors.New("conflict")
+	}
+	f.updated = append(f.updated, pageID)
+	return nil
+}
+
+func TestApplyBulkUpdateContinuesPastFailures(t *testing.T) {
+	updater := &fakePropertiesUpdater{fail: map[string]bool{"b": true}}
+	pages := []api.QueriedPage{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+
+	rows, result := applyBulkUpdate(context.Background(), updater, pages, map[string]any{"Status": nil})
+	if result.Succeeded != 2 || result.Failed() != 1 {
+		t.Fatalf("result = %s", result.Summary())
+	}
+	if len(updater.updated) != 2 || rows[1].Status != "failed" || rows[1].Error != "conflict" {
+		t.Fatalf("rows = %#v, updated = %v", rows, updater.updated)
+	}
+	if result.Err(false) == nil {
+		t.Fatal("expected batch error")
+	}
+}
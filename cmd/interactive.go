@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/lox/notion-cli/internal/output"
+
+	"golang.org/x/term"
+)
+
+// isInteractiveTerminal reports whether the user can be prompted on in.
+// --non-interactive makes it false even on a TTY, so CI runs take the same
+// path however their terminal is detected.
+func isInteractiveTerminal(ctx *Context, in io.Reader) bool {
+	if ctx != nil && ctx.NonInteractive {
+		return false
+	}
+	return isTerminalInput(in)
+}
+
+// isTerminalInput reports whether in is a terminal, ignoring
+// --non-interactive. Reading from it would wait for someone to type.
+func isTerminalInput(in io.Reader) bool {
+	f, ok := in.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// confirmAction asks question on out and reads a yes/no answer from in.
+// Without a terminal to ask on, or with --non-interactive, it refuses
+// rather than guess, telling the user to pass --yes to action. Any answer
+// other than y or yes cancels.
+func confirmAction(ctx *Context, in io.Reader, out io.Writer, question, action, noun string) error {
+	_, isFile := in.(*os.File)
+	if ctx.NonInteractive || (isFile && !isTerminalInput(in)) {
+		return &output.UserError{Message: "refusing to " + action + " without confirmation; pass --yes"}
+	}
+	_, _ = fmt.Fprint(out, question+" [y/N] ")
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return nil
+	}
+	return &output.UserError{Message: noun + " cancelled"}
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import "github.com/lox/notion-cli/internal/output"
+
+// applyListTemplate parses --template and installs it for list output.
+// It is rejected with --json, which already has a fixed shape.
+func applyListTemplate(ctx *Context, text string) error {
+	if text == "" {
+		return nil
+	}
+	if ctx.JSON {
+		return &output.UserError{Message: "--template cannot be combined with --json"}
+	}
+	tmpl, err := output.ParseListTemplate(text)
+	if err != nil {
+		return &output.UserError{Message: "invalid --template: " + err.Error()}
+	}
+	output.SetListTemplate(tmpl)
+	return nil
+}
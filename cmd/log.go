@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/lox/notion-cli/internal/config"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+type LogCmd struct {
+	Limit int  `help:"Maximum number of entries" short:"l" default:"20"`
+	JSON  bool `help:"Output as JSON" short:"j"`
+}
+
+var auditLogOutput io.Writer = os.Stdout
+
+func (c *LogCmd) Run(ctx *Context) error {
+	ctx.JSON = ctx.JSON || c.JSON
+
+	entries, err := config.ReadAuditEntries(c.Limit)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	return printAuditEntries(auditLogOutput, entries, ctx.JSON)
+}
+
+func printAuditEntries(w io.Writer, entries []config.AuditEntry, asJSON bool) error {
+	if asJSON {
+		if entries == nil {
+			entries = []config.AuditEntry{}
+		}
+		return output.WriteStructured(w, entries)
+	}
+
+	if len(entries) == 0 {
+		_, err := fmt.Fprintln(w, "No audit log entries. Set \"audit_log\": true in config.json to enable.")
+		return err
+	}
+
+	for _, e := range entries {
+		line := fmt.Sprintf("%s  %-14s %s", e.Time.Local().Format(time.RFC3339), e.Action, e.Target)
+		if e.Detail != "" {
+			line += "  " + e.Detail
+		}
+		if e.Profile != "" && e.Profile != config.DefaultProfile() {
+			line += "  [" + e.Profile + "]"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordAudit appends a local audit entry for a mutating command when the
+// active profile has audit_log enabled. Failures never fail the command.
+func recordAudit(ctx *Context, action, target, detail string) {
+	profile := ""
+	if ctx != nil {
+		profile = ctx.Profile
+	}
+	loaded, err := config.LoadWithMeta(config.APIOverrides{Profile: profile})
+	if err != nil || !loaded.Config.AuditLogEnabled() {
+		return
+	}
+	if err := config.AppendAuditEntry(config.AuditEntry{
+		Profile: loaded.Profile,
+		Action:  action,
+		Target:  target,
+		Detail:  detail,
+	}); err != nil {
+		printWarningFn("Unable to write audit log: " + err.Error())
+	}
+}
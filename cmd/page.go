@@ -7,6 +7,9 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/lox/notion-cli/internal/api"
 	"github.com/lox/notion-cli/internal/cli"
@@ -15,26 +18,49 @@ import (
 )
 
 type PageCmd struct {
-	List   PageListCmd   `cmd:"" help:"List pages"`
-	View   PageViewCmd   `cmd:"" help:"View a page"`
-	Create PageCreateCmd `cmd:"" help:"Create a page"`
-	Upload PageUploadCmd `cmd:"" help:"Upload a markdown file as a page"`
-	Sync   PageSyncCmd   `cmd:"" help:"Sync a markdown file to a page (create or update)"`
-	Edit   PageEditCmd   `cmd:"" help:"Edit a page"`
+	List     PageListCmd     `cmd:"" help:"List pages"`
+	View     PageViewCmd     `cmd:"" help:"View a page"`
+	Create   PageCreateCmd   `cmd:"" help:"Create a page"`
+	Upload   PageUploadCmd   `cmd:"" help:"Upload a markdown file as a page"`
+	Sync     PageSyncCmd     `cmd:"" help:"Sync a markdown file to a page (create or update)"`
+	Edit     PageEditCmd     `cmd:"" help:"Edit a page"`
+	Children PageChildrenCmd `cmd:"" help:"List the child blocks of a page"`
+	Export   PageExportCmd   `cmd:"" help:"Export a page as markdown"`
+	Stats    PageStatsCmd    `cmd:"" help:"Count a page's blocks, words, images, and links"`
 }
 
 var loadPageViewCommentsFn = loadPageViewComments
 var printViewedPageFn = output.PrintViewedPage
+var printPlainViewedPageFn = output.PrintPlainViewedPage
 var printWarningFn = output.PrintWarning
 
 type PageListCmd struct {
-	Query string `help:"Filter pages by name" short:"q"`
-	Limit int    `help:"Maximum number of results" short:"l" default:"20"`
-	JSON  bool   `help:"Output as JSON" short:"j"`
+	Query        string `help:"Filter pages by name" short:"q"`
+	Database     string `help:"List the pages in this database (URL, name, or ID) instead of searching the workspace" placeholder:"REF"`
+	CreatedAfter string `help:"Only pages created after this time (RFC3339, YYYY-MM-DD, or relative like 7d, 2w)" name:"created-after" placeholder:"TIME"`
+	EditedAfter  string `help:"Only pages edited after this time (RFC3339, YYYY-MM-DD, or relative like 7d, 2w)" name:"edited-after" placeholder:"TIME"`
+	Limit        int    `help:"Maximum number of results" short:"l" default:"20"`
+	JSON         bool   `help:"Output as JSON" short:"j"`
+	Template     string `help:"Print each page with a Go template, e.g. '{{.Title}}\\t{{.URL}}'" aliases:"output-template" placeholder:"TMPL"`
 }
 
 func (c *PageListCmd) Run(ctx *Context) error {
-	ctx.JSON = c.JSON
+	ctx.JSON = ctx.JSON || c.JSON
+	if err := applyListTemplate(ctx, c.Template); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	filter, err := parseTimeFilter(c.CreatedAfter, c.EditedAfter, time.Now())
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if c.Database != "" {
+		return runPageListDatabase(ctx, c.Database, c.Query, c.Limit, filter)
+	}
+	if filter.active() {
+		return runPageListTimed(ctx, c.Query, c.Limit, filter)
+	}
 	return runPageList(ctx, c.Query, c.Limit)
 }
 
@@ -57,6 +83,24 @@ func runPageList(ctx *Context, query string, limit int) error {
 	return output.PrintPages(pages, ctx.JSON)
 }
 
+// runPageListTimed lists pages through the official API search, which
+// returns the timestamps needed for --created-after and --edited-after.
+func runPageListTimed(ctx *Context, query string, limit int, filter timeFilter) error {
+	results, err := searchWithTimestamps(ctx, query, filter)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	pages := make([]api.QueriedPage, 0, len(results))
+	for _, r := range results {
+		if r.Object == "page" {
+			pages = append(pages, r)
+		}
+	}
+	return output.PrintPages(queriedPagesToOutput(pages, "", limit), ctx.JSON)
+}
+
 func filterPages(results []mcp.SearchResult, limit int) []output.Page {
 	pages := make([]output.Page, 0)
 	for _, r := range results {
@@ -76,18 +120,143 @@ func filterPages(results []mcp.SearchResult, limit int) []output.Page {
 }
 
 type PageViewCmd struct {
-	Page     string `arg:"" help:"Page URL, name, or ID"`
-	Comments bool   `help:"Show open page and block comments" default:"true" negatable:""`
-	JSON     bool   `help:"Output as JSON" short:"j"`
-	Raw      bool   `help:"Output raw Notion response without formatting" short:"r"`
+	Page       string `arg:"" help:"Page URL, name, or ID"`
+	Comments   bool   `help:"Show open page and block comments" default:"true" negatable:"" aliases:"include-comments"`
+	JSON       bool   `help:"Output as JSON" short:"j"`
+	Raw        bool   `help:"Output raw Notion response without formatting" short:"r" xor:"format"`
+	Plain      bool   `help:"Render without colors, ANSI styling, or line wrapping, even on a terminal" xor:"format"`
+	Pretty     bool   `help:"With --raw, indent the tag structure of the raw response"`
+	Normalize  bool   `help:"With --raw, convert CRLF line endings and literal \\n and \\t escapes to real newlines and tabs"`
+	MaxLines   int    `help:"Truncate the rendered body after this many lines" name:"max-lines" aliases:"max-blocks" placeholder:"N"`
+	Truncate   int    `help:"Truncate the rendered body after this many characters" placeholder:"N"`
+	Backlinks  bool   `help:"Also list pages that link to this one (approximate: found through search)"`
+	NoHeader   bool   `help:"Print only the body, without the title and metadata header" name:"no-header" aliases:"no-metadata-header"`
+	DownloadTo string `help:"Also download the page's file, PDF, audio, and video attachments into this directory" name:"download-to" placeholder:"DIR"`
+	IDOnly     bool   `help:"Print only the resolved page ID, without fetching the page" name:"id-only"`
+
+	RawContentFile string `help:"Also write the unprocessed page content from the server to this file" name:"raw-content-file" placeholder:"PATH"`
+	RawJSON        string `help:"Also write the full notion-fetch tool result as JSON to this file" name:"raw-json" placeholder:"PATH"`
+}
+
+// checkIDOnly rejects flags that --id-only would ignore, since it prints
+// the resolved ID without fetching or rendering the page.
+func (c *PageViewCmd) checkIDOnly() error {
+	if !c.IDOnly {
+		return nil
+	}
+	var conflicts []string
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"--raw", c.Raw},
+		{"--plain", c.Plain},
+		{"--pretty", c.Pretty},
+		{"--normalize", c.Normalize},
+		{"--max-lines", c.MaxLines != 0},
+		{"--truncate", c.Truncate != 0},
+		{"--backlinks", c.Backlinks},
+		{"--no-header", c.NoHeader},
+		{"--download-to", c.DownloadTo != ""},
+		{"--raw-content-file", c.RawContentFile != ""},
+		{"--raw-json", c.RawJSON != ""},
+	} {
+		if f.set {
+			conflicts = append(conflicts, f.name)
+		}
+	}
+	if len(conflicts) > 0 {
+		return &output.UserError{Message: "--id-only prints the page ID without fetching the page, so it cannot be combined with " + strings.Join(conflicts, ", ")}
+	}
+	return nil
+}
+
+// pageViewOptions carries the page view flags that shape rendering.
+type pageViewOptions struct {
+	Raw      bool
+	Comments bool
+	Plain    bool
+	Pretty   bool
+	// Normalize cleans up line endings and escapes in --raw output.
+	Normalize bool
+	MaxLines  int
+	Truncate  int
+	NoHeader  bool
+	// Backlinks searches for and lists pages that link to the viewed page.
+	Backlinks bool
+	// DownloadTo is a directory to save the page's file attachments in.
+	DownloadTo string
+	// IDOnly prints the resolved page ID instead of the page.
+	IDOnly bool
+
+	RawContentFile string
+	RawJSON        string
 }
 
 func (c *PageViewCmd) Run(ctx *Context) error {
-	ctx.JSON = c.JSON
-	return runPageView(ctx, c.Page, c.Raw, c.Comments)
+	ctx.JSON = ctx.JSON || c.JSON
+	if err := c.checkIDOnly(); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if err := applyPageOutput(ctx, false, c.IDOnly); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	return runPageView(ctx, c.Page, pageViewOptions{
+		Raw:      c.Raw,
+		Comments: c.Comments,
+		Plain:    c.Plain,
+		Pretty:   c.Pretty,
+		MaxLines: c.MaxLines,
+
+		Normalize: c.Normalize,
+		Truncate:  c.Truncate,
+		NoHeader:  c.NoHeader,
+
+		Backlinks:      c.Backlinks,
+		DownloadTo:     c.DownloadTo,
+		IDOnly:         c.IDOnly,
+		RawContentFile: c.RawContentFile,
+		RawJSON:        c.RawJSON,
+	})
 }
 
-func runPageView(ctx *Context, page string, raw, includeComments bool) error {
+func runPageView(ctx *Context, page string, opts pageViewOptions) error {
+	if opts.Pretty && !opts.Raw {
+		err := &output.UserError{Message: "--pretty requires --raw"}
+		output.PrintError(err)
+		return err
+	}
+	if opts.Normalize && (!opts.Raw || ctx.JSON) {
+		err := &output.UserError{Message: "--normalize requires --raw and cannot be combined with --json"}
+		output.PrintError(err)
+		return err
+	}
+	if opts.MaxLines < 0 || (opts.MaxLines > 0 && (opts.Raw || ctx.JSON)) {
+		err := &output.UserError{Message: "--max-lines must be positive and only applies to the rendered view, not --raw or --json"}
+		output.PrintError(err)
+		return err
+	}
+	if opts.Truncate < 0 || (opts.Truncate > 0 && (opts.Raw || ctx.JSON)) {
+		err := &output.UserError{Message: "--truncate must be positive and only applies to the rendered view, not --raw or --json"}
+		output.PrintError(err)
+		return err
+	}
+	if opts.Backlinks && opts.Raw && !ctx.JSON {
+		err := &output.UserError{Message: "--backlinks only applies to the rendered view or --json, not --raw"}
+		output.PrintError(err)
+		return err
+	}
+	if opts.NoHeader && (opts.Raw || ctx.JSON) {
+		err := &output.UserError{Message: "--no-header only applies to the rendered view, not --raw or --json"}
+		output.PrintError(err)
+		return err
+	}
+	output.SetHidePageHeader(opts.NoHeader)
+	output.SetMaxBodyLines(opts.MaxLines)
+	output.SetMaxBodyChars(opts.Truncate)
+
 	client, err := cli.RequireClient()
 	if err != nil {
 		return err
@@ -102,9 +271,12 @@ func runPageView(ctx *Context, page string, raw, includeComments bool) error {
 		output.PrintError(err)
 		return err
 	}
+	if opts.IDOnly {
+		return output.PrintPage(output.Page{ID: fetchID}, true)
+	}
 
 	fetchPage := client.Fetch
-	if shouldLoadPageViewComments(raw, includeComments, ctx.JSON) {
+	if shouldLoadPageViewComments(opts.Raw, opts.Comments, ctx.JSON) {
 		fetchPage = client.FetchWithDiscussions
 	}
 
@@ -113,12 +285,36 @@ func runPageView(ctx *Context, page string, raw, includeComments bool) error {
 		output.PrintError(err)
 		return err
 	}
+	if err := saveRawFetch(result, opts); err != nil {
+		output.PrintError(err)
+		return err
+	}
 
-	return renderFetchedPageView(bgCtx, ctx, client, fetchID, result, raw, includeComments)
+	return renderFetchedPageView(bgCtx, ctx, client, fetchID, result, opts)
 }
 
-func renderFetchedPageView(bgCtx context.Context, ctx *Context, client *mcp.Client, fetchID string, result *mcp.FetchResult, raw, includeComments bool) error {
-	comments, err := loadPageViewCommentsFn(bgCtx, client, fetchID, result.Content, raw, includeComments, ctx.JSON)
+// saveRawFetch writes the server's response as received, for debugging and
+// bug reports, independent of how the page is rendered.
+func saveRawFetch(result *mcp.FetchResult, opts pageViewOptions) error {
+	if opts.RawContentFile != "" {
+		if err := os.WriteFile(opts.RawContentFile, []byte(result.Content), 0o644); err != nil {
+			return fmt.Errorf("write raw content: %w", err)
+		}
+	}
+	if opts.RawJSON != "" {
+		data, err := json.MarshalIndent(result.ToolResult, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode tool result: %w", err)
+		}
+		if err := os.WriteFile(opts.RawJSON, append(data, '\n'), 0o644); err != nil {
+			return fmt.Errorf("write raw JSON: %w", err)
+		}
+	}
+	return nil
+}
+
+func renderFetchedPageView(bgCtx context.Context, ctx *Context, client *mcp.Client, fetchID string, result *mcp.FetchResult, opts pageViewOptions) error {
+	comments, err := loadPageViewCommentsFn(bgCtx, client, fetchID, result.Content, opts.Raw, opts.Comments, ctx.JSON)
 	if err != nil {
 		if !ctx.JSON {
 			printWarningFn("Unable to load comments: " + err.Error())
@@ -132,25 +328,73 @@ func renderFetchedPageView(bgCtx context.Context, ctx *Context, client *mcp.Clie
 		URL:     result.URL,
 		Content: result.Content,
 	}
+	if opts.Backlinks {
+		backlinks, err := findBacklinksFn(bgCtx, client, fetchID, result.Title)
+		if err != nil {
+			if !ctx.JSON {
+				printWarningFn("Unable to find backlinks: " + err.Error())
+			}
+			opts.Backlinks = false
+		}
+		pageOutput.Backlinks = backlinks
+	}
+	if opts.DownloadTo != "" {
+		attachments, err := downloadAttachmentsFn(ctx, bgCtx, fetchID, opts.DownloadTo)
+		if err != nil {
+			err = fmt.Errorf("download attachments: %w", err)
+			output.PrintError(err)
+			return err
+		}
+		pageOutput.Attachments = attachments
+	}
 
 	if ctx.JSON {
 		return printViewedPageFn(pageOutput, comments, true)
 	}
+	if opts.DownloadTo != "" {
+		// Report after the page on every path below, like --backlinks.
+		defer printAttachments(os.Stdout, pageOutput.Attachments)
+	}
 
-	if raw {
+	if opts.Raw {
+		if opts.Normalize {
+			result.Content = output.NormalizeRawMarkup(result.Content)
+		}
+		if opts.Pretty {
+			fmt.Println(output.PrettyNotionMarkup(result.Content))
+			return nil
+		}
+		if output.IsDatabaseContent(result.Content) {
+			// Raw database responses are mostly tagged JSON; summarise the schema and views instead.
+			fmt.Println(output.FormatDatabaseSummary(result.Content))
+			return nil
+		}
 		fmt.Println(result.Content)
 		return nil
 	}
 
+	output.SetMentionUserNames(mentionUserNames(bgCtx, client, result.Content))
+
 	if result.Content == "" {
 		printWarningFn("No content found")
 		if len(comments) == 0 {
+			if opts.Backlinks {
+				printBacklinks(os.Stdout, pageOutput.Backlinks)
+			}
 			return nil
 		}
 		fmt.Println()
 	}
 
-	return printViewedPageFn(pageOutput, comments, false)
+	if opts.Plain {
+		err = printPlainViewedPageFn(pageOutput, comments)
+	} else {
+		err = printViewedPageFn(pageOutput, comments, false)
+	}
+	if err == nil && opts.Backlinks {
+		printBacklinks(os.Stdout, pageOutput.Backlinks)
+	}
+	return err
 }
 
 func loadPageViewComments(ctx context.Context, client *mcp.Client, pageID, pageContent string, raw, includeComments, asJSON bool) ([]output.Comment, error) {
@@ -190,47 +434,152 @@ func resolveFetchID(ctx context.Context, page string, ref cli.PageRef, client *m
 }
 
 type PageCreateCmd struct {
-	Title   string `help:"Page title" short:"t" required:""`
-	Parent  string `help:"Parent page URL, name, or ID" short:"p"`
-	Content string `help:"Page content (markdown)" short:"c"`
-	JSON    bool   `help:"Output as JSON" short:"j"`
+	Title          string `help:"Page title" short:"t" required:""`
+	Parent         string `help:"Parent page URL, name, or ID, or \"workspace\" for the top level" short:"p" xor:"parent"`
+	ParentDB       string `help:"Parent database URL, name, or ID" name:"parent-db" short:"d" xor:"parent"`
+	TitleProperty  string `help:"Name of the --parent-db title property (default: detected from the schema)" name:"title-property"`
+	Content        string `help:"Page content (markdown)" short:"c"`
+	DedupProperty  string `help:"Update the existing database entry whose property NAME equals VALUE instead of creating a duplicate (requires --parent-db)" name:"dedup-property" placeholder:"NAME=VALUE"`
+	Icon           string `help:"Emoji icon for the page, or a shortcode like :rocket:" short:"i"`
+	Cover          string `help:"Cover image URL for the page" placeholder:"URL"`
+	IconFromParent bool   `help:"Copy the --parent page's icon to the new page (uses the official API)" name:"icon-from-parent"`
+	StripEmoji     bool   `help:"Remove emoji from the title" name:"strip-emoji"`
+	RequireContent bool   `help:"Fail instead of warning when --content is empty, to catch an unset variable in scripts" name:"require-content" xor:"empty"`
+	AllowEmpty     bool   `help:"Create a page with no content without warning" name:"allow-empty" xor:"empty"`
+	URLOnly        bool   `help:"Print only the page URL on success, for scripts; errors still go to stderr" name:"url-only" aliases:"silent-url"`
+	IDOnly         bool   `help:"Print only the page ID on success, for chaining commands" name:"id-only"`
+	JSON           bool   `help:"Output as JSON" short:"j"`
+}
+
+// pageCreateOptions carries the page create flags.
+type pageCreateOptions struct {
+	Title          string
+	Parent         string
+	ParentDB       string
+	TitleProperty  string
+	Content        string
+	DedupProperty  string
+	Icon           string
+	Cover          string
+	IconFromParent bool
+	StripEmoji     bool
+	// RequireContent and AllowEmpty turn the empty content warning into an
+	// error, or silence it.
+	RequireContent bool
+	AllowEmpty     bool
 }
 
 func (c *PageCreateCmd) Run(ctx *Context) error {
-	ctx.JSON = c.JSON
-	return runPageCreate(ctx, c.Title, c.Parent, c.Content)
+	ctx.JSON = ctx.JSON || c.JSON
+	if err := applyPageOutput(ctx, c.URLOnly, c.IDOnly); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	return runPageCreate(ctx, pageCreateOptions{
+		Title:          c.Title,
+		Parent:         c.Parent,
+		ParentDB:       c.ParentDB,
+		TitleProperty:  c.TitleProperty,
+		Content:        c.Content,
+		DedupProperty:  c.DedupProperty,
+		Icon:           c.Icon,
+		Cover:          c.Cover,
+		IconFromParent: c.IconFromParent,
+		StripEmoji:     c.StripEmoji,
+		RequireContent: c.RequireContent,
+		AllowEmpty:     c.AllowEmpty,
+	})
 }
 
-func runPageCreate(ctx *Context, title, parent, content string) error {
-	client, err := cli.RequireClient()
+func runPageCreate(ctx *Context, opts pageCreateOptions) error {
+	opts.Parent = applyDefaultParent(ctx, opts.Parent, opts.ParentDB)
+	if opts.StripEmoji {
+		opts.Title = cli.StripEmoji(opts.Title)
+	}
+	title, parent, parentDB, dedupProperty := opts.Title, opts.Parent, opts.ParentDB, opts.DedupProperty
+	if opts.IconFromParent && parent == "" {
+		err := &output.UserError{Message: "--icon-from-parent requires --parent"}
+		output.PrintError(err)
+		return err
+	}
+	if opts.IconFromParent && opts.Icon != "" {
+		err := &output.UserError{Message: "--icon cannot be combined with --icon-from-parent"}
+		output.PrintError(err)
+		return err
+	}
+	icon, err := cli.ParseIcon(opts.Icon)
 	if err != nil {
+		err = &output.UserError{Message: err.Error()}
+		output.PrintError(err)
+		return err
+	}
+	if err := checkCoverURL(opts.Cover); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if err := checkEmptyContent(opts, ctx.JSON); err != nil {
+		output.PrintError(err)
 		return err
 	}
-	defer func() { _ = client.Close() }()
-
-	bgCtx := context.Background()
 
-	parentID := parent
-	if parent != "" {
-		resolved, err := cli.ResolvePageID(bgCtx, client, parent)
+	var dedup *dedupKey
+	if dedupProperty != "" {
+		if parentDB == "" {
+			err := &output.UserError{Message: "--dedup-property requires --parent-db"}
+			output.PrintError(err)
+			return err
+		}
+		key, err := parseDedupProperty(dedupProperty)
 		if err != nil {
 			output.PrintError(err)
 			return err
 		}
-		parentID = resolved
+		dedup = &key
 	}
 
+	client, err := cli.RequireClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	bgCtx := context.Background()
+
 	req := mcp.CreatePageRequest{
-		Title:        title,
-		ParentPageID: parentID,
-		Content:      content,
+		Title:         title,
+		Content:       opts.Content,
+		TitleProperty: opts.TitleProperty,
+		Icon:          icon,
+		Cover:         opts.Cover,
+	}
+	if err := resolveCreateParent(bgCtx, client, newParentResolver(false, ctx.JSON), parent, parentDB, &req); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	var deferredIcon *api.PageIcon
+	if opts.IconFromParent {
+		req.Icon, deferredIcon = parentIconForCreate(ctx, bgCtx, req.ParentPageID)
 	}
 
-	resp, err := client.CreatePage(bgCtx, req)
+	var resp *mcp.CreatePageResponse
+	updated := false
+	if dedup != nil {
+		resp, updated, err = runPageCreateDedup(ctx, bgCtx, client, req, *dedup)
+	} else {
+		resp, err = client.CreatePage(bgCtx, req)
+	}
 	if err != nil {
 		output.PrintError(err)
 		return err
 	}
+	if updated {
+		recordAudit(ctx, "page.update", resp.ID, title)
+	} else {
+		recordAudit(ctx, "page.create", pageIDFromCreateResponse(resp), title)
+	}
+	if !updated {
+		setDeferredIcon(ctx, bgCtx, pageIDFromCreateResponse(resp), deferredIcon)
+	}
 
 	if ctx.JSON {
 		outPage := output.Page{
@@ -241,6 +590,10 @@ func runPageCreate(ctx *Context, title, parent, content string) error {
 		return output.PrintPage(outPage, true)
 	}
 
+	if updated {
+		output.PrintSuccess("Existing page updated: " + resp.ID)
+		return nil
+	}
 	if resp.URL != "" {
 		output.PrintSuccess("Page created: " + resp.URL)
 	} else {
@@ -249,30 +602,165 @@ func runPageCreate(ctx *Context, title, parent, content string) error {
 	return nil
 }
 
+// checkEmptyContent warns that a page would be created blank, which in a
+// script usually means the variable passed to --content was empty. Database
+// entries are exempt unless --require-content is given, since their data is
+// often all in properties.
+func checkEmptyContent(opts pageCreateOptions, asJSON bool) error {
+	if strings.TrimSpace(opts.Content) != "" || opts.AllowEmpty {
+		return nil
+	}
+	if opts.RequireContent {
+		return &output.UserError{Message: "--content is empty; pass the page body, or drop --require-content to create a blank page"}
+	}
+	if opts.ParentDB == "" && !asJSON {
+		printWarningFn("Creating a page with no content; pass --allow-empty if that is intended")
+	}
+	return nil
+}
+
 type PageUploadCmd struct {
-	File     string `arg:"" help:"Markdown file to upload" type:"existingfile"`
-	Title    string `help:"Page title (default: filename or first heading)" short:"t"`
-	Parent   string `help:"Parent page URL, name, or ID" short:"p"`
-	ParentDB string `help:"Parent database URL, name, or ID" name:"parent-db" short:"d"`
-	Icon     string `help:"Emoji icon for the page" short:"i"`
-	JSON     bool   `help:"Output as JSON" short:"j"`
+	File          string `arg:"" help:"Markdown file to upload" type:"existingfile"`
+	Title         string `help:"Page title (default: filename or first heading)" short:"t"`
+	Parent        string `help:"Parent page URL, name, or ID, or \"workspace\" for the top level" short:"p"`
+	ParentDB      string `help:"Parent database URL, name, or ID" name:"parent-db" short:"d"`
+	TitleProperty string `help:"Name of the --parent-db title property (default: detected from the schema)" name:"title-property"`
+	CreateParents bool   `help:"Create the --parent page at the workspace root when no page matches its name" name:"create-parents"`
+	Icon          string `help:"Emoji icon for the page, or a shortcode like :rocket:" short:"i"`
+	Cover         string `help:"Cover image URL for the page" placeholder:"URL"`
+	SplitOn       string `help:"Split the file into one page per section at lines matching this regex" name:"split-on" placeholder:"REGEX"`
+	HeadingSplit  string `help:"Create a parent page plus one child page per heading at this level (h1 or h2)" name:"heading-split" placeholder:"LEVEL"`
+
+	IconFromParent bool `help:"Copy the --parent page's icon to the new page (uses the official API)" name:"icon-from-parent"`
+	NoImageUpload  bool `help:"Leave local image references as-is instead of uploading the files" name:"no-image-upload"`
+	StrictImages   bool `help:"Fail before uploading if any local or remote image cannot be found" name:"strict-images"`
+	DedupeImages   bool `help:"With --split-on or --heading-split, upload each distinct image once and reuse it across pages" name:"dedupe-images"`
+
+	Var        []string `help:"Value for {{KEY}} placeholders in the body and title, KEY=VALUE (repeatable)" placeholder:"KEY=VALUE"`
+	StrictVars bool     `help:"Fail if the file uses a {{KEY}} placeholder that no --var defines" name:"strict-vars"`
+
+	ParentPrecedence string `help:"Which parent wins when both the flags and the file's notion-parent/notion-parent-db frontmatter name one" name:"parent-precedence" enum:"flag,frontmatter" default:"flag"`
+
+	TitleFrom  string `help:"Without --title, take the title from the first # heading (falling back to the file name) or always from the file name" name:"title-from" enum:"heading,filename" default:"heading"`
+	TitleCase  bool   `help:"Turn file-name titles like my-cool_page into My Cool Page" name:"title-case"`
+	StripEmoji bool   `help:"Remove emoji from the title; a leading emoji still becomes the page icon" name:"strip-emoji"`
+
+	StripComments bool `help:"Remove <!-- --> HTML comments from the body before uploading, outside code blocks" name:"strip-comments"`
+
+	ContinueOnError bool `help:"With --split-on, keep going after a section fails" name:"continue-on-error"`
+	IgnoreFailures  bool `help:"With --continue-on-error, exit zero even if some sections failed" name:"ignore-failures"`
+	URLOnly         bool `help:"Print only the page URL on success, for scripts; errors still go to stderr" name:"url-only" aliases:"silent-url"`
+	IDOnly          bool `help:"Print only the page ID on success, for chaining commands" name:"id-only"`
+	JSON            bool `help:"Output as JSON" short:"j"`
+}
+
+// pageFileOptions carries the flags shared by page upload and page sync.
+type pageFileOptions struct {
+	Title         string
+	Parent        string
+	ParentDB      string
+	TitleProperty string
+	CreateParents bool
+	Icon          string
+	Cover         string
+	SplitOn       string
+	HeadingSplit  string
+	AppendOnly    bool
+	PreserveMtime bool
+	NoImageUpload bool
+	TitleFrom     string
+	TitleCase     bool
+	StripEmoji    bool
+	StrictImages  bool
+	DedupeImages  bool
+	// StripComments removes HTML comments from the body before it is sent.
+	StripComments bool
+	// Vars holds --var values; nil unless --var or --strict-vars was given.
+	Vars       map[string]string
+	StrictVars bool
+
+	ParentPrecedence string
+
+	IconFromParent  bool
+	ContinueOnError bool
+	IgnoreFailures  bool
 }
 
 func (c *PageUploadCmd) Run(ctx *Context) error {
-	ctx.JSON = c.JSON
-	return runPageUpload(ctx, c.File, c.Title, c.Parent, c.ParentDB, c.Icon)
+	ctx.JSON = ctx.JSON || c.JSON
+	if err := applyPageOutput(ctx, c.URLOnly, c.IDOnly); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	opts := pageFileOptions{
+		Title:         c.Title,
+		Parent:        c.Parent,
+		ParentDB:      c.ParentDB,
+		TitleProperty: c.TitleProperty,
+		CreateParents: c.CreateParents,
+		Icon:          c.Icon,
+		Cover:         c.Cover,
+		SplitOn:       c.SplitOn,
+		HeadingSplit:  c.HeadingSplit,
+		TitleFrom:     c.TitleFrom,
+		TitleCase:     c.TitleCase,
+		StrictImages:  c.StrictImages,
+		DedupeImages:  c.DedupeImages,
+
+		ParentPrecedence: c.ParentPrecedence,
+		StripEmoji:       c.StripEmoji,
+		StripComments:    c.StripComments,
+
+		IconFromParent:  c.IconFromParent,
+		NoImageUpload:   c.NoImageUpload,
+		ContinueOnError: c.ContinueOnError,
+		IgnoreFailures:  c.IgnoreFailures,
+	}
+	if err := applyTemplateVars(&opts, c.Var, c.StrictVars); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	return runPageUpload(ctx, c.File, opts)
 }
 
-func runPageUpload(ctx *Context, file, title, parent, parentDB, icon string) error {
+func runPageUpload(ctx *Context, file string, opts pageFileOptions) error {
+	if err := validateBatchFlags(opts, false); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if err := applyFrontmatterParent(ctx, file, &opts); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	opts.Parent = applyDefaultParent(ctx, opts.Parent, opts.ParentDB)
+	if err := parseIconOption(&opts); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if err := checkStrictImages(file, opts); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if opts.HeadingSplit != "" {
+		return runPageHeadingSplit(ctx, file, opts)
+	}
+	if opts.SplitOn != "" {
+		return runPageSplit(ctx, file, opts, false)
+	}
+	title, parent, parentDB, icon := opts.Title, opts.Parent, opts.ParentDB, opts.Icon
 	content, err := os.ReadFile(file)
 	if err != nil {
 		output.PrintError(err)
 		return err
 	}
 
-	markdown := string(content)
+	markdown, err := preprocessPageFile(string(content), opts)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
 	bgCtx := context.Background()
-	markdown, localUploads, err := prepareLocalImageUploads(ctx, bgCtx, file, markdown)
+	markdown, localUploads, err := prepareLocalImageUploads(ctx, bgCtx, file, markdown, opts.NoImageUpload, nil)
 	if err != nil {
 		output.PrintError(err)
 		return err
@@ -283,15 +771,10 @@ func runPageUpload(ctx *Context, file, title, parent, parentDB, icon string) err
 	}
 
 	if title == "" {
-		title = extractTitleFromMarkdown(markdown)
-	}
-	if title == "" {
-		title = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		title = defaultPageTitle(markdown, file, opts)
 	}
 
-	if icon == "" {
-		icon, title = extractEmojiFromTitle(title)
-	}
+	title, icon = pageTitleIcon(title, icon, opts.StripEmoji)
 
 	client, err := cli.RequireClient()
 	if err != nil {
@@ -300,49 +783,33 @@ func runPageUpload(ctx *Context, file, title, parent, parentDB, icon string) err
 	defer func() { _ = client.Close() }()
 
 	req := mcp.CreatePageRequest{
-		Title:   title,
-		Content: markdown,
+		Title:         title,
+		Content:       markdown,
+		TitleProperty: opts.TitleProperty,
+		Icon:          icon,
+		Cover:         opts.Cover,
 	}
 
-	if parentDB != "" {
-		dbID, err := cli.ResolveDatabaseID(bgCtx, client, parentDB)
-		if err != nil {
-			output.PrintError(err)
-			return err
-		}
-		dbID, err = client.ResolveDataSourceID(bgCtx, dbID)
-		if err != nil {
-			output.PrintError(err)
-			return err
-		}
-		req.ParentDatabaseID = dbID
-	} else if parent != "" {
-		parentID, err := cli.ResolvePageID(bgCtx, client, parent)
-		if err != nil {
-			output.PrintError(err)
-			return err
+	parents := newParentResolver(opts.CreateParents, ctx.JSON)
+	if err := resolveCreateParent(bgCtx, client, parents, parent, parentDB, &req); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	var deferredIcon *api.PageIcon
+	if opts.IconFromParent && icon == "" {
+		req.Icon, deferredIcon = parentIconForCreate(ctx, bgCtx, req.ParentPageID)
+		if !isURL(req.Icon) {
+			icon = req.Icon
 		}
-		req.ParentPageID = parentID
 	}
 
-	resp, err := client.CreatePage(bgCtx, req)
+	resp, pageID, err := createMarkdownPage(ctx, bgCtx, client, req, localUploads)
 	if err != nil {
 		output.PrintError(err)
 		return err
 	}
-	pageID := pageIDFromCreateResponse(resp)
-	if err := substituteUploadedLocalImages(ctx, bgCtx, pageID, localUploads); err != nil {
-		finalErr := fmt.Errorf("insert uploaded local images: %w", err)
-		if pageID != "" {
-			if apiClient, apiErr := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx)); apiErr == nil {
-				if cleanupErr := apiClient.TrashPage(bgCtx, pageID); cleanupErr != nil {
-					finalErr = fmt.Errorf("%w (cleanup failed: %v)", finalErr, cleanupErr)
-				}
-			}
-		}
-		output.PrintError(finalErr)
-		return finalErr
-	}
+	recordAudit(ctx, "page.upload", pageID, file)
+	setDeferredIcon(ctx, bgCtx, pageID, deferredIcon)
 
 	displayTitle := title
 	if icon != "" {
@@ -351,10 +818,11 @@ func runPageUpload(ctx *Context, file, title, parent, parentDB, icon string) err
 
 	if ctx.JSON {
 		outPage := output.Page{
-			ID:    pageID,
-			URL:   resp.URL,
-			Title: displayTitle,
-			Icon:  icon,
+			ID:             pageID,
+			URL:            resp.URL,
+			Title:          displayTitle,
+			Icon:           icon,
+			UploadedAssets: uploadedAssets(localUploads),
 		}
 		return output.PrintPage(outPage, true)
 	}
@@ -366,6 +834,37 @@ func runPageUpload(ctx *Context, file, title, parent, parentDB, icon string) err
 	return nil
 }
 
+// defaultPageTitle derives a title when --title is not given: the first
+// "# " heading, falling back to the file name, or always the file name with
+// --title-from filename.
+func defaultPageTitle(markdown, file string, opts pageFileOptions) string {
+	if opts.TitleFrom != "filename" {
+		if title := extractTitleFromMarkdown(markdown); title != "" {
+			return title
+		}
+	}
+	return fileNameTitle(file, opts.TitleCase)
+}
+
+// fileNameTitle returns the file name without its extension. With
+// titleCase, dashes and underscores become spaces and each word is
+// capitalised, so my-cool_page.md becomes "My Cool Page".
+func fileNameTitle(file string, titleCase bool) string {
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	if !titleCase {
+		return name
+	}
+	words := strings.Fields(strings.NewReplacer("-", " ", "_", " ").Replace(name))
+	for i, w := range words {
+		r, size := utf8.DecodeRuneInString(w)
+		words[i] = string(unicode.ToUpper(r)) + w[size:]
+	}
+	if len(words) == 0 {
+		return name
+	}
+	return strings.Join(words, " ")
+}
+
 func extractTitleFromMarkdown(content string) string {
 	lines := strings.Split(content, "\n")
 	for _, line := range lines {
@@ -377,36 +876,88 @@ func extractTitleFromMarkdown(content string) string {
 	return ""
 }
 
+// parseIconOption expands an --icon shortcode such as :rocket: and rejects
+// values that aren't emoji, along with --icon-from-parent combinations that
+// have no single parent page to copy from, and a --cover that isn't a URL.
+func parseIconOption(opts *pageFileOptions) error {
+	if opts.IconFromParent {
+		switch {
+		case opts.Parent == "":
+			return &output.UserError{Message: "--icon-from-parent requires --parent"}
+		case opts.Icon != "":
+			return &output.UserError{Message: "--icon cannot be combined with --icon-from-parent"}
+		case opts.SplitOn != "" || opts.HeadingSplit != "":
+			return &output.UserError{Message: "--icon-from-parent cannot be combined with --split-on or --heading-split"}
+		}
+	}
+	icon, err := cli.ParseIcon(opts.Icon)
+	if err != nil {
+		return &output.UserError{Message: err.Error()}
+	}
+	opts.Icon = icon
+	return checkCoverURL(opts.Cover)
+}
+
+// extractEmojiFromTitle splits a leading emoji or :shortcode: off title as
+// the page icon. A title that is only the emoji keeps it rather than going
+// blank.
 func extractEmojiFromTitle(title string) (icon, cleanTitle string) {
-	runes := []rune(title)
-	if len(runes) == 0 {
+	icon, rest, ok := cli.LeadingShortcode(title)
+	if !ok {
+		icon, rest, ok = cli.LeadingEmoji(title)
+	}
+	if !ok {
 		return "", title
 	}
-
-	first := runes[0]
-	if cli.IsEmoji(first) {
-		rest := strings.TrimSpace(string(runes[1:]))
-		return string(first), rest
+	if rest = strings.TrimSpace(rest); rest == "" {
+		return icon, title
 	}
+	return icon, rest
+}
 
-	return "", title
+// pageTitleIcon splits a leading emoji off title as the page icon unless an
+// icon was given, then removes any remaining emoji when stripEmoji is set.
+func pageTitleIcon(title, icon string, stripEmoji bool) (string, string) {
+	if icon == "" {
+		icon, title = extractEmojiFromTitle(title)
+	}
+	if stripEmoji {
+		title = cli.StripEmoji(title)
+	}
+	return title, icon
 }
 
 type PageEditCmd struct {
 	Page                 string   `arg:"" help:"Page URL, name, or ID"`
 	Replace              string   `help:"Replace entire content with this text"`
 	Find                 string   `help:"Text to find (use ... for ellipsis)"`
+	Regex                bool     `help:"Treat --find as a regular expression, matched against the page's content locally" name:"regex"`
+	IgnoreCase           bool     `help:"Match --find without regard to case" name:"ignore-case" short:"i"`
 	ReplaceWith          string   `help:"Text to replace with (requires --find)" name:"replace-with"`
-	Append               string   `help:"Append text after selection (requires --find)"`
+	Append               string   `help:"Append text after selection (requires --find or --after)"`
+	After                string   `help:"With --append, insert the text as paragraphs directly after this top-level block (uses the official API)" placeholder:"BLOCK_ID"`
 	Prop                 []string `help:"Set page properties (key=value, repeatable)" short:"P"`
 	AllowDeletingContent bool     `help:"Allow deleting child pages/databases when replacing content" name:"allow-deleting-content"`
+	AppendOnly           bool     `help:"Refuse any edit that would replace or delete existing content" name:"append-only"`
 }
 
 func (c *PageEditCmd) Run(ctx *Context) error {
-	return runPageEdit(ctx, c.Page, c.Replace, c.Find, c.ReplaceWith, c.Append, c.Prop, c.AllowDeletingContent)
+	return runPageEdit(ctx, c.Page, c.Replace, c.Find, c.ReplaceWith, c.Append, c.After, c.Prop, c.AllowDeletingContent, c.AppendOnly, c.Regex, c.IgnoreCase)
 }
 
-func runPageEdit(ctx *Context, page, replace, find, replaceWith, appendText string, props []string, allowDeletingContent bool) error {
+func runPageEdit(ctx *Context, page, replace, find, replaceWith, appendText, after string, props []string, allowDeletingContent, appendOnly, regex, ignoreCase bool) error {
+	if (regex || ignoreCase) && find == "" {
+		err := &output.UserError{Message: "--regex and --ignore-case require --find"}
+		output.PrintError(err)
+		return err
+	}
+	if after != "" {
+		if err := validateAppendAfter(replace, find, replaceWith, appendText, props); err != nil {
+			output.PrintError(err)
+			return err
+		}
+	}
+
 	client, err := cli.RequireClient()
 	if err != nil {
 		return err
@@ -429,18 +980,39 @@ func runPageEdit(ctx *Context, page, replace, find, replaceWith, appendText stri
 		pageID = ref.ID
 	}
 
+	if after != "" {
+		return runPageAppendAfter(ctx, bgCtx, pageID, after, appendText)
+	}
+
 	req, err := buildPageEditRequest(replace, find, replaceWith, appendText, props, allowDeletingContent)
 	if err != nil {
 		output.PrintError(err)
 		return err
 	}
+	matched := 0
+	if regex || ignoreCase {
+		req, matched, err = resolvePatternEdit(bgCtx, client, pageID, find, replaceWith, appendText, regex, ignoreCase)
+		if err != nil {
+			output.PrintError(err)
+			return err
+		}
+	}
 	req.PageID = pageID
+	if err := checkAppendOnly(ctx, pageID, req.Command, appendOnly); err != nil {
+		output.PrintError(err)
+		return err
+	}
 
 	if err := client.UpdatePage(bgCtx, req); err != nil {
 		output.PrintError(err)
 		return err
 	}
+	recordAudit(ctx, "page.edit", pageID, req.Command)
 
+	if matched > 1 {
+		output.PrintSuccess(fmt.Sprintf("Page updated (%d matches replaced)", matched))
+		return nil
+	}
 	output.PrintSuccess("Page updated")
 	return nil
 }
@@ -535,20 +1107,130 @@ func parsePageEditProperties(props []string) (map[string]any, error) {
 }
 
 type PageSyncCmd struct {
-	File     string `arg:"" help:"Markdown file to sync" type:"existingfile"`
-	Title    string `help:"Page title (default: filename or first heading)" short:"t"`
-	Parent   string `help:"Parent page URL, name, or ID" short:"p"`
-	ParentDB string `help:"Parent database URL, name, or ID" name:"parent-db" short:"d"`
-	Icon     string `help:"Emoji icon for the page" short:"i"`
-	JSON     bool   `help:"Output as JSON" short:"j"`
+	File           string   `arg:"" help:"Markdown file to sync" type:"existingfile"`
+	Title          string   `help:"Page title (default: filename or first heading)" short:"t"`
+	Parent         string   `help:"Parent page URL, name, or ID, or \"workspace\" for the top level" short:"p"`
+	ParentDB       string   `help:"Parent database URL, name, or ID" name:"parent-db" short:"d"`
+	TitleProperty  string   `help:"Name of the --parent-db title property (default: detected from the schema)" name:"title-property"`
+	CreateParents  bool     `help:"Create the --parent page at the workspace root when no page matches its name" name:"create-parents"`
+	Icon           string   `help:"Emoji icon for the page, or a shortcode like :rocket:" short:"i"`
+	Cover          string   `help:"Cover image URL for the page, set when sync creates it" placeholder:"URL"`
+	SplitOn        string   `help:"Split the file into one page per section at lines matching this regex" name:"split-on" placeholder:"REGEX"`
+	PropertiesOnly bool     `help:"Only push frontmatter properties to the existing page; leave its content untouched" name:"properties-only"`
+	AppendOnly     bool     `help:"Refuse to replace the content of pages that already exist; only new pages are written" name:"append-only"`
+	PreserveMtime  bool     `help:"Keep the file's modification time when notion-id is written into its frontmatter" name:"preserve-mtime"`
+	TitleFrom      string   `help:"Without --title, take the title from the first # heading (falling back to the file name) or always from the file name" name:"title-from" enum:"heading,filename" default:"heading"`
+	TitleCase      bool     `help:"Turn file-name titles like my-cool_page into My Cool Page" name:"title-case"`
+	StripEmoji     bool     `help:"Remove emoji from the title; a leading emoji still becomes the page icon" name:"strip-emoji"`
+	StrictImages   bool     `help:"Fail before syncing if any local or remote image cannot be found" name:"strict-images"`
+	DedupeImages   bool     `help:"With --split-on, upload each distinct image once and reuse it across pages" name:"dedupe-images"`
+	Var            []string `help:"Value for {{KEY}} placeholders in the body and title, KEY=VALUE (repeatable)" placeholder:"KEY=VALUE"`
+	StrictVars     bool     `help:"Fail if the file uses a {{KEY}} placeholder that no --var defines" name:"strict-vars"`
+	StripComments  bool     `help:"Remove <!-- --> HTML comments from the body before syncing, outside code blocks" name:"strip-comments"`
+
+	ParentPrecedence string `help:"Which parent wins when both the flags and the file's notion-parent/notion-parent-db frontmatter name one" name:"parent-precedence" enum:"flag,frontmatter" default:"flag"`
+	Watch            bool   `help:"Keep running and re-sync whenever the file or its local images change" short:"w"`
+	Since            string `help:"Skip an already-synced file unless it or a local image it references changed since this time (RFC3339, YYYY-MM-DD, 7d) or git revision" placeholder:"TIME|REV"`
+
+	ContinueOnError bool `help:"With --split-on or a notion-targets list, keep going after a section or target fails" name:"continue-on-error"`
+	IgnoreFailures  bool `help:"With --continue-on-error, exit zero even if some sections or targets failed" name:"ignore-failures"`
+	URLOnly         bool `help:"Print only the page URL on success, for scripts; errors still go to stderr" name:"url-only" aliases:"silent-url"`
+	IDOnly          bool `help:"Print only the page ID on success, for chaining commands" name:"id-only"`
+	JSON            bool `help:"Output as JSON" short:"j"`
 }
 
 func (c *PageSyncCmd) Run(ctx *Context) error {
-	ctx.JSON = c.JSON
-	return runPageSync(ctx, c.File, c.Title, c.Parent, c.ParentDB, c.Icon)
+	ctx.JSON = ctx.JSON || c.JSON
+	if c.Watch && (c.URLOnly || c.IDOnly) {
+		flag := "--url-only"
+		if c.IDOnly {
+			flag = "--id-only"
+		}
+		err := &output.UserError{Message: "--watch cannot be combined with " + flag + "; it keeps running and prints progress"}
+		output.PrintError(err)
+		return err
+	}
+	if err := applyPageOutput(ctx, c.URLOnly, c.IDOnly); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if c.Since != "" {
+		if c.Watch {
+			err := &output.UserError{Message: "--since cannot be combined with --watch"}
+			output.PrintError(err)
+			return err
+		}
+		skip, err := skipUnchangedSync(ctx, c.File, c.Since)
+		if err != nil {
+			output.PrintError(err)
+			return err
+		}
+		if skip {
+			return nil
+		}
+	}
+	if c.PropertiesOnly {
+		if c.Watch {
+			err := &output.UserError{Message: "--watch cannot be combined with --properties-only"}
+			output.PrintError(err)
+			return err
+		}
+		return runPageSyncProperties(ctx, c.File, c.SplitOn)
+	}
+	opts := pageFileOptions{
+		Title:         c.Title,
+		Parent:        c.Parent,
+		ParentDB:      c.ParentDB,
+		TitleProperty: c.TitleProperty,
+		CreateParents: c.CreateParents,
+		Icon:          c.Icon,
+		Cover:         c.Cover,
+		SplitOn:       c.SplitOn,
+		AppendOnly:    c.AppendOnly,
+		PreserveMtime: c.PreserveMtime,
+		TitleFrom:     c.TitleFrom,
+		TitleCase:     c.TitleCase,
+		StrictImages:  c.StrictImages,
+		DedupeImages:  c.DedupeImages,
+
+		ParentPrecedence: c.ParentPrecedence,
+		StripEmoji:       c.StripEmoji,
+		StripComments:    c.StripComments,
+
+		ContinueOnError: c.ContinueOnError,
+		IgnoreFailures:  c.IgnoreFailures,
+	}
+	if err := applyTemplateVars(&opts, c.Var, c.StrictVars); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if c.Watch {
+		return runPageSyncWatch(ctx, c.File, opts)
+	}
+	return runPageSync(ctx, c.File, opts)
 }
 
-func runPageSync(ctx *Context, file, title, parent, parentDB, icon string) error {
+func runPageSync(ctx *Context, file string, opts pageFileOptions) error {
+	if err := validateBatchFlags(opts, hasNotionTargets(file)); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if err := parseIconOption(&opts); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if err := checkStrictImages(file, opts); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if opts.SplitOn != "" {
+		if err := applyFrontmatterParent(ctx, file, &opts); err != nil {
+			output.PrintError(err)
+			return err
+		}
+		return runPageSplit(ctx, file, opts, true)
+	}
+	title, parent, parentDB, icon := opts.Title, opts.Parent, opts.ParentDB, opts.Icon
 	raw, err := os.ReadFile(file)
 	if err != nil {
 		output.PrintError(err)
@@ -557,22 +1239,30 @@ func runPageSync(ctx *Context, file, title, parent, parentDB, icon string) error
 
 	content := string(raw)
 	fm, body := cli.ParseFrontmatter(content)
+	if body, err = preprocessBody(body, opts); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if len(fm.NotionTargets) > 0 {
+		return syncPageTargets(ctx, file, fm, body, opts)
+	}
+	if fm.NotionID != "" {
+		if err := checkAppendOnly(ctx, fm.NotionID, "replace_content", opts.AppendOnly || fm.AppendOnly); err != nil {
+			output.PrintError(err)
+			return err
+		}
+	}
 	bgCtx := context.Background()
-	body, localUploads, err := prepareLocalImageUploads(ctx, bgCtx, file, body)
+	body, localUploads, err := prepareLocalImageUploads(ctx, bgCtx, file, body, opts.NoImageUpload, nil)
 	if err != nil {
 		output.PrintError(err)
 		return err
 	}
 
 	if title == "" {
-		title = extractTitleFromMarkdown(body)
-	}
-	if title == "" {
-		title = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
-	}
-	if icon == "" {
-		icon, title = extractEmojiFromTitle(title)
+		title = defaultPageTitle(body, file, opts)
 	}
+	title, icon = pageTitleIcon(title, icon, opts.StripEmoji)
 
 	client, err := cli.RequireClient()
 	if err != nil {
@@ -581,38 +1271,11 @@ func runPageSync(ctx *Context, file, title, parent, parentDB, icon string) error
 	defer func() { _ = client.Close() }()
 
 	if fm.NotionID != "" {
-		var snapshot *api.PageMarkdown
-		if len(localUploads) > 0 {
-			apiClient, err := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx))
-			if err != nil {
-				output.PrintError(err)
-				return err
-			}
-			snapshot, err = apiClient.GetPageMarkdown(bgCtx, fm.NotionID)
-			if err != nil {
-				output.PrintError(err)
-				return err
-			}
-		}
-
-		req := mcp.UpdatePageRequest{
-			PageID:     fm.NotionID,
-			Command:    "replace_content",
-			NewContent: body,
-		}
-		if err := client.UpdatePage(bgCtx, req); err != nil {
+		if err := replaceMarkdownPage(ctx, bgCtx, client, fm.NotionID, body, localUploads); err != nil {
 			output.PrintError(err)
 			return err
 		}
-		if err := substituteUploadedLocalImages(ctx, bgCtx, fm.NotionID, localUploads); err != nil {
-			finalErr := fmt.Errorf("insert uploaded local images: %w", err)
-			rollbackErr := rollbackSyncedPage(bgCtx, client, fm.NotionID, snapshot)
-			if rollbackErr != nil {
-				finalErr = fmt.Errorf("%w (rollback failed: %v)", finalErr, rollbackErr)
-			}
-			output.PrintError(finalErr)
-			return finalErr
-		}
+		recordAudit(ctx, "page.sync", fm.NotionID, file)
 
 		displayTitle := title
 		if icon != "" {
@@ -621,9 +1284,10 @@ func runPageSync(ctx *Context, file, title, parent, parentDB, icon string) error
 
 		if ctx.JSON {
 			outPage := output.Page{
-				ID:    fm.NotionID,
-				Title: displayTitle,
-				Icon:  icon,
+				ID:             fm.NotionID,
+				Title:          displayTitle,
+				Icon:           icon,
+				UploadedAssets: uploadedAssets(localUploads),
 			}
 			return output.PrintPage(outPage, true)
 		}
@@ -632,65 +1296,38 @@ func runPageSync(ctx *Context, file, title, parent, parentDB, icon string) error
 		return nil
 	}
 
+	parent, parentDB = useFrontmatterParent(ctx, file, parent, parentDB, fm, opts.ParentPrecedence)
+	parent = applyDefaultParent(ctx, parent, parentDB)
 	if err := requireLocalImageParent(localUploads, parent, parentDB); err != nil {
 		output.PrintError(err)
 		return err
 	}
 
 	req := mcp.CreatePageRequest{
-		Title:   title,
-		Content: body,
+		Title:         title,
+		Content:       body,
+		TitleProperty: opts.TitleProperty,
+		Icon:          icon,
+		Cover:         opts.Cover,
 	}
 
-	if parentDB != "" {
-		dbID, err := cli.ResolveDatabaseID(bgCtx, client, parentDB)
-		if err != nil {
-			output.PrintError(err)
-			return err
-		}
-		dbID, err = client.ResolveDataSourceID(bgCtx, dbID)
-		if err != nil {
-			output.PrintError(err)
-			return err
-		}
-		req.ParentDatabaseID = dbID
-	} else if parent != "" {
-		parentID, err := cli.ResolvePageID(bgCtx, client, parent)
-		if err != nil {
-			output.PrintError(err)
-			return err
-		}
-		req.ParentPageID = parentID
+	parents := newParentResolver(opts.CreateParents, ctx.JSON)
+	if err := resolveCreateParent(bgCtx, client, parents, parent, parentDB, &req); err != nil {
+		output.PrintError(err)
+		return err
 	}
 
-	resp, err := client.CreatePage(bgCtx, req)
+	resp, pageID, err := createMarkdownPage(ctx, bgCtx, client, req, localUploads)
 	if err != nil {
 		output.PrintError(err)
 		return err
 	}
-
-	pageID := pageIDFromCreateResponse(resp)
-	if err := substituteUploadedLocalImages(ctx, bgCtx, pageID, localUploads); err != nil {
-		finalErr := fmt.Errorf("insert uploaded local images: %w", err)
-		if pageID != "" {
-			if apiClient, apiErr := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx)); apiErr == nil {
-				if cleanupErr := apiClient.TrashPage(bgCtx, pageID); cleanupErr != nil {
-					finalErr = fmt.Errorf("%w (cleanup failed: %v)", finalErr, cleanupErr)
-				}
-			}
-		}
-		output.PrintError(finalErr)
-		return finalErr
-	}
+	recordAudit(ctx, "page.sync", pageID, file)
 	if pageID == "" {
 		output.PrintWarning("Page created but could not retrieve ID for frontmatter")
 	} else {
 		updated := cli.SetFrontmatterID(content, pageID)
-		fileMode := os.FileMode(0o644)
-		if info, err := os.Stat(file); err == nil {
-			fileMode = info.Mode()
-		}
-		if err := os.WriteFile(file, []byte(updated), fileMode); err != nil {
+		if err := writeSourceFile(file, updated, opts.PreserveMtime); err != nil {
 			output.PrintError(fmt.Errorf("page created but failed to update frontmatter: %w", err))
 			return err
 		}
@@ -703,10 +1340,11 @@ func runPageSync(ctx *Context, file, title, parent, parentDB, icon string) error
 
 	if ctx.JSON {
 		outPage := output.Page{
-			ID:    pageID,
-			URL:   resp.URL,
-			Title: displayTitle,
-			Icon:  icon,
+			ID:             pageID,
+			URL:            resp.URL,
+			Title:          displayTitle,
+			Icon:           icon,
+			UploadedAssets: uploadedAssets(localUploads),
 		}
 		return output.PrintPage(outPage, true)
 	}
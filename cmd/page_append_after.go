@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/lox/notion-cli/internal/api"
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// validateAppendAfter checks that --after is only combined with --append.
+func validateAppendAfter(replace, find, replaceWith, appendText string, props []string) error {
+	if appendText == "" {
+		return &output.UserError{Message: "--after requires --append"}
+	}
+	if replace != "" || find != "" || replaceWith != "" || len(props) > 0 {
+		return &output.UserError{Message: "--after cannot be combined with --replace, --find, --replace-with, or --prop"}
+	}
+	return nil
+}
+
+// runPageAppendAfter inserts markdown directly after a block of the page
+// through the official API. It only adds content, so append-only pages
+// allow it.
+func runPageAppendAfter(ctx *Context, bgCtx context.Context, pageID, after, text string) error {
+	blocks := api.MarkdownBlocks(text)
+	if len(blocks) == 0 {
+		err := &output.UserError{Message: "--append text is empty"}
+		output.PrintError(err)
+		return err
+	}
+
+	blockID := after
+	if id, ok := cli.ExtractNotionUUID(after); ok {
+		blockID = id
+	}
+
+	apiClient, err := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx))
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if err := apiClient.AppendBlocksAfter(bgCtx, pageID, blockID, blocks); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	recordAudit(ctx, "page.edit", pageID, "append_after "+blockID)
+
+	output.PrintSuccess("Page updated")
+	return nil
+}
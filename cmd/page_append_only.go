@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/config"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// appendOnlySafeCommands are the update commands that leave a page's
+// existing content in place.
+var appendOnlySafeCommands = map[string]bool{
+	"insert_content_after": true,
+	"update_properties":    true,
+}
+
+// checkAppendOnly refuses an update command that would replace or delete
+// content on an append-only page. A page is append-only when forced is set
+// (--append-only or append-only frontmatter) or when the profile's
+// append_only_pages config lists it. If the config cannot be read, the
+// command is refused too, since the page may be listed there.
+func checkAppendOnly(ctx *Context, pageID, command string, forced bool) error {
+	if appendOnlySafeCommands[command] {
+		return nil
+	}
+	if !forced {
+		configured, err := appendOnlyConfigured(ctx.Profile, pageID)
+		if err != nil {
+			return fmt.Errorf("refusing to %s page %s: cannot check append_only_pages: %w", command, pageID, err)
+		}
+		if !configured {
+			return nil
+		}
+	}
+	return &output.UserError{Message: "page " + pageID + " is append-only; refusing to " + command + " (only --find with --append is allowed)"}
+}
+
+func appendOnlyConfigured(profile, pageID string) (bool, error) {
+	target, ok := cli.ExtractNotionUUID(pageID)
+	if !ok {
+		return false, nil
+	}
+	loaded, err := config.LoadWithMeta(config.APIOverrides{Profile: profile})
+	if err != nil {
+		return false, err
+	}
+	for _, id := range loaded.Config.AppendOnlyPages {
+		if listed, ok := cli.ExtractNotionUUID(id); ok && listed == target {
+			return true, nil
+		}
+	}
+	return false, nil
+}
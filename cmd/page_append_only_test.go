@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/config"
+)
+
+func TestCheckAppendOnly(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ctx := &Context{}
+	const listed = "0123456789abcdef0123456789abcdef"
+	const other = "fedcba98-7654-3210-fedc-ba9876543210"
+
+	if err := checkAppendOnly(ctx, other, "replace_content", false); err != nil {
+		t.Fatalf("unguarded replace: %v", err)
+	}
+	if err := checkAppendOnly(ctx, other, "replace_content", true); err == nil {
+		t.Fatal("expected --append-only to refuse replace_content")
+	}
+	if err := checkAppendOnly(ctx, other, "insert_content_after", true); err != nil {
+		t.Fatalf("append on guarded page: %v", err)
+	}
+
+	if err := config.SaveForProfile("", config.Config{AppendOnlyPages: []string{listed}}); err != nil {
+		t.Fatalf("SaveForProfile: %v", err)
+	}
+	if err := checkAppendOnly(ctx, "01234567-89ab-cdef-0123-456789abcdef", "update_content", false); err == nil {
+		t.Fatal("expected configured page to refuse update_content")
+	}
+	if err := checkAppendOnly(ctx, listed, "update_properties", false); err != nil {
+		t.Fatalf("properties on configured page: %v", err)
+	}
+	if err := checkAppendOnly(ctx, other, "replace_content", false); err != nil {
+		t.Fatalf("unlisted page: %v", err)
+	}
+}
+
+func TestCheckAppendOnlyRefusesWhenConfigUnreadable(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path, err := config.Path()
+	if err != nil {
+		t.Fatalf("Path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	const page = "fedcba98-7654-3210-fedc-ba9876543210"
+	if err := checkAppendOnly(&Context{}, page, "replace_content", false); err == nil {
+		t.Fatal("expected replace_content to be refused when the config cannot be read")
+	}
+	if err := checkAppendOnly(&Context{}, page, "insert_content_after", false); err != nil {
+		t.Fatalf("append with unreadable config: %v", err)
+	}
+}
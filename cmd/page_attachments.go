@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lox/notion-cli/internal/api"
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+var downloadAttachmentsFn = func(ctx *Context, bgCtx context.Context, pageID, dir string) ([]output.Attachment, error) {
+	apiClient, err := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return downloadPageAttachments(bgCtx, apiClient, exportHTTPClient, pageID, dir, time.Now())
+}
+
+// downloadPageAttachments saves the files held by the page's file, pdf,
+// audio, and video blocks into dir, named after each file. Blocks nested in
+// toggles or columns are included, but child pages are not. A file whose
+// signed URL has expired, or that fails to download, is reported with its
+// error instead of failing the rest.
+func downloadPageAttachments(ctx context.Context, lister blockChildrenLister, client *http.Client, pageID, dir string, now time.Time) ([]output.Attachment, error) {
+	files, err := listBlockFiles(ctx, lister, pageID)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	attachments := make([]output.Attachment, 0, len(files))
+	for _, file := range files {
+		attachment := output.Attachment{Name: file.Name}
+		if !file.ExpiresAt.IsZero() && !now.Before(file.ExpiresAt) {
+			attachment.Error = "download link expired at " + file.ExpiresAt.Format(time.RFC3339) + "; view the page again for a fresh link"
+		} else if name, err := downloadAsset(ctx, client, file.URL, file.Name, dir, used); err != nil {
+			attachment.Error = err.Error()
+		} else {
+			attachment.Path = filepath.Join(dir, name)
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+// listBlockFiles returns the files of the file blocks under blockID, in
+// document order.
+func listBlockFiles(ctx context.Context, lister blockChildrenLister, blockID string) ([]api.BlockFile, error) {
+	children, err := lister.ListAllBlockChildren(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+	var files []api.BlockFile
+	for _, child := range children {
+		if file, ok := child.File(); ok {
+			files = append(files, file)
+		}
+		if child.HasChildren && child.Type != "child_page" && child.Type != "child_database" {
+			nested, err := listBlockFiles(ctx, lister, child.ID)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, nested...)
+		}
+	}
+	return files, nil
+}
+
+// printAttachments reports where each attachment was saved, and warns
+// about each one that was skipped.
+func printAttachments(w io.Writer, attachments []output.Attachment) {
+	if len(attachments) == 0 {
+		_, _ = fmt.Fprintln(w, "\nNo attachments found.")
+		return
+	}
+	_, _ = fmt.Fprintln(w, "\nAttachments:")
+	for _, a := range attachments {
+		if a.Error != "" {
+			printWarningFn("Skipped " + a.Name + ": " + a.Error)
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "  %s  %s\n", a.Name, a.Path)
+	}
+}
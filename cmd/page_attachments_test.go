@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDownloadPageAttachments(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/spec", "/nested":
+			_, _ = w.Write([]byte("pdf " + r.URL.Path))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	lister := fakeBlockLister{
+		"page": `[
+			{"id":"f","type":"pdf","pdf":{"name":"Q3 spec.pdf","type":"file","file":{"url":"` + srv.URL + `/spec","expiry_time":"2026-01-01T01:00:00Z"}}},
+			{"id":"old","type":"file","file":{"name":"old.zip","type":"file","file":{"url":"` + srv.URL + `/old","expiry_time":"2025-12-31T00:00:00Z"}}},
+			{"id":"t","type":"toggle","has_children":true,"toggle":{"rich_text":[]}},
+			{"id":"sub","type":"child_page","has_children":true,"child_page":{"title":"Sub"}}
+		]`,
+		"t":   `[{"id":"n","type":"file","file":{"name":"Q3 spec.pdf","type":"external","external":{"url":"` + srv.URL + `/nested"}}}]`,
+		"sub": `[{"id":"x","type":"file","file":{"name":"skip.pdf","type":"external","external":{"url":"` + srv.URL + `/spec"}}}]`,
+	}
+
+	dir := filepath.Join(t.TempDir(), "files")
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	attachments, err := downloadPageAttachments(context.Background(), lister, srv.Client(), "page", dir, now)
+	if err != nil {
+		t.Fatalf("downloadPageAttachments: %v", err)
+	}
+	if len(attachments) != 3 {
+		t.Fatalf("attachments = %#v", attachments)
+	}
+	if attachments[0].Path != filepath.Join(dir, "Q3-spec.pdf") || attachments[2].Path != filepath.Join(dir, "Q3-spec-2.pdf") {
+		t.Fatalf("paths = %q, %q", attachments[0].Path, attachments[2].Path)
+	}
+	if attachments[1].Path != "" || !strings.Contains(attachments[1].Error, "expired") {
+		t.Fatalf("expired attachment = %#v", attachments[1])
+	}
+
+	data, err := os.ReadFile(attachments[2].Path)
+	if err != nil || string(data) != "pdf /nested" {
+		t.Fatalf("nested file = %q, %v", data, err)
+	}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// maxBacklinkCandidates bounds how many search results are fetched to
+// confirm that they really link to the page.
+const maxBacklinkCandidates = 25
+
+var findBacklinksFn = func(ctx context.Context, client *mcp.Client, pageID, title string) ([]output.Backlink, error) {
+	return findBacklinks(ctx, client, pageID, title)
+}
+
+// backlinkSource is the part of the MCP client findBacklinks uses.
+type backlinkSource interface {
+	Search(ctx context.Context, query string, opts *mcp.SearchOptions) (*mcp.SearchResponse, error)
+	Fetch(ctx context.Context, id string) (*mcp.FetchResult, error)
+}
+
+// findBacklinks approximates "what links here": Notion has no backlink API,
+// so it searches the workspace for the page's ID and title, then fetches
+// each page found and keeps those whose body references the page's ID.
+// Pages the search does not surface are missed, and only the first
+// maxBacklinkCandidates results are checked.
+func findBacklinks(ctx context.Context, src backlinkSource, pageID, title string) ([]output.Backlink, error) {
+	id := strings.ToLower(strings.ReplaceAll(pageID, "-", ""))
+	if uuid, ok := cli.ExtractNotionUUID(pageID); ok {
+		id = strings.ReplaceAll(uuid, "-", "")
+	}
+
+	queries := []string{id}
+	if strings.TrimSpace(title) != "" {
+		queries = append(queries, title)
+	}
+
+	seen := map[string]bool{id: true}
+	var candidates []mcp.SearchResult
+	for _, query := range queries {
+		resp, err := src.Search(ctx, query, &mcp.SearchOptions{ContentSearchMode: "workspace_search"})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resp.Results {
+			if r.ObjectType != "page" && r.Object != "page" && r.Type != "page" {
+				continue
+			}
+			key := strings.ToLower(strings.ReplaceAll(r.ID, "-", ""))
+			if seen[key] || len(candidates) == maxBacklinkCandidates {
+				continue
+			}
+			seen[key] = true
+			candidates = append(candidates, r)
+		}
+	}
+
+	var links []output.Backlink
+	for _, c := range candidates {
+		page, err := src.Fetch(ctx, c.ID)
+		if err != nil {
+			continue
+		}
+		// Only the body counts: the fetched document also names the page's
+		// ancestors, so every child of the page would otherwise match.
+		body, ok := output.PageNotionMarkup(page.Content)
+		if !ok {
+			body = page.Content
+		}
+		if !referencesPageID(body, id) {
+			continue
+		}
+		title := c.Title
+		if title == "" {
+			title = page.Title
+		}
+		links = append(links, output.Backlink{ID: c.ID, Title: title, URL: c.URL})
+	}
+	return links, nil
+}
+
+// referencesPageID reports whether content contains id (32 lowercase hex
+// digits) in either its plain or dashed form.
+func referencesPageID(content, id string) bool {
+	content = strings.ToLower(content)
+	if strings.Contains(content, id) {
+		return true
+	}
+	uuid, ok := cli.ExtractNotionUUID(id)
+	return ok && strings.Contains(content, uuid)
+}
+
+func printBacklinks(w io.Writer, links []output.Backlink) {
+	if len(links) == 0 {
+		_, _ = fmt.Fprintln(w, "\nNo backlinks found (approximate).")
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\nBacklinks (%d, approximate):\n", len(links))
+	for _, link := range links {
+		_, _ = fmt.Fprintf(w, "  %s  %s\n", link.Title, link.URL)
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+type fakeBacklinkSource struct {
+	results map[string][]mcp.SearchResult
+	content map[string]string
+	fetched []string
+}
+
+func (f *fakeBacklinkSource) Search(_ context.Context, query string, _ *mcp.SearchOptions) (*mcp.SearchResponse, error) {
+	return &mcp.SearchResponse{Results: f.results[query]}, nil
+}
+
+func (f *fakeBacklinkSource) Fetch(_ context.Context, id string) (*mcp.FetchResult, error) {
+	f.fetched = append(f.fetched, id)
+	content, ok := f.content[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &mcp.FetchResult{Content: content}, nil
+}
+
+func TestFindBacklinksConfirmsLinksByID(t *testing.T) {
+	const pageID = "11111111-2222-3333-4444-555555555555"
+	src := &fakeBacklinkSource{
+		results: map[string][]mcp.SearchResult{
+			"11111111222233334444555555555555": {
+				{Object: "page", ID: "aaa", Title: "Index", URL: "https://notion.so/aaa"},
+			},
+			"Roadmap": {
+				{Object: "page", ID: pageID, Title: "Roadmap"},
+				{Object: "page", ID: "aaa", Title: "Index"},
+				{Object: "page", ID: "bbb", Title: "Mentions roadmap in text"},
+				{Object: "page", ID: "ccc", Title: "Dashed link"},
+				{Object: "database", ID: "ddd", Title: "Roadmap DB"},
+				{Object: "page", ID: "eee", Title: "Fetch fails"},
+			},
+		},
+		content: map[string]string{
+			"aaa": "See [Roadmap](https://www.notion.so/11111111222233334444555555555555).",
+			"bbb": "The roadmap is on the wiki.",
+			"ccc": `<mention-page url="https://www.notion.so/11111111-2222-3333-4444-555555555555"/>`,
+		},
+	}
+
+	links, err := findBacklinks(context.Background(), src, pageID, "Roadmap")
+	if err != nil {
+		t.Fatalf("findBacklinks: %v", err)
+	}
+	if len(links) != 2 || links[0].ID != "aaa" || links[1].ID != "ccc" {
+		t.Fatalf("links = %#v", links)
+	}
+	if strings.Join(src.fetched, ",") != "aaa,bbb,ccc,eee" {
+		t.Fatalf("fetched = %v, want each page candidate once", src.fetched)
+	}
+}
+
+func TestFindBacklinksIgnoresAncestorPath(t *testing.T) {
+	const pageID = "11111111222233334444555555555555"
+	src := &fakeBacklinkSource{
+		results: map[string][]mcp.SearchResult{
+			pageID: {
+				{Object: "page", ID: "child", Title: "Child"},
+				{Object: "page", ID: "linker", Title: "Linker"},
+			},
+		},
+		content: map[string]string{
+			"child":  "<page url=\"https://www.notion.so/child\">\n<ancestor-path>\n<parent-page url=\"https://www.notion.so/" + pageID + "\" title=\"Roadmap\"/>\n</ancestor-path>\n<content>\nJust a child.\n</content>\n</page>",
+			"linker": "<page url=\"https://www.notion.so/linker\">\n<ancestor-path>\n<parent-page url=\"https://www.notion.so/" + pageID + "\" title=\"Roadmap\"/>\n</ancestor-path>\n<content>\nSee <mention-page url=\"https://www.notion.so/" + pageID + "\"/>.\n</content>\n</page>",
+		},
+	}
+
+	links, err := findBacklinks(context.Background(), src, pageID, "")
+	if err != nil {
+		t.Fatalf("findBacklinks: %v", err)
+	}
+	if len(links) != 1 || links[0].ID != "linker" {
+		t.Fatalf("links = %#v, want only the page whose body links here", links)
+	}
+}
+
+func TestPrintBacklinks(t *testing.T) {
+	var buf bytes.Buffer
+	printBacklinks(&buf, nil)
+	if !strings.Contains(buf.String(), "No backlinks found") {
+		t.Fatalf("empty output = %q", buf.String())
+	}
+
+	buf.Reset()
+	printBacklinks(&buf, []output.Backlink{{ID: "aaa", Title: "Index", URL: "https://notion.so/aaa"}})
+	if !strings.Contains(buf.String(), "Backlinks (1, approximate):") || !strings.Contains(buf.String(), "Index  https://notion.so/aaa") {
+		t.Fatalf("output = %q", buf.String())
+	}
+}
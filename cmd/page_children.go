@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/lox/notion-cli/internal/api"
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+type PageChildrenCmd struct {
+	Page      string `arg:"" help:"Page URL, name, or ID"`
+	Recursive bool   `help:"Also list the children of nested blocks" short:"r"`
+	MaxDepth  int    `help:"With --recursive, stop after this many levels (0 for no limit)" name:"max-depth" placeholder:"N"`
+	JSON      bool   `help:"Output as JSON" short:"j"`
+}
+
+func (c *PageChildrenCmd) Run(ctx *Context) error {
+	ctx.JSON = ctx.JSON || c.JSON
+	return runPageChildren(ctx, c.Page, c.Recursive, c.MaxDepth)
+}
+
+type blockChildrenLister interface {
+	ListAllBlockChildren(ctx context.Context, blockID string) ([]api.Block, error)
+}
+
+func runPageChildren(ctx *Context, page string, recursive bool, maxDepth int) error {
+	if maxDepth < 0 || (maxDepth > 0 && !recursive) {
+		err := &output.UserError{Message: "--max-depth must be positive and requires --recursive"}
+		output.PrintError(err)
+		return err
+	}
+
+	bgCtx := context.Background()
+	ref := cli.ParsePageRef(page)
+	pageID := ref.ID
+	if ref.Kind != cli.RefID {
+		client, err := cli.RequireClient()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = client.Close() }()
+
+		pageID, err = cli.ResolvePageID(bgCtx, client, page)
+		if err != nil {
+			output.PrintError(err)
+			return err
+		}
+	}
+
+	apiClient, err := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx))
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	depth := 1
+	if recursive {
+		depth = maxDepth
+	}
+	blocks, err := listBlockTree(bgCtx, apiClient, pageID, depth)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	return output.PrintBlocks(blocks, ctx.JSON)
+}
+
+// listBlockTree lists the children of blockID, descending into blocks that
+// have children until depth levels are listed. A depth of 0 has no limit.
+// Child pages and databases are listed but not descended into, since their
+// content belongs to another page.
+func listBlockTree(ctx context.Context, lister blockChildrenLister, blockID string, depth int) ([]output.Block, error) {
+	children, err := lister.ListAllBlockChildren(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]output.Block, 0, len(children))
+	for _, child := range children {
+		b := output.Block{
+			ID:          child.ID,
+			Type:        child.Type,
+			Text:        child.Text(),
+			HasChildren: child.HasChildren,
+		}
+		descend := depth != 1 && child.HasChildren && child.Type != "child_page" && child.Type != "child_database"
+		if descend {
+			b.Children, err = listBlockTree(ctx, lister, child.ID, max(depth-1, 0))
+			if err != nil {
+				return nil, err
+			}
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, nil
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/api"
+)
+
+type fakeBlockLister map[string]string
+
+func (f fakeBlockLister) ListAllBlockChildren(_ context.Context, blockID string) ([]api.Block, error) {
+	var blocks []api.Block
+	if err := json.Unmarshal([]byte(f[blockID]), &blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+func TestListBlockTree(t *testing.T) {
+	lister := fakeBlockLister{
+		"page": `[
+			{"id":"h","type":"heading_1","heading_1":{"rich_text":[{"plain_text":"Intro"}]}},
+			{"id":"t","type":"toggle","has_children":true,"toggle":{"rich_text":[{"plain_text":"More"}]}},
+			{"id":"p","type":"child_page","has_children":true,"child_page":{"title":"Sub"}}
+		]`,
+		"t": `[{"id":"n","type":"bulleted_list_item","has_children":true,"bulleted_list_item":{"rich_text":[{"plain_text":"Item"}]}}]`,
+		"n": `[{"id":"leaf","type":"paragraph","paragraph":{"rich_text":[{"plain_text":"Deep"}]}}]`,
+	}
+
+	top, err := listBlockTree(context.Background(), lister, "page", 1)
+	if err != nil {
+		t.Fatalf("listBlockTree: %v", err)
+	}
+	if len(top) != 3 || top[0].Text != "Intro" || top[2].Text != "Sub" || len(top[1].Children) != 0 {
+		t.Fatalf("depth 1 = %#v", top)
+	}
+
+	two, err := listBlockTree(context.Background(), lister, "page", 2)
+	if err != nil {
+		t.Fatalf("listBlockTree: %v", err)
+	}
+	if len(two[1].Children) != 1 || len(two[1].Children[0].Children) != 0 {
+		t.Fatalf("depth 2 = %#v", two[1])
+	}
+
+	all, err := listBlockTree(context.Background(), lister, "page", 0)
+	if err != nil {
+		t.Fatalf("listBlockTree: %v", err)
+	}
+	if got := all[1].Children[0].Children; len(got) != 1 || got[0].Text != "Deep" {
+		t.Fatalf("unlimited = %#v", all[1])
+	}
+	if len(all[2].Children) != 0 {
+		t.Fatalf("descended into child page: %#v", all[2])
+	}
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// pageContentAppender is the part of the MCP client appendMarkdownChunks uses.
+type pageContentAppender interface {
+	Fetch(ctx context.Context, id string) (*mcp.FetchResult, error)
+	UpdatePage(ctx context.Context, req mcp.UpdatePageRequest) error
+}
+
+// chunkPageContent splits content at --chunk-size, warning when it has to
+// be sent in more than one request.
+func chunkPageContent(ctx *Context, content string) []string {
+	chunks := cli.ChunkMarkdown(content, ctx.ChunkSize)
+	if len(chunks) > 1 && !ctx.JSON {
+		printWarningFn(fmt.Sprintf("Content is %d bytes, over --chunk-size %d; sending it in %d parts", len(content), ctx.ChunkSize, len(chunks)))
+	}
+	return chunks
+}
+
+// appendMarkdownChunks adds each chunk to the end of the page in turn. The
+// page is fetched before every append so the insert_content_after
+// selection is taken from what Notion stored, not from the markdown sent.
+func appendMarkdownChunks(ctx context.Context, client pageContentAppender, pageID string, chunks []string) error {
+	for i, chunk := range chunks {
+		result, err := client.Fetch(ctx, pageID)
+		if err != nil {
+			return fmt.Errorf("append part %d of %d: %w", i+2, len(chunks)+1, err)
+		}
+		body, _ := output.PageNotionMarkup(result.Content)
+		selection, ok := pageEndSelection(body)
+		if !ok {
+			return fmt.Errorf("append part %d of %d: could not find the end of the page content", i+2, len(chunks)+1)
+		}
+		if err := client.UpdatePage(ctx, mcp.UpdatePageRequest{
+			PageID:    pageID,
+			Command:   "insert_content_after",
+			Selection: selection,
+			NewStr:    "\n" + chunk,
+		}); err != nil {
+			return fmt.Errorf("append part %d of %d: %w", i+2, len(chunks)+1, err)
+		}
+	}
+	return nil
+}
+
+// pageEndSelection returns text that ends the page body and occurs in it
+// only once: its last line, extended upwards until it is unique. A literal
+// "..." would be read as an ellipsis, so only the text after the last one
+// is used.
+func pageEndSelection(body string) (string, bool) {
+	body = strings.TrimRight(body, " \t\r\n")
+	if body == "" {
+		return "", false
+	}
+	if i := strings.LastIndex(body, "..."); i >= 0 {
+		tail := body[i+3:]
+		return tail, strings.TrimSpace(tail) != "" && strings.Count(body, tail) == 1
+	}
+
+	start := len(body)
+	for {
+		start = strings.LastIndexByte(body[:start], '\n')
+		selection := body[start+1:]
+		if strings.TrimSpace(selection) != "" && strings.Count(body, selection) == 1 {
+			return selection, true
+		}
+		if start < 0 {
+			return "", false
+		}
+	}
+}
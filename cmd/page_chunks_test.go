@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/mcp"
+)
+
+// fakePageContentAppender keeps a page body and applies insert_content_after
+// the way the MCP server does.
+type fakePageContentAppender struct {
+	body string
+}
+
+func (f *fakePageContentAppender) Fetch(_ context.Context, _ string) (*mcp.FetchResult, error) {
+	return &mcp.FetchResult{Content: "<page><content>\n" + f.body + "\n</content></page>"}, nil
+}
+
+func (f *fakePageContentAppender) UpdatePage(_ context.Context, req mcp.UpdatePageRequest) error {
+	i := strings.Index(f.body, req.Selection)
+	if req.Command != "insert_content_after" || i < 0 || strings.Count(f.body, req.Selection) != 1 {
+		return errors.New("selection not found: " + req.Selection)
+	}
+	end := i + len(req.Selection)
+	f.body = f.body[:end] + req.NewStr + f.body[end:]
+	return nil
+}
+
+func TestAppendMarkdownChunks(t *testing.T) {
+	page := &fakePageContentAppender{body: "# Report\nSame line\nSame line"}
+	if err := appendMarkdownChunks(context.Background(), page, "page", []string{"Part two", "Part three"}); err != nil {
+		t.Fatalf("appendMarkdownChunks: %v", err)
+	}
+	if want := "# Report\nSame line\nSame line\nPart two\nPart three"; page.body != want {
+		t.Fatalf("body = %q, want %q", page.body, want)
+	}
+}
+
+func TestSnapshotPageReadsContentOverMCP(t *testing.T) {
+	page := &fakePageContentAppender{body: "# Report\nOld text"}
+	snapshot, err := snapshotPage(&Context{}, context.Background(), page, "page", false)
+	if err != nil {
+		t.Fatalf("snapshotPage: %v", err)
+	}
+	if snapshot.Markdown != page.body {
+		t.Fatalf("snapshot = %q, want %q", snapshot.Markdown, page.body)
+	}
+}
+
+func TestPageEndSelection(t *testing.T) {
+	tests := []struct {
+		body, want string
+		ok         bool
+	}{
+		{"a\nb\n", "b", true},
+		{"x\ny\nx", "y\nx", true},
+		{"wait...then done", "then done", true},
+		{"   ", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := pageEndSelection(tt.body)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("pageEndSelection(%q) = %q, %v; want %q, %v", tt.body, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import "testing"
+
+func TestCheckEmptyContent(t *testing.T) {
+	originalPrintWarning := printWarningFn
+	defer func() { printWarningFn = originalPrintWarning }()
+	var warnings []string
+	printWarningFn = func(message string) { warnings = append(warnings, message) }
+
+	tests := []struct {
+		name     string
+		opts     pageCreateOptions
+		asJSON   bool
+		wantErr  bool
+		wantWarn bool
+	}{
+		{name: "content", opts: pageCreateOptions{Content: "Body", RequireContent: true}},
+		{name: "blank warns", opts: pageCreateOptions{Content: " \n"}, wantWarn: true},
+		{name: "blank with allow-empty", opts: pageCreateOptions{AllowEmpty: true}},
+		{name: "blank with require-content", opts: pageCreateOptions{RequireContent: true}, wantErr: true},
+		{name: "database entry", opts: pageCreateOptions{ParentDB: "Tasks"}},
+		{name: "database entry with require-content", opts: pageCreateOptions{ParentDB: "Tasks", RequireContent: true}, wantErr: true},
+		{name: "json", opts: pageCreateOptions{}, asJSON: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings = nil
+			err := checkEmptyContent(tt.opts, tt.asJSON)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (len(warnings) > 0) != tt.wantWarn {
+				t.Fatalf("warnings = %q, wantWarn %v", warnings, tt.wantWarn)
+			}
+		})
+	}
+}
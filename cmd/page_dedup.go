@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lox/notion-cli/internal/api"
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// dedupKey is a parsed --dedup-property NAME=VALUE.
+type dedupKey struct {
+	Name  string
+	Value string
+}
+
+func parseDedupProperty(s string) (dedupKey, error) {
+	k, v, ok := strings.Cut(s, "=")
+	k = strings.TrimSpace(k)
+	if !ok || k == "" {
+		return dedupKey{}, &output.UserError{Message: "invalid --dedup-property format (expected NAME=VALUE): " + s}
+	}
+	return dedupKey{Name: k, Value: strings.TrimSpace(v)}, nil
+}
+
+// findDedupPage queries the data source for a page whose dedup property
+// equals the key's value. It returns "" when no page matches and an error
+// when more than one does. The key's name is normalised to the schema's
+// spelling of the property.
+func findDedupPage(bgCtx context.Context, apiClient *api.Client, dataSourceID string, key *dedupKey) (string, error) {
+	ds, err := apiClient.GetDataSource(bgCtx, dataSourceID)
+	if err != nil {
+		return "", err
+	}
+
+	name, prop, ok := lookupDataSourceProperty(ds, key.Name)
+	if !ok {
+		return "", &output.UserError{Message: "database has no property named " + key.Name}
+	}
+	key.Name = name
+	filter, err := api.PropertyEqualsFilter(name, prop, key.Value)
+	if err != nil {
+		return "", &output.UserError{Message: err.Error()}
+	}
+
+	// Two results are enough to tell a unique match from an ambiguous one.
+	pages, err := apiClient.QueryDataSource(bgCtx, dataSourceID, filter, 2)
+	if err != nil {
+		return "", err
+	}
+	switch len(pages) {
+	case 0:
+		return "", nil
+	case 1:
+		return pages[0].ID, nil
+	default:
+		return "", &output.UserError{Message: "multiple pages match " + key.Name + "=" + key.Value + "; dedup key must be unique"}
+	}
+}
+
+func lookupDataSourceProperty(ds *api.DataSource, name string) (string, api.DataSourceProperty, bool) {
+	if prop, ok := ds.Properties[name]; ok {
+		return name, prop, true
+	}
+	for k, prop := range ds.Properties {
+		if strings.EqualFold(k, name) {
+			return k, prop, true
+		}
+	}
+	return "", api.DataSourceProperty{}, false
+}
+
+// updateDedupPage brings an existing page found by its dedup key in line
+// with the create request: title and properties, then content if given.
+func updateDedupPage(bgCtx context.Context, client *mcp.Client, pageID string, req mcp.CreatePageRequest) error {
+	properties := make(map[string]any, len(req.Properties)+1)
+	for k, v := range req.Properties {
+		properties[k] = v
+	}
+	titleProperty := req.TitleProperty
+	if titleProperty == "" {
+		titleProperty = "title"
+	}
+	properties[titleProperty] = req.Title
+
+	if err := client.UpdatePage(bgCtx, mcp.UpdatePageRequest{
+		PageID:     pageID,
+		Command:    "update_properties",
+		Properties: properties,
+	}); err != nil {
+		return err
+	}
+	if req.Content == "" {
+		return nil
+	}
+	return client.UpdatePage(bgCtx, mcp.UpdatePageRequest{
+		PageID:     pageID,
+		Command:    "replace_content",
+		NewContent: req.Content,
+	})
+}
+
+// runPageCreateDedup creates the page unless one with the same dedup key
+// already exists in the parent database, in which case it is updated.
+// It reports whether an existing page was updated.
+func runPageCreateDedup(ctx *Context, bgCtx context.Context, client *mcp.Client, req mcp.CreatePageRequest, key dedupKey) (*mcp.CreatePageResponse, bool, error) {
+	apiClient, err := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx), api.FeatureDataSources)
+	if err != nil {
+		return nil, false, err
+	}
+
+	existingID, err := findDedupPage(bgCtx, apiClient, req.ParentDatabaseID, &key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if req.Properties == nil {
+		req.Properties = make(map[string]any)
+	}
+	req.Properties[key.Name] = key.Value
+
+	if existingID == "" {
+		resp, err := client.CreatePage(bgCtx, req)
+		return resp, false, err
+	}
+	if err := updateDedupPage(bgCtx, client, existingID, req); err != nil {
+		return nil, false, err
+	}
+	return &mcp.CreatePageResponse{ID: existingID}, true, nil
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/lox/notion-cli/internal/api"
+)
+
+func TestParseDedupProperty(t *testing.T) {
+	key, err := parseDedupProperty(" Slug = intro ")
+	if err != nil {
+		t.Fatalf("parseDedupProperty: %v", err)
+	}
+	if key.Name != "Slug" || key.Value != "intro" {
+		t.Fatalf("unexpected key: %#v", key)
+	}
+
+	if _, err := parseDedupProperty("Slug"); err == nil {
+		t.Fatal("expected error without =")
+	}
+	if _, err := parseDedupProperty("=intro"); err == nil {
+		t.Fatal("expected error without name")
+	}
+}
+
+func TestLookupDataSourcePropertyIgnoresCase(t *testing.T) {
+	ds := &api.DataSource{Properties: map[string]api.DataSourceProperty{
+		"Slug": {Type: "rich_text"},
+	}}
+
+	name, prop, ok := lookupDataSourceProperty(ds, "slug")
+	if !ok || name != "Slug" || prop.Type != "rich_text" {
+		t.Fatalf("lookup = %q %#v %v", name, prop, ok)
+	}
+	if _, _, ok := lookupDataSourceProperty(ds, "Missing"); ok {
+		t.Fatal("expected missing property")
+	}
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// compileFindPattern turns --find into a regular expression: as is with
+// --regex, otherwise as literal text in which "..." still stands for any
+// run of text. --ignore-case makes either form case-insensitive.
+func compileFindPattern(find string, regex, ignoreCase bool) (*regexp.Regexp, error) {
+	pattern := find
+	if !regex {
+		parts := strings.Split(find, "...")
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+		pattern = strings.Join(parts, "(?s:.*?)")
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, &output.UserError{Message: fmt.Sprintf("invalid --find pattern: %v", err)}
+	}
+	return re, nil
+}
+
+// buildPatternEdit matches re against the page's Notion markup and rewrites
+// a --find edit into one the MCP server can apply, since its selections are
+// literal text. Replacements become exact update_content pairs when every
+// match occurs only once on the page; otherwise the whole body is sent back
+// with every match replaced, through replace_content. With --regex, $1 and
+// ${name} in the replacement refer to capture groups. --append needs a
+// single match, which becomes the insert_content_after selection.
+func buildPatternEdit(body string, re *regexp.Regexp, replaceWith, appendText string, regex bool) (mcp.UpdatePageRequest, int, error) {
+	matches := re.FindAllStringSubmatchIndex(body, -1)
+	if len(matches) == 0 {
+		return mcp.UpdatePageRequest{}, 0, &output.UserError{Message: fmt.Sprintf("--find %q matched nothing on the page", re.String())}
+	}
+	for _, m := range matches {
+		if m[0] == m[1] {
+			return mcp.UpdatePageRequest{}, 0, &output.UserError{Message: fmt.Sprintf("--find %q matches empty text", re.String())}
+		}
+	}
+
+	if appendText != "" {
+		selection := body[matches[0][0]:matches[0][1]]
+		if len(matches) > 1 || strings.Count(body, selection) > 1 {
+			return mcp.UpdatePageRequest{}, 0, &output.UserError{Message: fmt.Sprintf("--append needs --find to match exactly one place on the page; it matched %d", max(len(matches), strings.Count(body, selection)))}
+		}
+		return mcp.UpdatePageRequest{Command: "insert_content_after", Selection: selection, NewStr: appendText}, 1, nil
+	}
+
+	replacement := func(m []int) string {
+		if !regex {
+			return replaceWith
+		}
+		return string(re.ExpandString(nil, replaceWith, body, m))
+	}
+
+	updates := make([]mcp.ContentUpdate, 0, len(matches))
+	precise := true
+	for _, m := range matches {
+		old := body[m[0]:m[1]]
+		if strings.Count(body, old) > 1 {
+			precise = false
+			break
+		}
+		updates = append(updates, mcp.ContentUpdate{OldStr: old, NewStr: replacement(m)})
+	}
+	if precise {
+		return mcp.UpdatePageRequest{Command: "update_content", ContentUpdates: updates}, len(matches), nil
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(body[last:m[0]])
+		b.WriteString(replacement(m))
+		last = m[1]
+	}
+	b.WriteString(body[last:])
+	return mcp.UpdatePageRequest{Command: "replace_content", NewContent: b.String()}, len(matches), nil
+}
+
+// resolvePatternEdit fetches the page and rewrites req, a --find edit, with
+// buildPatternEdit.
+func resolvePatternEdit(ctx context.Context, client *mcp.Client, pageID, find, replaceWith, appendText string, regex, ignoreCase bool) (mcp.UpdatePageRequest, int, error) {
+	re, err := compileFindPattern(find, regex, ignoreCase)
+	if err != nil {
+		return mcp.UpdatePageRequest{}, 0, err
+	}
+	result, err := client.Fetch(ctx, pageID)
+	if err != nil {
+		return mcp.UpdatePageRequest{}, 0, err
+	}
+	body, ok := output.PageNotionMarkup(result.Content)
+	if !ok {
+		return mcp.UpdatePageRequest{}, 0, &output.UserError{Message: "could not read the page content to match --find against"}
+	}
+	return buildPatternEdit(body, re, replaceWith, appendText, regex)
+}
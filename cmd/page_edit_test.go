@@ -231,3 +231,92 @@ func TestResolveFetchIDUsesCanonicalIDForKnownRefKinds(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAppendAfter(t *testing.T) {
+	if err := validateAppendAfter("", "", "", "text", nil); err != nil {
+		t.Fatalf("--append with --after: %v", err)
+	}
+	for _, tt := range []struct {
+		name                                 string
+		replace, find, replaceWith, appendTx string
+		props                                []string
+	}{
+		{name: "without append"},
+		{name: "with find", find: "x", appendTx: "y"},
+		{name: "with replace", replace: "x", appendTx: "y"},
+		{name: "with props", appendTx: "y", props: []string{"Status=Done"}},
+	} {
+		if err := validateAppendAfter(tt.replace, tt.find, tt.replaceWith, tt.appendTx, tt.props); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestBuildPatternEditUsesExactUpdatesForUniqueMatches(t *testing.T) {
+	body := "Version: v1.2\nStatus: draft\nOwner: Ana"
+	re, err := compileFindPattern(`v(\d+)\.(\d+)`, true, false)
+	if err != nil {
+		t.Fatalf("compileFindPattern: %v", err)
+	}
+
+	req, n, err := buildPatternEdit(body, re, "v$1.${2}-rc", "", true)
+	if err != nil {
+		t.Fatalf("buildPatternEdit: %v", err)
+	}
+	want := []mcp.ContentUpdate{{OldStr: "v1.2", NewStr: "v1.2-rc"}}
+	if n != 1 || req.Command != "update_content" || !reflect.DeepEqual(req.ContentUpdates, want) {
+		t.Fatalf("req = %+v, n = %d", req, n)
+	}
+}
+
+func TestBuildPatternEditFallsBackToReplaceContent(t *testing.T) {
+	body := "TODO: one\nDone: two\ntodo: three\nTODO: one"
+	re, err := compileFindPattern("todo", false, true)
+	if err != nil {
+		t.Fatalf("compileFindPattern: %v", err)
+	}
+
+	req, n, err := buildPatternEdit(body, re, "DONE", "", false)
+	if err != nil {
+		t.Fatalf("buildPatternEdit: %v", err)
+	}
+	if n != 3 || req.Command != "replace_content" || req.NewContent != "DONE: one\nDone: two\nDONE: three\nDONE: one" {
+		t.Fatalf("req = %+v, n = %d", req, n)
+	}
+}
+
+func TestBuildPatternEditAppendNeedsSingleMatch(t *testing.T) {
+	body := "## Notes\ntext\n## notes archive"
+	re, err := compileFindPattern("## notes", false, true)
+	if err != nil {
+		t.Fatalf("compileFindPattern: %v", err)
+	}
+	if _, _, err := buildPatternEdit(body, re, "", "extra", false); err == nil {
+		t.Fatal("expected error for ambiguous --append match")
+	}
+
+	re, err = compileFindPattern(`(?m)^## Notes$`, true, false)
+	if err != nil {
+		t.Fatalf("compileFindPattern: %v", err)
+	}
+	req, _, err := buildPatternEdit(body, re, "", "extra", true)
+	if err != nil {
+		t.Fatalf("buildPatternEdit: %v", err)
+	}
+	if req.Command != "insert_content_after" || req.Selection != "## Notes" || req.NewStr != "extra" {
+		t.Fatalf("req = %+v", req)
+	}
+}
+
+func TestCompileFindPatternKeepsEllipsis(t *testing.T) {
+	re, err := compileFindPattern("Start...end.", false, true)
+	if err != nil {
+		t.Fatalf("compileFindPattern: %v", err)
+	}
+	if got := re.FindString("x start of it, the END. y"); got != "start of it, the END." {
+		t.Fatalf("match = %q", got)
+	}
+	if _, err := compileFindPattern("(", true, false); err == nil {
+		t.Fatal("expected error for invalid regex")
+	}
+}
@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+const (
+	exportPageFile  = "page.md"
+	exportAssetsDir = "assets"
+)
+
+type PageExportCmd struct {
+	Page           string `arg:"" help:"Page URL, name, or ID"`
+	Output         string `help:"Write page.md into this directory instead of printing to stdout" short:"o" placeholder:"DIR"`
+	WithAssets     bool   `help:"Download images and file attachments into DIR/assets and link to the local copies" name:"with-assets"`
+	Recursive      bool   `help:"Also export child pages, each into a subdirectory of its parent's" short:"r"`
+	MaxDepth       int    `help:"With --recursive, stop this many levels below the page (0 for no limit)" name:"max-depth" placeholder:"N"`
+	MaxPages       int    `help:"With --recursive, export at most this many pages (0 for no limit)" name:"max-pages" placeholder:"N"`
+	RewriteLinks   bool   `help:"Point links to pages in the export at their local page.md files instead of notion.so" name:"rewrite-links"`
+	IgnoreFailures bool   `help:"With --with-assets, exit zero even if some assets could not be downloaded" name:"ignore-failures"`
+	JSON           bool   `help:"Output as JSON" short:"j"`
+}
+
+// pageExportOptions carries the page export flags.
+type pageExportOptions struct {
+	Dir          string
+	WithAssets   bool
+	Recursive    bool
+	RewriteLinks bool
+	// IgnoreFailures keeps the exit status zero when assets fail to download.
+	IgnoreFailures bool
+	// Limits bounds a --recursive export.
+	Limits cli.TreeLimits
+}
+
+func (c *PageExportCmd) Run(ctx *Context) error {
+	ctx.JSON = ctx.JSON || c.JSON
+	return runPageExport(ctx, c.Page, pageExportOptions{
+		Dir:            c.Output,
+		WithAssets:     c.WithAssets,
+		Recursive:      c.Recursive,
+		RewriteLinks:   c.RewriteLinks,
+		IgnoreFailures: c.IgnoreFailures,
+		Limits:         cli.TreeLimits{MaxDepth: c.MaxDepth, MaxNodes: c.MaxPages},
+	})
+}
+
+type exportedAsset struct {
+	URL   string `json:"url"`
+	Path  string `json:"path,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type pageExportResult struct {
+	ID       string             `json:"id"`
+	Title    string             `json:"title,omitempty"`
+	URL      string             `json:"url,omitempty"`
+	Path     string             `json:"path,omitempty"`
+	Assets   []exportedAsset    `json:"assets,omitempty"`
+	Children []pageExportResult `json:"children,omitempty"`
+}
+
+var exportHTTPClient = &http.Client{Timeout: 2 * time.Minute}
+
+func runPageExport(ctx *Context, page string, opts pageExportOptions) error {
+	dir := opts.Dir
+	if opts.WithAssets && dir == "" {
+		err := &output.UserError{Message: "--with-assets requires --output DIR"}
+		output.PrintError(err)
+		return err
+	}
+	if opts.IgnoreFailures && !opts.WithAssets {
+		err := &output.UserError{Message: "--ignore-failures requires --with-assets"}
+		output.PrintError(err)
+		return err
+	}
+	if (opts.Recursive || opts.RewriteLinks) && dir == "" {
+		err := &output.UserError{Message: "--recursive and --rewrite-links require --output DIR"}
+		output.PrintError(err)
+		return err
+	}
+	if opts.Limits.MaxDepth < 0 || opts.Limits.MaxNodes < 0 {
+		err := &output.UserError{Message: "--max-depth and --max-pages cannot be negative"}
+		output.PrintError(err)
+		return err
+	}
+	if (opts.Limits.MaxDepth > 0 || opts.Limits.MaxNodes > 0) && !opts.Recursive {
+		err := &output.UserError{Message: "--max-depth and --max-pages require --recursive"}
+		output.PrintError(err)
+		return err
+	}
+	if ctx.JSON && dir == "" {
+		err := &output.UserError{Message: "--json requires --output DIR; without it the markdown is printed to stdout"}
+		output.PrintError(err)
+		return err
+	}
+
+	client, err := cli.RequireClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	bgCtx := context.Background()
+	fetchID, err := resolveFetchID(bgCtx, page, cli.ParsePageRef(page), client, cli.ResolvePageID)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	if dir == "" {
+		result, err := client.Fetch(bgCtx, fetchID)
+		if err != nil {
+			output.PrintError(err)
+			return err
+		}
+		fmt.Print(exportMarkdown(result.Title, output.PageMarkdown(result.Content)))
+		return nil
+	}
+
+	root, truncated, err := collectExportPages(bgCtx, client, fetchID, dir, opts.WithAssets, opts.Recursive, opts.Limits)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if truncated && !ctx.JSON {
+		printWarningFn("Some child pages were not exported because of --max-depth or --max-pages")
+	}
+	pages := 0
+	var batch cli.BatchResult
+	root.walk(func(p *exportedPage) {
+		pages++
+		for _, asset := range p.result.Assets {
+			if asset.Error != "" {
+				batch.Fail(asset.URL, errors.New(asset.Error))
+			} else {
+				batch.Success()
+			}
+		}
+	})
+
+	exported, err := writeExportedPages(root, opts.RewriteLinks)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	if ctx.JSON {
+		if batch.Failed() > 0 {
+			printBatchSummary(&batch, true)
+		}
+		if err := output.WriteStructured(os.Stdout, exported); err != nil {
+			return err
+		}
+		return batch.Err(opts.IgnoreFailures)
+	}
+	output.PrintSuccess("Exported: " + exported.Path)
+	if pages > 1 {
+		output.PrintInfo(fmt.Sprintf("%d pages in %s", pages, dir))
+	}
+	if batch.Succeeded > 0 {
+		where := filepath.Join(dir, exportAssetsDir)
+		if pages > 1 {
+			where = "each page's " + exportAssetsDir + " directory"
+		}
+		output.PrintInfo(fmt.Sprintf("%d assets in %s", batch.Succeeded, where))
+	}
+	if batch.Failed() > 0 {
+		printBatchSummary(&batch, false)
+	}
+	return batch.Err(opts.IgnoreFailures)
+}
+
+func exportMarkdown(title, body string) string {
+	var b strings.Builder
+	if title != "" {
+		b.WriteString("# " + title + "\n\n")
+	}
+	if body != "" {
+		b.WriteString(body + "\n")
+	}
+	return b.String()
+}
+
+// exportAssets downloads the images and attachments referenced by content
+// into dir/assets and rewrites content to link to them relative to dir.
+// An asset that cannot be downloaded keeps its remote link and is reported
+// with its error rather than failing the export.
+func exportAssets(ctx context.Context, client *http.Client, content, dir string) (string, []exportedAsset, error) {
+	urls := cli.RemoteAssetURLs(content)
+	if len(urls) == 0 {
+		return content, nil, nil
+	}
+	assetsDir := filepath.Join(dir, exportAssetsDir)
+	if err := os.MkdirAll(assetsDir, 0o755); err != nil {
+		return "", nil, err
+	}
+
+	used := make(map[string]bool)
+	local := make(map[string]string, len(urls))
+	assets := make([]exportedAsset, 0, len(urls))
+	for _, u := range urls {
+		name, err := downloadAsset(ctx, client, u, "", assetsDir, used)
+		if err != nil {
+			assets = append(assets, exportedAsset{URL: u, Error: err.Error()})
+			continue
+		}
+		rel := exportAssetsDir + "/" + name
+		local[u] = rel
+		assets = append(assets, exportedAsset{URL: u, Path: rel})
+	}
+	return cli.RewriteRemoteAssets(content, local), assets, nil
+}
+
+// downloadAsset saves rawURL into dir under name, or a name taken from the
+// URL path when name is empty, adding an extension from the Content-Type
+// when it has none and a numeric suffix when the name is already used.
+func downloadAsset(ctx context.Context, client *http.Client, rawURL, name, dir string, used map[string]bool) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("server returned %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	if name == "" {
+		name = assetFileName(rawURL, resp.Header.Get("Content-Type"))
+	} else {
+		name = safeAssetName(name, resp.Header.Get("Content-Type"))
+	}
+	name = uniqueAssetName(name, used)
+	target := filepath.Join(dir, name)
+	f, err := os.Create(target)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		_ = f.Close()
+		_ = os.Remove(target)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// assetFileName derives a safe file name from the last segment of rawURL's
+// path. Notion's signed file URLs end in the original file name.
+func assetFileName(rawURL, contentType string) string {
+	name := ""
+	if parsed, err := url.Parse(rawURL); err == nil {
+		name = path.Base(parsed.Path)
+	}
+	return safeAssetName(name, contentType)
+}
+
+// safeAssetName strips characters that are unsafe in file names from name
+// and adds an extension from contentType when it has none.
+func safeAssetName(name, contentType string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r == ' ':
+			return '-'
+		case r < 0x20, strings.ContainsRune(`/\:*?"<>|()[]`, r):
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimLeft(name, ".")
+	if name == "" {
+		name = "asset"
+	}
+	if path.Ext(name) == "" {
+		if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+			if mediaType == "image/jpeg" {
+				name += ".jpg"
+			} else if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+				name += exts[0]
+			}
+		}
+	}
+	return name
+}
+
+func uniqueAssetName(name string, used map[string]bool) string {
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	candidate := name
+	for i := 2; used[strings.ToLower(candidate)]; i++ {
+		candidate = stem + "-" + strconv.Itoa(i) + ext
+	}
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+func TestExportAssets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a/diagram.png", "/b/diagram.png":
+			_, _ = w.Write([]byte("png " + r.URL.Path))
+		case "/blob":
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte("pdf"))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+
+	content := "<content>\n![One](" + srv.URL + "/a/diagram.png)\n" +
+		"![Two]({{" + srv.URL + "/b/diagram.png}})\n" +
+		"<file src=\"" + srv.URL + "/blob\">Spec</file>\n" +
+		"![Gone](" + srv.URL + "/expired.png)\n</content>"
+
+	dir := t.TempDir()
+	rewritten, assets, err := exportAssets(context.Background(), srv.Client(), content, dir)
+	if err != nil {
+		t.Fatalf("exportAssets: %v", err)
+	}
+
+	wantPaths := []string{"assets/diagram.png", "assets/diagram-2.png", "assets/blob.pdf", ""}
+	if len(assets) != len(wantPaths) {
+		t.Fatalf("assets = %#v", assets)
+	}
+	for i, want := range wantPaths {
+		if assets[i].Path != want {
+			t.Fatalf("assets[%d].Path = %q, want %q", i, assets[i].Path, want)
+		}
+	}
+	if assets[3].Error == "" {
+		t.Fatalf("expected an error for the failed download: %#v", assets[3])
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "assets", "diagram-2.png"))
+	if err != nil || string(data) != "png /b/diagram.png" {
+		t.Fatalf("diagram-2.png = %q, %v", data, err)
+	}
+	for _, want := range []string{
+		"![One](assets/diagram.png)",
+		"![Two](assets/diagram-2.png)",
+		"[Spec](assets/blob.pdf)",
+		"![Gone](" + srv.URL + "/expired.png)",
+	} {
+		if !strings.Contains(rewritten, want) {
+			t.Fatalf("rewritten content missing %q:\n%s", want, rewritten)
+		}
+	}
+}
+
+func TestAssetFileName(t *testing.T) {
+	tests := []struct {
+		url, contentType, want string
+	}{
+		{"https://files.example.com/x/My%20Photo.png?sig=1", "", "My-Photo.png"},
+		{"https://files.example.com/", "image/jpeg", "asset.jpg"},
+		{"https://files.example.com/.hidden", "", "hidden"},
+	}
+	for _, tt := range tests {
+		if got := assetFileName(tt.url, tt.contentType); got != tt.want {
+			t.Errorf("assetFileName(%q, %q) = %q, want %q", tt.url, tt.contentType, got, tt.want)
+		}
+	}
+}
+
+type fakeExportFetcher map[string]*mcp.FetchResult
+
+func (f fakeExportFetcher) Fetch(_ context.Context, id string) (*mcp.FetchResult, error) {
+	result, ok := f[id]
+	if !ok {
+		return nil, errors.New("not found: " + id)
+	}
+	return result, nil
+}
+
+func TestExportSubtreeRewritesLinks(t *testing.T) {
+	const (
+		rootID  = "11111111-1111-1111-1111-111111111111"
+		childID = "22222222-2222-2222-2222-222222222222"
+		leafID  = "33333333-3333-3333-3333-333333333333"
+	)
+	fetcher := fakeExportFetcher{
+		rootID: {Title: "Wiki", Content: "<content>\nSee <mention-page url=\"{{https://www.notion.so/33333333333333333333333333333333}}\"/>.\n" +
+			"<page url=\"{{https://www.notion.so/22222222222222222222222222222222}}\">Guides</page>\n" +
+			"[Elsewhere](https://www.notion.so/Other-44444444444444444444444444444444)\n</content>"},
+		childID: {Title: "Guides", Content: "<content>\n<page url=\"{{https://www.notion.so/33333333333333333333333333333333}}\">Setup Guide</page>\n" +
+			"[Home](https://www.notion.so/Wiki-11111111111111111111111111111111)\n</content>"},
+		leafID: {Title: "Setup Guide", Content: "<content>\nSteps.\n</content>"},
+	}
+
+	dir := t.TempDir()
+	root, _, err := collectExportPages(context.Background(), fetcher, rootID, dir, false, true, cli.TreeLimits{})
+	if err != nil {
+		t.Fatalf("collectExportPages: %v", err)
+	}
+	tree, err := writeExportedPages(root, true)
+	if err != nil {
+		t.Fatalf("writeExportedPages: %v", err)
+	}
+	if len(tree.Children) != 1 || len(tree.Children[0].Children) != 1 {
+		t.Fatalf("tree = %#v", tree)
+	}
+
+	read := func(rel string) string {
+		data, err := os.ReadFile(filepath.Join(dir, rel))
+		if err != nil {
+			t.Fatalf("read %s: %v", rel, err)
+		}
+		return string(data)
+	}
+	rootMD := read("page.md")
+	for _, want := range []string{
+		"[→ page](guides/setup-guide/page.md)",
+		"[📄 Guides](guides/page.md)",
+		"[Elsewhere](https://www.notion.so/Other-44444444444444444444444444444444)",
+	} {
+		if !strings.Contains(rootMD, want) {
+			t.Fatalf("root page.md missing %q:\n%s", want, rootMD)
+		}
+	}
+	if guides := read("guides/page.md"); !strings.Contains(guides, "[Home](../page.md)") {
+		t.Fatalf("guides/page.md = %s", guides)
+	}
+	if leaf := read("guides/setup-guide/page.md"); !strings.Contains(leaf, "Steps.") {
+		t.Fatalf("leaf page.md = %s", leaf)
+	}
+}
+
+func TestExportSubtreeKeepsAssetsDirForImages(t *testing.T) {
+	const (
+		rootID  = "11111111-1111-1111-1111-111111111111"
+		childID = "22222222-2222-2222-2222-222222222222"
+	)
+	fetcher := fakeExportFetcher{
+		rootID:  {Title: "Wiki", Content: "<content>\n<page url=\"{{https://www.notion.so/22222222222222222222222222222222}}\">Assets</page>\n</content>"},
+		childID: {Title: "Assets", Content: "<content>\nLogos.\n</content>"},
+	}
+
+	root, _, err := collectExportPages(context.Background(), fetcher, rootID, t.TempDir(), false, true, cli.TreeLimits{})
+	if err != nil {
+		t.Fatalf("collectExportPages: %v", err)
+	}
+	if got := filepath.Base(filepath.Dir(root.children[0].result.Path)); got != "assets-2" {
+		t.Fatalf("child page dir = %q, want assets-2", got)
+	}
+}
+
+func TestPageExportIgnoreFailuresNeedsAssets(t *testing.T) {
+	err := runPageExport(&Context{}, "page", pageExportOptions{Dir: t.TempDir(), IgnoreFailures: true})
+	var userErr *output.UserError
+	if !errors.As(err, &userErr) || !strings.Contains(userErr.Message, "--with-assets") {
+		t.Fatalf("error = %v, want --with-assets UserError", err)
+	}
+}
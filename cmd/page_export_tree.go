@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// exportFetcher is the part of the MCP client page export uses.
+type exportFetcher interface {
+	Fetch(ctx context.Context, id string) (*mcp.FetchResult, error)
+}
+
+// exportedPage is a page fetched for export, with its rendered markdown and,
+// under --recursive, its exported child pages.
+type exportedPage struct {
+	result   pageExportResult
+	markdown string
+	children []*exportedPage
+}
+
+var childPageTagRe = regexp.MustCompile(`<page\s+url="\{*([^"}]+)\}*"[^>]*>([^<]*)</page>`)
+
+// exportChildPages lists the child pages embedded in fetched page content,
+// in document order.
+func exportChildPages(content string) []cli.PageChild {
+	body, ok := output.PageNotionMarkup(content)
+	if !ok {
+		return nil
+	}
+	var children []cli.PageChild
+	for _, m := range childPageTagRe.FindAllStringSubmatch(body, -1) {
+		if id, ok := cli.ExtractNotionUUID(m[1]); ok {
+			children = append(children, cli.PageChild{ID: id, Title: strings.TrimSpace(m[2])})
+		}
+	}
+	return children
+}
+
+// collectExportPages fetches the page id for export into dir and, when
+// recursive, the child pages below it within limits, each into a
+// subdirectory of its parent's named after a slug of its title. A child
+// whose slug is the assets directory's name gets a suffix instead, so its
+// folder never mixes with its parent's images. truncated reports whether
+// limits left child pages out.
+func collectExportPages(ctx context.Context, client exportFetcher, id, dir string, withAssets, recursive bool, limits cli.TreeLimits) (root *exportedPage, truncated bool, err error) {
+	pages := make(map[string]*exportedPage)
+	tree, truncated, err := cli.WalkPageTree(ctx, cli.PageChild{ID: id}, limits, []string{exportAssetsDir}, func(ctx context.Context, node *cli.PageNode) ([]cli.PageChild, error) {
+		pageDir := filepath.Join(append([]string{dir}, node.Path()...)...)
+		result, err := client.Fetch(ctx, node.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		page := &exportedPage{result: pageExportResult{
+			ID:    node.ID,
+			Title: result.Title,
+			URL:   result.URL,
+			Path:  filepath.Join(pageDir, exportPageFile),
+		}}
+		content := result.Content
+		if withAssets {
+			content, page.result.Assets, err = exportAssets(ctx, exportHTTPClient, content, pageDir)
+			if err != nil {
+				return nil, err
+			}
+		}
+		page.markdown = exportMarkdown(result.Title, output.PageMarkdown(content))
+		pages[node.ID] = page
+		if !recursive {
+			return nil, nil
+		}
+		return exportChildPages(result.Content), nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	tree.Walk(func(node *cli.PageNode) {
+		for _, child := range node.Children {
+			pages[node.ID].children = append(pages[node.ID].children, pages[child.ID])
+		}
+	})
+	return pages[tree.ID], truncated, nil
+}
+
+// walk calls fn for page and every page below it.
+func (p *exportedPage) walk(fn func(*exportedPage)) {
+	fn(p)
+	for _, child := range p.children {
+		child.walk(fn)
+	}
+}
+
+var notionLinkRe = regexp.MustCompile(`\]\((https?://[^)\s]*notion\.(?:so|site)/[^)\s]*)\)`)
+
+// rewriteExportLinks points markdown links to Notion pages that are part of
+// the export at the exported page.md files, relative to from. Links to
+// pages outside the export are left alone.
+func rewriteExportLinks(markdown, from string, paths map[string]string) string {
+	return notionLinkRe.ReplaceAllStringFunc(markdown, func(m string) string {
+		link := notionLinkRe.FindStringSubmatch(m)[1]
+		id, ok := cli.ExtractNotionUUID(link)
+		if !ok {
+			return m
+		}
+		target, ok := paths[id]
+		if !ok {
+			return m
+		}
+		rel, err := filepath.Rel(filepath.Dir(from), target)
+		if err != nil {
+			return m
+		}
+		return "](" + filepath.ToSlash(rel) + ")"
+	})
+}
+
+// writeExportedPages writes every collected page, rewriting links between
+// them first when rewriteLinks is set, and returns the results as a tree.
+func writeExportedPages(root *exportedPage, rewriteLinks bool) (pageExportResult, error) {
+	paths := make(map[string]string)
+	root.walk(func(p *exportedPage) { paths[p.result.ID] = p.result.Path })
+
+	var err error
+	root.walk(func(p *exportedPage) {
+		if err != nil {
+			return
+		}
+		markdown := p.markdown
+		if rewriteLinks {
+			markdown = rewriteExportLinks(markdown, p.result.Path, paths)
+		}
+		if err = os.MkdirAll(filepath.Dir(p.result.Path), 0o755); err == nil {
+			err = os.WriteFile(p.result.Path, []byte(markdown), 0o644)
+		}
+	})
+	return root.tree(), err
+}
+
+// tree returns the page's export result with its children nested.
+func (p *exportedPage) tree() pageExportResult {
+	result := p.result
+	for _, child := range p.children {
+		result.Children = append(result.Children, child.tree())
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/lox/notion-cli/internal/api"
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// parentIconForCreate looks up the parent page's icon before the new page
+// is created, so that it can go into the create request and the page never
+// exists without it. Emoji and external icons are returned as the value for
+// CreatePageRequest.Icon. A custom emoji cannot be expressed there and is
+// returned as deferred, to be applied with setDeferredIcon once the page
+// exists. Problems are reported as warnings rather than failing the command.
+func parentIconForCreate(ctx *Context, bgCtx context.Context, parentID string) (icon string, deferred *api.PageIcon) {
+	if parentID == "" {
+		warnIcon(ctx, "Skipping --icon-from-parent: parent page ID is unknown")
+		return "", nil
+	}
+	apiClient, err := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx))
+	if err != nil {
+		warnIcon(ctx, "Skipping --icon-from-parent: "+err.Error())
+		return "", nil
+	}
+	parentIcon, err := fetchParentIcon(bgCtx, apiClient, parentID)
+	if err != nil {
+		warnIcon(ctx, "Unable to copy parent icon: "+err.Error())
+		return "", nil
+	}
+	if icon := createIcon(parentIcon); icon != "" {
+		return icon, nil
+	}
+	return "", parentIcon
+}
+
+// setDeferredIcon applies an icon that could not be part of the create
+// request to the new page.
+func setDeferredIcon(ctx *Context, bgCtx context.Context, pageID string, icon *api.PageIcon) {
+	if icon == nil {
+		return
+	}
+	if pageID == "" {
+		warnIcon(ctx, "Unable to copy parent icon: new page ID is unknown")
+		return
+	}
+	apiClient, err := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx))
+	if err == nil {
+		err = apiClient.SetPageIcon(bgCtx, pageID, icon)
+	}
+	if err != nil {
+		warnIcon(ctx, "Unable to copy parent icon: "+err.Error())
+	}
+}
+
+func warnIcon(ctx *Context, message string) {
+	if !ctx.JSON {
+		printWarningFn(message)
+	}
+}
+
+// fetchParentIcon returns the parent page's icon. A parent without an icon
+// is not an error and returns nil.
+func fetchParentIcon(bgCtx context.Context, apiClient *api.Client, parentID string) (*api.PageIcon, error) {
+	parent, err := apiClient.RetrievePage(bgCtx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent.Icon == nil {
+		return nil, nil
+	}
+	if !parent.Icon.Settable() {
+		return nil, fmt.Errorf("parent icon of type %q cannot be copied", parent.Icon.Type)
+	}
+	return parent.Icon, nil
+}
+
+// createIcon returns icon as a CreatePageRequest.Icon value: the emoji or
+// the external image URL. Other icons, and nil, give "".
+func createIcon(icon *api.PageIcon) string {
+	switch {
+	case icon == nil:
+		return ""
+	case icon.Type == "emoji":
+		return icon.Emoji
+	case icon.Type == "external" && icon.External != nil:
+		return icon.External.URL
+	}
+	return ""
+}
+
+// checkCoverURL rejects a --cover value that is not an http(s) URL.
+func checkCoverURL(cover string) error {
+	if cover == "" || isURL(cover) {
+		return nil
+	}
+	return &output.UserError{Message: fmt.Sprintf("--cover must be an http(s) image URL, got %q", cover)}
+}
+
+func isURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (strings.EqualFold(u.Scheme, "http") || strings.EqualFold(u.Scheme, "https")) && u.Host != ""
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/api"
+	"github.com/lox/notion-cli/internal/config"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+func TestFetchParentIconSkipsParentWithoutIcon(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/pages/parent" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"object":"page","id":"parent","icon":null}`))
+	}))
+	defer srv.Close()
+
+	client, err := api.NewClient(config.APIConfig{BaseURL: srv.URL + "/v1"}, "secret-token")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	icon, err := fetchParentIcon(context.Background(), client, "parent")
+	if err != nil || icon != nil {
+		t.Fatalf("fetchParentIcon = %#v, %v; want nil, nil", icon, err)
+	}
+}
+
+func TestFetchParentIconRejectsFileIcons(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"object":"page","id":"parent","icon":{"type":"file","file":{"url":"https://files.example/i.png"}}}`))
+	}))
+	defer srv.Close()
+
+	client, err := api.NewClient(config.APIConfig{BaseURL: srv.URL + "/v1"}, "secret-token")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := fetchParentIcon(context.Background(), client, "parent"); err == nil {
+		t.Fatal("expected error for file icon")
+	}
+}
+
+func TestCreateIcon(t *testing.T) {
+	tests := []struct {
+		icon *api.PageIcon
+		want string
+	}{
+		{nil, ""},
+		{&api.PageIcon{Type: "emoji", Emoji: "🚀"}, "🚀"},
+		{&api.PageIcon{Type: "external", External: &api.PageIconExternal{URL: "https://example.com/i.png"}}, "https://example.com/i.png"},
+		{&api.PageIcon{Type: "custom_emoji", CustomEmoji: &api.PageIconCustom{ID: "c1"}}, ""},
+	}
+	for _, tt := range tests {
+		if got := createIcon(tt.icon); got != tt.want {
+			t.Errorf("createIcon(%#v) = %q, want %q", tt.icon, got, tt.want)
+		}
+	}
+}
+
+func TestPageTitleIcon(t *testing.T) {
+	tests := []struct {
+		title, icon string
+		strip       bool
+		wantTitle   string
+		wantIcon    string
+	}{
+		{"🚀 Launch ✨ plan", "", false, "Launch ✨ plan", "🚀"},
+		{"🚀 Launch ✨ plan", "", true, "Launch plan", "🚀"},
+		{"🚀 Launch ✨ plan", "📝", true, "Launch plan", "📝"},
+		{"Team 👩‍💻 notes 🇳🇿", "", true, "Team notes", ""},
+		{":rocket: Launch", "", false, "Launch", "🚀"},
+		{":rocket: Launch", "📝", false, ":rocket: Launch", "📝"},
+		{":fire:", "", false, ":fire:", "🔥"},
+		{"1️⃣ Kickoff", "", false, "Kickoff", "1️⃣"},
+		{"🇳🇿 Trip", "", false, "Trip", "🇳🇿"},
+		{"👩‍💻Notes", "", false, "Notes", "👩‍💻"},
+		{"€5 budget", "", false, "€5 budget", ""},
+	}
+	for _, tt := range tests {
+		title, icon := pageTitleIcon(tt.title, tt.icon, tt.strip)
+		if title != tt.wantTitle || icon != tt.wantIcon {
+			t.Errorf("pageTitleIcon(%q, %q, %v) = %q, %q; want %q, %q", tt.title, tt.icon, tt.strip, title, icon, tt.wantTitle, tt.wantIcon)
+		}
+	}
+}
+
+func TestPageSyncChecksCoverURL(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "doc.md")
+	if err := os.WriteFile(file, []byte("# Doc\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	var userErr *output.UserError
+	if err := runPageSync(&Context{JSON: true}, file, pageFileOptions{Cover: "banner.png"}); !errors.As(err, &userErr) || !strings.Contains(userErr.Message, "--cover") {
+		t.Fatalf("runPageSync error = %v, want a --cover error", err)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lox/notion-cli/internal/api"
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// runPageListDatabase lists the pages of one database through the official
+// API query endpoint, rather than searching the whole workspace.
+func runPageListDatabase(ctx *Context, database, query string, limit int, filter timeFilter) error {
+	client, err := cli.RequireClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	bgCtx := context.Background()
+	dbID, err := cli.ResolveDatabaseID(bgCtx, client, database)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	dataSourceID, _ := resolveDataSource(bgCtx, client, dbID)
+
+	apiClient, err := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx), api.FeatureDataSources)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	// Name and time filters are applied locally, so they cannot bound the
+	// query itself.
+	queryLimit := limit
+	if query != "" || filter.active() {
+		queryLimit = 0
+	}
+	results, err := apiClient.QueryDataSource(bgCtx, dataSourceID, nil, queryLimit)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	results = filter.apply(results)
+
+	return output.PrintPages(queriedPagesToOutput(results, query, limit), ctx.JSON)
+}
+
+func queriedPagesToOutput(results []api.QueriedPage, query string, limit int) []output.Page {
+	query = strings.ToLower(strings.TrimSpace(query))
+	pages := make([]output.Page, 0, len(results))
+	for _, r := range results {
+		title := r.Title()
+		if query != "" && !strings.Contains(strings.ToLower(title), query) {
+			continue
+		}
+		if limit > 0 && len(pages) >= limit {
+			break
+		}
+		pages = append(pages, output.Page{
+			ID:             r.ID,
+			Title:          title,
+			URL:            r.URL,
+			CreatedTime:    r.CreatedTime,
+			LastEditedTime: r.LastEditedTime,
+		})
+	}
+	return pages
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/lox/notion-cli/internal/api"
+)
+
+func TestQueriedPagesToOutputFiltersByTitleAndLimit(t *testing.T) {
+	title := func(s string) map[string]api.PageProperty {
+		return map[string]api.PageProperty{
+			"Status": {Type: "status"},
+			"Name":   {Type: "title", Title: []api.RichText{{PlainText: s}}},
+		}
+	}
+	results := []api.QueriedPage{
+		{ID: "1", URL: "https://www.notion.so/1", Properties: title("Weekly sync")},
+		{ID: "2", Properties: title("Roadmap")},
+		{ID: "3", Properties: title("Sync retro")},
+	}
+
+	pages := queriedPagesToOutput(results, "sync", 0)
+	if len(pages) != 2 || pages[0].Title != "Weekly sync" || pages[0].URL != "https://www.notion.so/1" || pages[1].ID != "3" {
+		t.Fatalf("unexpected pages: %+v", pages)
+	}
+
+	pages = queriedPagesToOutput(results, "", 2)
+	if len(pages) != 2 || pages[1].Title != "Roadmap" {
+		t.Fatalf("unexpected limited pages: %+v", pages)
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"strings"
@@ -16,10 +17,61 @@ type uploadedLocalImage struct {
 	Alt          string
 	FileUploadID string
 	Placeholder  string
+	OriginalPath string
 	ResolvedPath string
 }
 
-func prepareLocalImageUploads(cmdCtx *Context, ctx context.Context, sourceFile, markdown string) (string, []uploadedLocalImage, error) {
+// uploadedAssets reports uploads for JSON output, using each image's path
+// as written in the markdown. It returns nil when nothing was uploaded.
+func uploadedAssets(uploads []uploadedLocalImage) []output.UploadedAsset {
+	if len(uploads) == 0 {
+		return nil
+	}
+	assets := make([]output.UploadedAsset, 0, len(uploads))
+	for _, u := range uploads {
+		assets = append(assets, output.UploadedAsset{
+			Path:         u.OriginalPath,
+			FileUploadID: u.FileUploadID,
+			Caption:      u.Alt,
+		})
+	}
+	return assets
+}
+
+// imageUploadCache implements --dedupe-images: it remembers file uploads by
+// content hash for the rest of a run, so an image shared by several
+// sections or files (or copied under another name) is uploaded once and
+// its file upload reused.
+type imageUploadCache struct {
+	ids    map[[sha256.Size]byte]string
+	reused int
+}
+
+// newImageUploadCache returns a cache when enabled, or nil, which
+// prepareLocalImageUploads treats as no cross-image deduplication.
+func newImageUploadCache(enabled bool) *imageUploadCache {
+	if !enabled {
+		return nil
+	}
+	return &imageUploadCache{ids: make(map[[sha256.Size]byte]string)}
+}
+
+// report prints how many uploads the cache avoided, if any.
+func (c *imageUploadCache) report(asJSON bool) {
+	if c == nil || c.reused == 0 || asJSON {
+		return
+	}
+	output.PrintInfo(fmt.Sprintf("Reused %d duplicate image upload(s)", c.reused))
+}
+
+// prepareLocalImageUploads uploads the standalone local images in markdown
+// and swaps them for placeholders. With skip set (--no-image-upload) the
+// markdown is returned unchanged, local references and all. A non-nil
+// cache reuses earlier uploads of identical image content.
+func prepareLocalImageUploads(cmdCtx *Context, ctx context.Context, sourceFile, markdown string, skip bool, cache *imageUploadCache) (string, []uploadedLocalImage, error) {
+	if skip {
+		return markdown, nil, nil
+	}
 	rewritten, placements, err := cli.RewriteStandaloneLocalImages(markdown, sourceFile)
 	if err != nil {
 		return "", nil, err
@@ -42,9 +94,21 @@ func prepareLocalImageUploads(cmdCtx *Context, ctx context.Context, sourceFile,
 			if err != nil {
 				return "", nil, fmt.Errorf("read local image %q: %w", placement.Resolved, err)
 			}
-			uploadID, err = apiClient.UploadFile(ctx, placement.Resolved, fileData)
-			if err != nil {
-				return "", nil, fmt.Errorf("upload local image %q: %w", placement.Resolved, err)
+			var hash [sha256.Size]byte
+			if cache != nil {
+				hash = sha256.Sum256(fileData)
+				uploadID, ok = cache.ids[hash]
+			}
+			if ok {
+				cache.reused++
+			} else {
+				uploadID, err = apiClient.UploadFile(ctx, placement.Resolved, fileData)
+				if err != nil {
+					return "", nil, fmt.Errorf("upload local image %q: %w", placement.Resolved, err)
+				}
+				if cache != nil {
+					cache.ids[hash] = uploadID
+				}
 			}
 			uploadIDByPath[placement.Resolved] = uploadID
 		}
@@ -53,6 +117,7 @@ func prepareLocalImageUploads(cmdCtx *Context, ctx context.Context, sourceFile,
 			Alt:          placement.Alt,
 			FileUploadID: uploadID,
 			Placeholder:  placement.Placeholder,
+			OriginalPath: placement.Original,
 			ResolvedPath: placement.Resolved,
 		})
 	}
@@ -60,11 +125,38 @@ func prepareLocalImageUploads(cmdCtx *Context, ctx context.Context, sourceFile,
 	return rewritten, uploads, nil
 }
 
+// checkStrictImages implements --strict-images: every image in the file,
+// local or remote, must be found before anything is written to Notion. All
+// broken images are reported together.
+func checkStrictImages(file string, opts pageFileOptions) error {
+	if !opts.StrictImages {
+		return nil
+	}
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	_, body := cli.ParseFrontmatter(string(raw))
+	body, err = preprocessBody(body, opts)
+	if err != nil {
+		return err
+	}
+	failures := cli.CheckImages(context.Background(), body, file)
+	if len(failures) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(failures))
+	for _, f := range failures {
+		lines = append(lines, "  "+f.Error())
+	}
+	return &output.UserError{Message: fmt.Sprintf("--strict-images: %d broken image(s) in %s:\n%s", len(failures), file, strings.Join(lines, "\n"))}
+}
+
 func requireLocalImageParent(uploads []uploadedLocalImage, parent, parentDB string) error {
 	if len(uploads) == 0 {
 		return nil
 	}
-	if strings.TrimSpace(parent) != "" || strings.TrimSpace(parentDB) != "" {
+	if (strings.TrimSpace(parent) != "" && !isWorkspaceParent(parent)) || strings.TrimSpace(parentDB) != "" {
 		return nil
 	}
 	return &output.UserError{
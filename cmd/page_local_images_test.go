@@ -3,12 +3,15 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/lox/notion-cli/internal/output"
 )
 
 func TestPrepareLocalImageUploadsUploadsAndDeduplicates(t *testing.T) {
@@ -47,7 +50,7 @@ func TestPrepareLocalImageUploadsUploadsAndDeduplicates(t *testing.T) {
 	rewritten, uploads, err := prepareLocalImageUploads(&Context{
 		APIToken:   "secret-token",
 		APIBaseURL: srv.URL + "/v1",
-	}, context.Background(), doc, "![One](./diagram.png)\n![Two](./diagram.png)\n")
+	}, context.Background(), doc, "![One](./diagram.png)\n![Two](./diagram.png)\n", false, nil)
 	if err != nil {
 		t.Fatalf("prepareLocalImageUploads: %v", err)
 	}
@@ -63,6 +66,28 @@ func TestPrepareLocalImageUploadsUploadsAndDeduplicates(t *testing.T) {
 	if !strings.Contains(rewritten, uploads[0].Placeholder) || !strings.Contains(rewritten, uploads[1].Placeholder) {
 		t.Fatalf("rewritten markdown missing placeholders: %q", rewritten)
 	}
+
+	assets := uploadedAssets(uploads)
+	if len(assets) != 2 || assets[0].Path != "./diagram.png" || assets[0].FileUploadID != "upload_123" || assets[1].Caption != "Two" {
+		t.Fatalf("uploadedAssets = %#v", assets)
+	}
+	if uploadedAssets(nil) != nil {
+		t.Fatal("expected no assets without uploads")
+	}
+}
+
+func TestPrepareLocalImageUploadsSkip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("NOTION_API_TOKEN", "")
+
+	markdown := "Intro ![inline](./missing.png)\n![Alt](./diagram.png)\n"
+	rewritten, uploads, err := prepareLocalImageUploads(&Context{}, context.Background(), "doc.md", markdown, true, nil)
+	if err != nil {
+		t.Fatalf("prepareLocalImageUploads: %v", err)
+	}
+	if rewritten != markdown || len(uploads) != 0 {
+		t.Fatalf("skip = %q, %#v", rewritten, uploads)
+	}
 }
 
 func TestSubstituteUploadedLocalImagesAppendsAfterPlaceholderAndDeletes(t *testing.T) {
@@ -148,3 +173,74 @@ func TestRequireLocalImageParent(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestCheckStrictImages(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "doc.md")
+	content := "---\nnotion-id: abc\n---\n\n![one](./missing.png)\n![two](./also-missing.png)\n"
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := checkStrictImages(file, pageFileOptions{}); err != nil {
+		t.Fatalf("without --strict-images: %v", err)
+	}
+
+	err := checkStrictImages(file, pageFileOptions{StrictImages: true})
+	var userErr *output.UserError
+	if !errors.As(err, &userErr) {
+		t.Fatalf("expected UserError, got %v", err)
+	}
+	for _, want := range []string{"2 broken image(s)", "./missing.png", "./also-missing.png"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q missing %q", err, want)
+		}
+	}
+}
+
+func TestPrepareLocalImageUploadsReusesCachedContent(t *testing.T) {
+	tmp := t.TempDir()
+	doc := filepath.Join(tmp, "doc.md")
+	for _, name := range []string{"logo.png", "logo-copy.png"} {
+		if err := os.WriteFile(filepath.Join(tmp, name), []byte("LOGO"), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	createCalls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/file_uploads":
+			createCalls++
+			_, _ = w.Write([]byte(`{"id":"upload_logo","status":"pending"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/file_uploads/upload_logo/send":
+			_, _ = w.Write([]byte(`{"id":"upload_logo","status":"uploaded"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/file_uploads/upload_logo":
+			_, _ = w.Write([]byte(`{"id":"upload_logo","status":"uploaded"}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	ctx := &Context{APIToken: "secret-token", APIBaseURL: srv.URL + "/v1"}
+	cache := newImageUploadCache(true)
+
+	// Two sections of one batch: the same image, then a copy under another name.
+	for _, section := range []string{"![Logo](./logo.png)\n", "![Logo](./logo-copy.png)\n"} {
+		_, uploads, err := prepareLocalImageUploads(ctx, context.Background(), doc, section, false, cache)
+		if err != nil {
+			t.Fatalf("prepareLocalImageUploads: %v", err)
+		}
+		if len(uploads) != 1 || uploads[0].FileUploadID != "upload_logo" {
+			t.Fatalf("uploads = %#v", uploads)
+		}
+	}
+	if createCalls != 1 || cache.reused != 1 {
+		t.Fatalf("createCalls = %d, reused = %d; want 1 and 1", createCalls, cache.reused)
+	}
+	if newImageUploadCache(false) != nil {
+		t.Fatal("expected no cache when disabled")
+	}
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import "github.com/lox/notion-cli/internal/output"
+
+// applyPageOutput makes a command that writes or views pages print only
+// the page URL (--url-only) or ID (--id-only). It runs the command in JSON
+// mode, which already keeps progress and warnings off stdout, and swaps the
+// JSON for the bare field. Both are rejected with --json.
+func applyPageOutput(ctx *Context, urlOnly, idOnly bool) error {
+	flag, mode := "--url-only", output.PageOutputURL
+	switch {
+	case urlOnly && idOnly:
+		return &output.UserError{Message: "--url-only and --id-only cannot be combined"}
+	case idOnly:
+		flag, mode = "--id-only", output.PageOutputID
+	case !urlOnly:
+		return nil
+	}
+	if ctx.JSON {
+		return &output.UserError{Message: flag + " cannot be combined with --json"}
+	}
+	ctx.JSON = true
+	output.SetPageOutput(mode)
+	return nil
+}
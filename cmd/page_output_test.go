@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/output"
+)
+
+func TestApplyPageOutput(t *testing.T) {
+	defer output.SetPageOutput(output.PageOutputJSON)
+
+	page := output.Page{ID: "p1", URL: "https://www.notion.so/p1", Title: "New"}
+	for _, tt := range []struct {
+		urlOnly, idOnly bool
+		want            string
+	}{
+		{urlOnly: true, want: "https://www.notion.so/p1\n"},
+		{idOnly: true, want: "p1\n"},
+	} {
+		ctx := &Context{}
+		if err := applyPageOutput(ctx, tt.urlOnly, tt.idOnly); err != nil {
+			t.Fatalf("applyPageOutput: %v", err)
+		}
+		if !ctx.JSON {
+			t.Fatal("expected the bare output to run in JSON mode")
+		}
+		stdout := captureStdout(t, func() {
+			if err := output.PrintPage(page, true); err != nil {
+				t.Fatalf("PrintPage: %v", err)
+			}
+		})
+		if stdout != tt.want {
+			t.Fatalf("stdout = %q, want %q", stdout, tt.want)
+		}
+	}
+
+	if err := applyPageOutput(&Context{JSON: true}, true, false); err == nil {
+		t.Fatal("expected --url-only with --json to fail")
+	}
+	if err := applyPageOutput(&Context{}, true, true); err == nil {
+		t.Fatal("expected --url-only with --id-only to fail")
+	}
+}
+
+func TestPageViewIDOnlyRejectsRenderFlags(t *testing.T) {
+	defer output.SetPageOutput(output.PageOutputJSON)
+
+	cmd := &PageViewCmd{Page: "abc", IDOnly: true, NoHeader: true, DownloadTo: "files"}
+	err := cmd.Run(&Context{})
+	if err == nil || !strings.Contains(err.Error(), "--id-only") || !strings.Contains(err.Error(), "--no-header, --download-to") {
+		t.Fatalf("err = %v", err)
+	}
+	if err := (&PageViewCmd{Page: "abc", IDOnly: true}).checkIDOnly(); err != nil {
+		t.Fatalf("checkIDOnly alone: %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/config"
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// parentResolver resolves --parent and --parent-db references for a single
+// run. When createMissing is set, a parent name with no matching page is
+// created as an empty top-level page. Resolved and created parents are
+// remembered so each name is only looked up (or created) once per run, which
+// matters for batch runs such as --split-on that create many pages under the
+// same parent.
+type parentResolver struct {
+	createMissing bool
+	quiet         bool
+	resolved      map[string]string
+	databases     map[string]resolvedDatabase
+
+	// defaultParent caches applyDefaultParent once defaultLoaded is set.
+	defaultLoaded bool
+	defaultParent string
+}
+
+// resolvedDatabase is a --parent-db reference resolved to the data source
+// pages are created in, along with its schema.
+type resolvedDatabase struct {
+	id     string
+	schema output.DatabaseSchema
+}
+
+func newParentResolver(createMissing, quiet bool) *parentResolver {
+	return &parentResolver{
+		createMissing: createMissing,
+		quiet:         quiet,
+		resolved:      make(map[string]string),
+		databases:     make(map[string]resolvedDatabase),
+	}
+}
+
+// withDefault returns parent, or the configured default parent when neither
+// parent nor parentDB is set. The default is looked up, and announced, only
+// the first time a page needs it, so a sync that only updates pages does not
+// report a parent it never uses.
+func (r *parentResolver) withDefault(ctx *Context, parent, parentDB string) string {
+	if parent != "" || parentDB != "" {
+		return parent
+	}
+	if !r.defaultLoaded {
+		r.defaultParent = applyDefaultParent(ctx, parent, parentDB)
+		r.defaultLoaded = true
+	}
+	return r.defaultParent
+}
+
+// resolveDatabase resolves a --parent-db reference to its data source ID and
+// schema, fetching the database only the first time it is seen.
+func (r *parentResolver) resolveDatabase(ctx context.Context, client *mcp.Client, parentDB string) (string, output.DatabaseSchema, error) {
+	key := strings.ToLower(strings.TrimSpace(parentDB))
+	if db, ok := r.databases[key]; ok {
+		return db.id, db.schema, nil
+	}
+
+	dbID, err := cli.ResolveDatabaseID(ctx, client, parentDB)
+	if err != nil {
+		return "", output.DatabaseSchema{}, err
+	}
+	dbID, schema := resolveDataSource(ctx, client, dbID)
+	r.databases[key] = resolvedDatabase{id: dbID, schema: schema}
+	return dbID, schema, nil
+}
+
+func (r *parentResolver) resolve(ctx context.Context, client *mcp.Client, parent string) (string, error) {
+	key := strings.ToLower(strings.TrimSpace(parent))
+	if id, ok := r.resolved[key]; ok {
+		return id, nil
+	}
+
+	id, err := cli.ResolvePageID(ctx, client, parent)
+	if err == nil {
+		r.resolved[key] = id
+		return id, nil
+	}
+	if !r.createMissing || !cli.IsNotFound(err) {
+		return "", err
+	}
+
+	resp, err := client.CreatePage(ctx, mcp.CreatePageRequest{Title: strings.TrimSpace(parent)})
+	if err != nil {
+		return "", err
+	}
+	id = pageIDFromCreateResponse(resp)
+	if id == "" {
+		return "", &output.UserError{Message: "created parent page " + parent + " but could not determine its ID"}
+	}
+	r.resolved[key] = id
+	if !r.quiet {
+		output.PrintInfo("Created parent page: " + parent)
+	}
+	return id, nil
+}
+
+// workspaceParent is the --parent value that creates a page at the top
+// level of the workspace, even when a default parent is configured.
+const workspaceParent = "workspace"
+
+func isWorkspaceParent(parent string) bool {
+	return strings.EqualFold(strings.TrimSpace(parent), workspaceParent)
+}
+
+const defaultParentEnv = "NOTION_CLI_DEFAULT_PARENT"
+
+// applyDefaultParent returns the configured default parent when neither
+// --parent nor --parent-db was given, and parent otherwise. The default is
+// announced so a page never lands somewhere unexpected silently.
+func applyDefaultParent(ctx *Context, parent, parentDB string) string {
+	if parent != "" || parentDB != "" {
+		return parent
+	}
+	value, source := defaultParentSetting(ctx.Profile)
+	if value == "" {
+		return ""
+	}
+	if !ctx.JSON {
+		output.PrintInfo("Using default parent " + value + " (from " + source + ")")
+	}
+	return value
+}
+
+// defaultParentSetting reads NOTION_CLI_DEFAULT_PARENT, falling back to the
+// profile's default_parent config key, and reports where it came from.
+func defaultParentSetting(profile string) (string, string) {
+	if value := strings.TrimSpace(os.Getenv(defaultParentEnv)); value != "" {
+		return value, defaultParentEnv
+	}
+	loaded, err := config.LoadWithMeta(config.APIOverrides{Profile: profile})
+	if err != nil {
+		return "", ""
+	}
+	return loaded.Config.DefaultParent, "default_parent in " + loaded.Path
+}
+
+const (
+	parentPrecedenceFlag        = "flag"
+	parentPrecedenceFrontmatter = "frontmatter"
+)
+
+// applyFrontmatterParent sets opts' parent from the file's notion-parent or
+// notion-parent-db frontmatter keys, as chosen by useFrontmatterParent.
+func applyFrontmatterParent(ctx *Context, file string, opts *pageFileOptions) error {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	fm, _ := cli.ParseFrontmatter(string(raw))
+	opts.Parent, opts.ParentDB = useFrontmatterParent(ctx, file, opts.Parent, opts.ParentDB, fm, opts.ParentPrecedence)
+	return nil
+}
+
+// useFrontmatterParent returns the parent to create file's page under. The
+// frontmatter parent is used when no flag names one, or over the flags with
+// --parent-precedence frontmatter. Each source is taken as a whole, so a
+// --parent and a frontmatter notion-parent-db are never mixed.
+func useFrontmatterParent(ctx *Context, file, parent, parentDB string, fm cli.Frontmatter, precedence string) (string, string) {
+	flagSet := parent != "" || parentDB != ""
+	fileSet := fm.Parent != "" || fm.ParentDB != ""
+	if !fileSet || (flagSet && precedence != parentPrecedenceFrontmatter) {
+		return parent, parentDB
+	}
+	if !ctx.JSON {
+		value := fm.Parent
+		if fm.ParentDB != "" {
+			value = fm.ParentDB
+		}
+		output.PrintInfo("Using parent " + value + " (from frontmatter in " + file + ")")
+	}
+	return fm.Parent, fm.ParentDB
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/config"
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+func TestApplyDefaultParentPrecedence(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(defaultParentEnv, "")
+	ctx := &Context{JSON: true}
+
+	if got := applyDefaultParent(ctx, "", ""); got != "" {
+		t.Fatalf("without default = %q, want empty", got)
+	}
+
+	if err := config.SaveForProfile("", config.Config{DefaultParent: "Inbox"}); err != nil {
+		t.Fatalf("SaveForProfile: %v", err)
+	}
+	if got := applyDefaultParent(ctx, "", ""); got != "Inbox" {
+		t.Fatalf("config default = %q, want Inbox", got)
+	}
+
+	t.Setenv(defaultParentEnv, "Scratch")
+	if got := applyDefaultParent(ctx, "", ""); got != "Scratch" {
+		t.Fatalf("env default = %q, want Scratch", got)
+	}
+	if got := applyDefaultParent(ctx, "Engineering", ""); got != "Engineering" {
+		t.Fatalf("explicit --parent = %q, want Engineering", got)
+	}
+	if got := applyDefaultParent(ctx, "", "Tasks"); got != "" {
+		t.Fatalf("with --parent-db = %q, want empty", got)
+	}
+	if got := applyDefaultParent(ctx, "workspace", ""); got != "workspace" {
+		t.Fatalf("--parent workspace = %q, want workspace", got)
+	}
+}
+
+func TestParentResolverLoadsDefaultOnce(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(defaultParentEnv, "Inbox")
+	ctx := &Context{JSON: true}
+	parents := newParentResolver(false, true)
+
+	if got := parents.withDefault(ctx, "Engineering", ""); got != "Engineering" || parents.defaultLoaded {
+		t.Fatalf("explicit parent = %q (default loaded %v), want Engineering without loading", got, parents.defaultLoaded)
+	}
+	if got := parents.withDefault(ctx, "", ""); got != "Inbox" {
+		t.Fatalf("default parent = %q, want Inbox", got)
+	}
+	t.Setenv(defaultParentEnv, "Scratch")
+	if got := parents.withDefault(ctx, "", ""); got != "Inbox" {
+		t.Fatalf("second lookup = %q, want cached Inbox", got)
+	}
+}
+
+func TestResolveCreateParentWorkspace(t *testing.T) {
+	var req mcp.CreatePageRequest
+	if err := resolveCreateParent(context.Background(), nil, newParentResolver(false, true), "Workspace", "", &req); err != nil {
+		t.Fatalf("resolveCreateParent: %v", err)
+	}
+	if !req.Workspace || req.ParentPageID != "" {
+		t.Fatalf("req = %+v, want a workspace-level page", req)
+	}
+
+	uploads := []uploadedLocalImage{{ResolvedPath: "diagram.png"}}
+	if err := requireLocalImageParent(uploads, "workspace", ""); err == nil {
+		t.Fatal("expected local images to need a shared parent page")
+	}
+}
+
+func TestParentResolverCachesResolvedParents(t *testing.T) {
+	parents := newParentResolver(false, true)
+	parents.resolved["engineering"] = "page-1"
+	parents.databases["tasks"] = resolvedDatabase{
+		id:     "ds-1",
+		schema: output.DatabaseSchema{Columns: []output.DatabaseColumn{{Name: "Task", Type: "title"}}},
+	}
+
+	// A nil client would panic on any lookup, so these only pass when the
+	// cached results are used.
+	var req mcp.CreatePageRequest
+	if err := resolveCreateParent(context.Background(), nil, parents, " Engineering ", "", &req); err != nil {
+		t.Fatalf("resolveCreateParent page: %v", err)
+	}
+	if req.ParentPageID != "page-1" {
+		t.Fatalf("ParentPageID = %q, want page-1", req.ParentPageID)
+	}
+
+	req = mcp.CreatePageRequest{}
+	if err := resolveCreateParent(context.Background(), nil, parents, "", "Tasks", &req); err != nil {
+		t.Fatalf("resolveCreateParent database: %v", err)
+	}
+	if req.ParentDatabaseID != "ds-1" || req.TitleProperty != "Task" {
+		t.Fatalf("req = %+v, want data source ds-1 with title property Task", req)
+	}
+}
+
+func TestUseFrontmatterParentPrecedence(t *testing.T) {
+	ctx := &Context{JSON: true}
+	fm := cli.Frontmatter{ParentDB: "Tasks"}
+
+	tests := []struct {
+		name             string
+		parent, parentDB string
+		fm               cli.Frontmatter
+		precedence       string
+		wantParent       string
+		wantParentDB     string
+	}{
+		{"frontmatter only", "", "", fm, parentPrecedenceFlag, "", "Tasks"},
+		{"flag wins by default", "Engineering", "", fm, parentPrecedenceFlag, "Engineering", ""},
+		{"frontmatter wins when asked", "Engineering", "", fm, parentPrecedenceFrontmatter, "", "Tasks"},
+		{"no frontmatter parent", "Engineering", "", cli.Frontmatter{}, parentPrecedenceFrontmatter, "Engineering", ""},
+	}
+	for _, tt := range tests {
+		parent, parentDB := useFrontmatterParent(ctx, "doc.md", tt.parent, tt.parentDB, tt.fm, tt.precedence)
+		if parent != tt.wantParent || parentDB != tt.wantParentDB {
+			t.Fatalf("%s: got (%q, %q), want (%q, %q)", tt.name, parent, parentDB, tt.wantParent, tt.wantParentDB)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import "github.com/lox/notion-cli/internal/cli"
+
+// preprocessBody prepares the markdown body of a file for page upload and
+// page sync, in order: HTML comments are removed with --strip-comments,
+// {{key}} placeholders are filled from --var, and :shortcode: callout icons
+// (in <callout icon=":bulb:"> tags and at the start of blockquotes like
+// "> :bulb: Note") become emoji. Unknown shortcodes and fenced code are
+// left as written.
+func preprocessBody(body string, opts pageFileOptions) (string, error) {
+	if opts.StripComments {
+		body = cli.StripHTMLComments(body)
+	}
+	body, err := expandTemplate(body, opts)
+	if err != nil {
+		return "", err
+	}
+	return cli.ExpandCalloutShortcodes(body), nil
+}
+
+// preprocessPageFile is preprocessBody for a whole file, leaving any
+// frontmatter as written.
+func preprocessPageFile(content string, opts pageFileOptions) (string, error) {
+	_, body := cli.ParseFrontmatter(content)
+	processed, err := preprocessBody(body, opts)
+	if err != nil {
+		return "", err
+	}
+	return content[:len(content)-len(body)] + processed, nil
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// fileChangedSince reports whether file, or any local image it references,
+// changed after since, which is either a time accepted by
+// cli.ParseTimeBound (compared with modification times) or a git revision
+// (compared with git, counting uncommitted and untracked changes). A
+// referenced image that is missing counts as changed, so the sync runs and
+// reports it.
+func fileChangedSince(file, since string, now time.Time) (bool, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	if changed, err := pathChangedSince(file, since, now); err != nil || changed {
+		return changed, err
+	}
+	for _, image := range cli.LocalImagePaths(string(raw), file) {
+		if _, err := os.Stat(image); err != nil {
+			return true, nil
+		}
+		if changed, err := pathChangedSince(image, since, now); err != nil || changed {
+			return changed, err
+		}
+	}
+	return false, nil
+}
+
+// pathChangedSince is fileChangedSince for a single path.
+func pathChangedSince(file, since string, now time.Time) (bool, error) {
+	if t, err := cli.ParseTimeBound(since, now); err == nil {
+		info, err := os.Stat(file)
+		if err != nil {
+			return false, err
+		}
+		return info.ModTime().After(t), nil
+	}
+
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return false, err
+	}
+	dir, name := filepath.Dir(abs), filepath.Base(abs)
+	if err := runGit(dir, "rev-parse", "--verify", "--quiet", since+"^{commit}"); err != nil {
+		return false, &output.UserError{Message: fmt.Sprintf("--since %q is neither a time (RFC3339, YYYY-MM-DD, 7d) nor a git revision in %s", since, dir)}
+	}
+	if err := runGit(dir, "ls-files", "--error-unmatch", "--", name); err != nil {
+		// Untracked files have changed by definition.
+		return true, nil
+	}
+	err = runGit(dir, "diff", "--quiet", since, "--", name)
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return false, nil
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		return true, nil
+	}
+	return false, err
+}
+
+func runGit(dir string, args ...string) error {
+	return exec.Command("git", append([]string{"-C", dir}, args...)...).Run()
+}
+
+// skipUnchangedSync reports whether page sync --since can skip file: it
+// must already be linked to a page (so there is nothing to create) and be
+// unchanged since the given time or revision.
+func skipUnchangedSync(ctx *Context, file, since string) (bool, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	fm, _ := cli.ParseFrontmatter(string(raw))
+	if fm.NotionID == "" && len(fm.NotionIDs) == 0 && len(fm.NotionTargets) == 0 {
+		return false, nil
+	}
+	changed, err := fileChangedSince(file, since, time.Now())
+	if err != nil || changed {
+		return false, err
+	}
+
+	if output.PageOutputMode() != output.PageOutputJSON {
+		// --url-only and --id-only print the pages the file is linked to,
+		// as they would after a sync.
+		return true, output.PrintPages(linkedPages(fm), true)
+	}
+	if ctx.JSON {
+		return true, output.WriteStructured(os.Stdout, map[string]any{
+			"file":    file,
+			"id":      fm.NotionID,
+			"skipped": true,
+			"since":   since,
+		})
+	}
+	output.PrintInfo("Unchanged since " + since + ", skipped: " + file)
+	return true, nil
+}
+
+// linkedPages returns the pages a synced file's frontmatter points at:
+// notion-id, then notion-targets in file order, then the notion-ids split
+// sections sorted by key.
+func linkedPages(fm cli.Frontmatter) []output.Page {
+	var pages []output.Page
+	if fm.NotionID != "" {
+		pages = append(pages, output.Page{ID: fm.NotionID})
+	}
+	for _, id := range fm.NotionTargets {
+		pages = append(pages, output.Page{ID: id})
+	}
+	keys := make([]string, 0, len(fm.NotionIDs))
+	for key := range fm.NotionIDs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		pages = append(pages, output.Page{ID: fm.NotionIDs[key]})
+	}
+	return pages
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lox/notion-cli/internal/output"
+)
+
+func TestFileChangedSinceTime(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "doc.md")
+	if err := os.WriteFile(file, []byte("# Doc\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	now := time.Now()
+	mtime := now.Add(-48 * time.Hour)
+	if err := os.Chtimes(file, mtime, mtime); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	if changed, err := fileChangedSince(file, "1d", now); err != nil || changed {
+		t.Fatalf("since 1d = %v, %v; want unchanged", changed, err)
+	}
+	if changed, err := fileChangedSince(file, "3d", now); err != nil || !changed {
+		t.Fatalf("since 3d = %v, %v; want changed", changed, err)
+	}
+}
+
+func TestFileChangedSinceCountsLocalImages(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "doc.md")
+	image := filepath.Join(dir, "diagram.png")
+	if err := os.WriteFile(file, []byte("# Doc\n\n![Diagram](./diagram.png)\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.WriteFile(image, []byte("png"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+	for _, path := range []string{file, image} {
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatalf("Chtimes: %v", err)
+		}
+	}
+
+	if changed, err := fileChangedSince(file, "1d", now); err != nil || changed {
+		t.Fatalf("untouched = %v, %v; want unchanged", changed, err)
+	}
+	if err := os.Chtimes(image, now, now); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+	if changed, err := fileChangedSince(file, "1d", now); err != nil || !changed {
+		t.Fatalf("edited image = %v, %v; want changed", changed, err)
+	}
+	if err := os.Remove(image); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if changed, err := fileChangedSince(file, "1d", now); err != nil || !changed {
+		t.Fatalf("missing image = %v, %v; want changed", changed, err)
+	}
+}
+
+func TestFileChangedSinceGitRevision(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		return path
+	}
+
+	git("init", "-q")
+	a := write("a.md", "one\n")
+	b := write("b.md", "one\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "first")
+	write("b.md", "two\n")
+	c := write("c.md", "new\n")
+
+	tests := []struct {
+		file string
+		want bool
+	}{
+		{a, false},
+		{b, true},
+		{c, true},
+	}
+	for _, tt := range tests {
+		changed, err := fileChangedSince(tt.file, "HEAD", time.Now())
+		if err != nil {
+			t.Fatalf("fileChangedSince(%s): %v", tt.file, err)
+		}
+		if changed != tt.want {
+			t.Errorf("fileChangedSince(%s) = %v, want %v", filepath.Base(tt.file), changed, tt.want)
+		}
+	}
+
+	if _, err := fileChangedSince(a, "no-such-ref", time.Now()); err == nil {
+		t.Fatal("expected an error for an unknown revision")
+	}
+}
+
+func TestSkipUnchangedSyncURLOnly(t *testing.T) {
+	defer output.SetPageOutput(output.PageOutputJSON)
+
+	file := filepath.Join(t.TempDir(), "doc.md")
+	if err := os.WriteFile(file, []byte("---\nnotion-id: 1234-abcd\n---\n# Doc\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(file, old, old); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	ctx := &Context{}
+	if err := applyPageOutput(ctx, true, false); err != nil {
+		t.Fatalf("applyPageOutput: %v", err)
+	}
+	var skip bool
+	stdout := captureStdout(t, func() {
+		var err error
+		if skip, err = skipUnchangedSync(ctx, file, "1d"); err != nil {
+			t.Fatalf("skipUnchangedSync: %v", err)
+		}
+	})
+	if !skip || stdout != "https://www.notion.so/1234abcd\n" {
+		t.Fatalf("skip = %v, stdout = %q", skip, stdout)
+	}
+}
+
+func TestPageSyncWatchRejectsIDOnly(t *testing.T) {
+	defer output.SetPageOutput(output.PageOutputJSON)
+
+	err := (&PageSyncCmd{File: "doc.md", Watch: true, IDOnly: true}).Run(&Context{})
+	if err == nil || !strings.Contains(err.Error(), "--id-only") {
+		t.Fatalf("err = %v, want an error naming --id-only", err)
+	}
+}
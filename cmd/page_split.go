@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// runPageSplit uploads or syncs a markdown file as several pages, one per
+// section between lines matching --split-on. When syncing, the page ID of
+// each section is tracked in the file's notion-ids frontmatter block, keyed
+// by a slug of the section title.
+func runPageSplit(ctx *Context, file string, opts pageFileOptions, sync bool) error {
+	sep, err := regexp.Compile(opts.SplitOn)
+	if err != nil {
+		err = &output.UserError{Message: "invalid --split-on pattern: " + err.Error()}
+		output.PrintError(err)
+		return err
+	}
+	if opts.Title != "" || opts.TitleFrom == "filename" {
+		err := &output.UserError{Message: "--title and --title-from filename cannot be combined with --split-on; section titles come from each section's first heading"}
+		output.PrintError(err)
+		return err
+	}
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	content := string(raw)
+	body := content
+	var fm cli.Frontmatter
+	expand := preprocessPageFile
+	if sync {
+		fm, body = cli.ParseFrontmatter(content)
+		if len(fm.NotionTargets) > 0 {
+			err := &output.UserError{Message: "--split-on tracks sections under notion-ids and cannot be combined with the list of mirrored pages in " + file}
+			output.PrintError(err)
+			return err
+		}
+		opts.AppendOnly = opts.AppendOnly || fm.AppendOnly
+		expand = preprocessBody
+	}
+	if body, err = expand(body, opts); err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	sections := cli.SplitMarkdownSections(body, sep)
+	if len(sections) == 0 {
+		err := &output.UserError{Message: "no content found in " + file + " after splitting on " + opts.SplitOn}
+		output.PrintError(err)
+		return err
+	}
+
+	base := fileNameTitle(file, opts.TitleCase)
+	titles := make([]string, len(sections))
+	for i, section := range sections {
+		titles[i] = extractTitleFromMarkdown(section)
+		if titles[i] == "" {
+			titles[i] = base + " (" + strconv.Itoa(i+1) + ")"
+		}
+	}
+	keys := cli.SectionKeys(titles)
+
+	client, err := cli.RequireClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	bgCtx := context.Background()
+	parents := newParentResolver(opts.CreateParents, ctx.JSON)
+	ids := make(map[string]string, len(fm.NotionIDs))
+	for k, v := range fm.NotionIDs {
+		ids[k] = v
+	}
+
+	auditAction := "page.upload"
+	if sync {
+		auditAction = "page.sync"
+	}
+
+	images := newImageUploadCache(opts.DedupeImages)
+	pages := make([]output.Page, 0, len(sections))
+	idsChanged := false
+	var batch cli.BatchResult
+	for i, section := range sections {
+		title, icon := pageTitleIcon(titles[i], opts.Icon, opts.StripEmoji)
+		displayTitle := title
+		if icon != "" {
+			displayTitle = icon + " " + title
+		}
+
+		written, verb, err := writeSplitSection(ctx, bgCtx, client, parents, images, file, section, title, icon, ids[keys[i]], opts)
+		if err != nil {
+			batch.Fail(fmt.Sprintf("section %q", displayTitle), err)
+			if !opts.ContinueOnError {
+				break
+			}
+			continue
+		}
+		batch.Success()
+		if !sync {
+			verb = "Uploaded"
+		}
+		if sync && written.ID != "" && ids[keys[i]] != written.ID {
+			ids[keys[i]] = written.ID
+			idsChanged = true
+		}
+		if sync && written.ID == "" && !ctx.JSON {
+			printWarningFn("Page created but could not retrieve ID for frontmatter: " + displayTitle)
+		}
+		recordAudit(ctx, auditAction, written.ID, file+"#"+keys[i])
+
+		written.Title, written.Icon = displayTitle, icon
+		pages = append(pages, written)
+		if !ctx.JSON {
+			output.PrintSuccess(verb + ": " + displayTitle)
+			if written.URL != "" {
+				output.PrintInfo(written.URL)
+			}
+		}
+	}
+
+	// Record IDs of pages created so far even if a later section failed, so
+	// a rerun updates them instead of creating duplicates.
+	if idsChanged {
+		if err := writeSourceFile(file, cli.SetFrontmatterIDs(content, ids), opts.PreserveMtime); err != nil {
+			err = fmt.Errorf("pages synced but failed to update frontmatter: %w", err)
+			output.PrintError(err)
+			return err
+		}
+	}
+
+	images.report(ctx.JSON)
+	printBatchSummary(&batch, ctx.JSON)
+	if ctx.JSON {
+		if err := output.PrintPages(pages, true); err != nil {
+			return err
+		}
+	}
+	return batch.Err(opts.IgnoreFailures)
+}
+
+// writeSplitSection replaces the page at existingID with the section, or
+// creates a new page when existingID is empty. The returned page carries
+// only the ID, URL, and uploaded assets.
+func writeSplitSection(ctx *Context, bgCtx context.Context, client *mcp.Client, parents *parentResolver, images *imageUploadCache, file, section, title, icon, existingID string, opts pageFileOptions) (written output.Page, verb string, err error) {
+	section, uploads, err := prepareLocalImageUploads(ctx, bgCtx, file, section, opts.NoImageUpload, images)
+	if err != nil {
+		return output.Page{}, "", err
+	}
+
+	if existingID != "" {
+		if err := checkAppendOnly(ctx, existingID, "replace_content", opts.AppendOnly); err != nil {
+			return output.Page{}, "", err
+		}
+		if err := replaceMarkdownPage(ctx, bgCtx, client, existingID, section, uploads); err != nil {
+			return output.Page{}, "", err
+		}
+		return output.Page{ID: existingID, UploadedAssets: uploadedAssets(uploads)}, "Synced", nil
+	}
+
+	parent := parents.withDefault(ctx, opts.Parent, opts.ParentDB)
+	if err := requireLocalImageParent(uploads, parent, opts.ParentDB); err != nil {
+		return output.Page{}, "", err
+	}
+	req := mcp.CreatePageRequest{
+		Title:         title,
+		Content:       section,
+		TitleProperty: opts.TitleProperty,
+		Icon:          icon,
+		Cover:         opts.Cover,
+	}
+	if err := resolveCreateParent(bgCtx, client, parents, parent, opts.ParentDB, &req); err != nil {
+		return output.Page{}, "", err
+	}
+	resp, pageID, err := createMarkdownPage(ctx, bgCtx, client, req, uploads)
+	if err != nil {
+		return output.Page{}, "", err
+	}
+	return output.Page{ID: pageID, URL: resp.URL, UploadedAssets: uploadedAssets(uploads)}, "Created", nil
+}
+
+// runPageHeadingSplit uploads a markdown file as a parent page holding the
+// content before the first heading at the --heading-split level, plus one
+// child page per heading at that level. If a child cannot be created, the
+// parent (and with it any children already created) is moved to trash.
+func runPageHeadingSplit(ctx *Context, file string, opts pageFileOptions) error {
+	var level int
+	switch strings.ToLower(opts.HeadingSplit) {
+	case "h1":
+		level = 1
+	case "h2":
+		level = 2
+	default:
+		err := &output.UserError{Message: "--heading-split must be h1 or h2"}
+		output.PrintError(err)
+		return err
+	}
+	if opts.SplitOn != "" {
+		err := &output.UserError{Message: "--heading-split cannot be combined with --split-on"}
+		output.PrintError(err)
+		return err
+	}
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	content, err := preprocessPageFile(string(raw), opts)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	preamble, sections := cli.SplitMarkdownHeadings(content, level)
+
+	title := opts.Title
+	if title == "" {
+		title = defaultPageTitle(preamble, file, opts)
+	}
+	title, icon := pageTitleIcon(title, opts.Icon, opts.StripEmoji)
+	displayTitle := title
+	if icon != "" {
+		displayTitle = icon + " " + title
+	}
+
+	bgCtx := context.Background()
+	images := newImageUploadCache(opts.DedupeImages)
+	preamble, localUploads, err := prepareLocalImageUploads(ctx, bgCtx, file, preamble, opts.NoImageUpload, images)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if err := requireLocalImageParent(localUploads, opts.Parent, opts.ParentDB); err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	client, err := cli.RequireClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	req := mcp.CreatePageRequest{
+		Title:         title,
+		Content:       preamble,
+		TitleProperty: opts.TitleProperty,
+		Icon:          icon,
+		Cover:         opts.Cover,
+	}
+	parents := newParentResolver(opts.CreateParents, ctx.JSON)
+	if err := resolveCreateParent(bgCtx, client, parents, opts.Parent, opts.ParentDB, &req); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	resp, parentID, err := createMarkdownPage(ctx, bgCtx, client, req, localUploads)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if parentID == "" && len(sections) > 0 {
+		err := fmt.Errorf("created %s but could not determine its ID to add child pages", displayTitle)
+		output.PrintError(err)
+		return err
+	}
+	recordAudit(ctx, "page.upload", parentID, file)
+
+	pages := []output.Page{{ID: parentID, URL: resp.URL, Title: displayTitle, Icon: icon, UploadedAssets: uploadedAssets(localUploads)}}
+	for _, section := range sections {
+		childTitle, childIcon := pageTitleIcon(section.Title, "", opts.StripEmoji)
+		childDisplay := section.Title
+
+		body, uploads, err := prepareLocalImageUploads(ctx, bgCtx, file, section.Body, opts.NoImageUpload, images)
+		var childResp *mcp.CreatePageResponse
+		var childID string
+		if err == nil {
+			childResp, childID, err = createMarkdownPage(ctx, bgCtx, client, mcp.CreatePageRequest{
+				Title:        childTitle,
+				Content:      body,
+				ParentPageID: parentID,
+				Icon:         childIcon,
+			}, uploads)
+		}
+		if err != nil {
+			finalErr := fmt.Errorf("section %q: %w", childDisplay, err)
+			if apiClient, apiErr := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx)); apiErr == nil {
+				if cleanupErr := apiClient.TrashPage(bgCtx, parentID); cleanupErr != nil {
+					finalErr = fmt.Errorf("%w (cleanup failed: %v)", finalErr, cleanupErr)
+				}
+			}
+			output.PrintError(finalErr)
+			return finalErr
+		}
+		recordAudit(ctx, "page.upload", childID, file+"#"+childTitle)
+		pages = append(pages, output.Page{ID: childID, URL: childResp.URL, Title: childDisplay, Icon: childIcon, UploadedAssets: uploadedAssets(uploads)})
+	}
+
+	if ctx.JSON {
+		return output.PrintPages(pages, true)
+	}
+
+	output.PrintSuccess("Uploaded: " + displayTitle)
+	if resp.URL != "" {
+		output.PrintInfo(resp.URL)
+	}
+	for _, page := range pages[1:] {
+		output.PrintSuccess("  Child page: " + page.Title)
+	}
+	images.report(false)
+	return nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/output"
+)
+
+func TestPageSplitFlagValidation(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(file, []byte("# One\n\n# Two\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		run  func() error
+	}{
+		{
+			name: "invalid split regex",
+			run: func() error {
+				return runPageSync(&Context{}, file, pageFileOptions{SplitOn: "("})
+			},
+		},
+		{
+			name: "split with explicit title",
+			run: func() error {
+				return runPageUpload(&Context{}, file, pageFileOptions{SplitOn: "^---$", Title: "Notes"})
+			},
+		},
+		{
+			name: "unknown heading level",
+			run: func() error {
+				return runPageUpload(&Context{}, file, pageFileOptions{HeadingSplit: "h3"})
+			},
+		},
+		{
+			name: "heading split with separator",
+			run: func() error {
+				return runPageUpload(&Context{}, file, pageFileOptions{HeadingSplit: "h1", SplitOn: "^---$"})
+			},
+		},
+		{
+			name: "ignore failures without continue on error",
+			run: func() error {
+				return runPageSync(&Context{}, file, pageFileOptions{SplitOn: "^---$", IgnoreFailures: true})
+			},
+		},
+		{
+			name: "unknown icon shortcode",
+			run: func() error {
+				return runPageUpload(&Context{}, file, pageFileOptions{Icon: ":not_an_emoji:"})
+			},
+		},
+		{
+			name: "icon from parent without parent",
+			run: func() error {
+				return runPageUpload(&Context{}, file, pageFileOptions{IconFromParent: true})
+			},
+		},
+		{
+			name: "icon from parent with split",
+			run: func() error {
+				return runPageUpload(&Context{}, file, pageFileOptions{IconFromParent: true, Parent: "Docs", SplitOn: "^---$"})
+			},
+		},
+		{
+			name: "split with title from filename",
+			run: func() error {
+				return runPageSync(&Context{}, file, pageFileOptions{SplitOn: "^---$", TitleFrom: "filename"})
+			},
+		},
+		{
+			name: "continue on error without split",
+			run: func() error {
+				return runPageUpload(&Context{}, file, pageFileOptions{ContinueOnError: true})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var userErr *output.UserError
+			if err := tt.run(); !errors.As(err, &userErr) {
+				t.Fatalf("error = %v, want UserError", err)
+			}
+		})
+	}
+}
+
+func TestDefaultPageTitle(t *testing.T) {
+	file := filepath.Join("docs", "my-cool_page.md")
+	markdown := "Intro\n# Heading Title\n"
+
+	tests := []struct {
+		name string
+		opts pageFileOptions
+		body string
+		want string
+	}{
+		{"heading wins", pageFileOptions{}, markdown, "Heading Title"},
+		{"file name fallback", pageFileOptions{}, "No heading", "my-cool_page"},
+		{"file name fallback title-cased", pageFileOptions{TitleCase: true}, "No heading", "My Cool Page"},
+		{"title from filename", pageFileOptions{TitleFrom: "filename"}, markdown, "my-cool_page"},
+		{"title from filename title-cased", pageFileOptions{TitleFrom: "filename", TitleCase: true}, markdown, "My Cool Page"},
+		{"title case leaves headings alone", pageFileOptions{TitleCase: true}, "# lower heading", "lower heading"},
+	}
+	for _, tt := range tests {
+		if got := defaultPageTitle(tt.body, file, tt.opts); got != tt.want {
+			t.Errorf("%s: defaultPageTitle = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if got := fileNameTitle("api--v2_notes.md", true); got != "Api V2 Notes" {
+		t.Errorf("fileNameTitle = %q", got)
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+type PageStatsCmd struct {
+	Page string `arg:"" help:"Page URL, name, or ID"`
+	JSON bool   `help:"Output as JSON" short:"j"`
+}
+
+func (c *PageStatsCmd) Run(ctx *Context) error {
+	ctx.JSON = ctx.JSON || c.JSON
+	return runPageStats(ctx, c.Page)
+}
+
+// pageStats counts a page's content. Child pages and databases are counted
+// as blocks but their own content is not included.
+type pageStats struct {
+	ID         string         `json:"id"`
+	Blocks     int            `json:"blocks"`
+	BlockTypes map[string]int `json:"block_types"`
+	Words      int            `json:"words"`
+	Images     int            `json:"images"`
+	Links      int            `json:"links"`
+	ChildPages int            `json:"child_pages"`
+}
+
+var pageStatsOutput io.Writer = os.Stdout
+
+func runPageStats(ctx *Context, page string) error {
+	bgCtx := context.Background()
+	ref := cli.ParsePageRef(page)
+	pageID := ref.ID
+	if ref.Kind != cli.RefID {
+		client, err := cli.RequireClient()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = client.Close() }()
+
+		pageID, err = cli.ResolvePageID(bgCtx, client, page)
+		if err != nil {
+			output.PrintError(err)
+			return err
+		}
+	}
+
+	apiClient, err := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx))
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	stats := pageStats{ID: pageID, BlockTypes: make(map[string]int)}
+	if err := collectPageStats(bgCtx, apiClient, pageID, &stats); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	return printPageStats(pageStatsOutput, stats, ctx.JSON)
+}
+
+// collectPageStats adds the blocks under blockID, at every depth, to stats.
+// Link-type blocks (bookmarks, link previews, embeds) count as links along
+// with linked text.
+func collectPageStats(ctx context.Context, lister blockChildrenLister, blockID string, stats *pageStats) error {
+	children, err := lister.ListAllBlockChildren(ctx, blockID)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		stats.Blocks++
+		stats.BlockTypes[child.Type]++
+		stats.Links += child.Links()
+
+		switch child.Type {
+		case "child_page":
+			stats.ChildPages++
+			continue
+		case "child_database":
+			continue
+		case "image":
+			stats.Images++
+		case "bookmark", "link_preview", "embed":
+			stats.Links++
+		}
+		stats.Words += len(strings.Fields(child.Text()))
+
+		if child.HasChildren {
+			if err := collectPageStats(ctx, lister, child.ID, stats); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func printPageStats(w io.Writer, stats pageStats, asJSON bool) error {
+	if asJSON {
+		return output.WriteStructured(w, stats)
+	}
+
+	for _, line := range [][2]string{
+		{"Blocks", strconv.Itoa(stats.Blocks)},
+		{"Words", strconv.Itoa(stats.Words)},
+		{"Images", strconv.Itoa(stats.Images)},
+		{"Links", strconv.Itoa(stats.Links)},
+		{"Child pages", strconv.Itoa(stats.ChildPages)},
+	} {
+		if _, err := fmt.Fprintf(w, "%-13s%s\n", line[0]+":", line[1]); err != nil {
+			return err
+		}
+	}
+	if len(stats.BlockTypes) == 0 {
+		return nil
+	}
+
+	types := make([]string, 0, len(stats.BlockTypes))
+	for t := range stats.BlockTypes {
+		types = append(types, t)
+	}
+	// Most common first, then by name.
+	sort.Slice(types, func(i, j int) bool {
+		a, b := stats.BlockTypes[types[i]], stats.BlockTypes[types[j]]
+		if a != b {
+			return a > b
+		}
+		return types[i] < types[j]
+	})
+	if _, err := fmt.Fprintln(w, "\nBlocks by type:"); err != nil {
+		return err
+	}
+	for _, t := range types {
+		if _, err := fmt.Fprintf(w, "  %-20s %d\n", t, stats.BlockTypes[t]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
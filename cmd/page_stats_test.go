@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCollectPageStats(t *testing.T) {
+	lister := fakeBlockLister{
+		"page": `[
+			{"id":"h","type":"heading_1","heading_1":{"rich_text":[{"plain_text":"Quarterly plan"}]}},
+			{"id":"p","type":"paragraph","paragraph":{"rich_text":[{"plain_text":"See the "},{"plain_text":"doc","href":"https://example.com"},{"plain_text":" now."}]}},
+			{"id":"t","type":"toggle","has_children":true,"toggle":{"rich_text":[{"plain_text":"Details"}]}},
+			{"id":"sub","type":"child_page","has_children":true,"child_page":{"title":"Sub page title"}}
+		]`,
+		"t": `[
+			{"id":"i","type":"image","image":{"caption":[]}},
+			{"id":"b","type":"bookmark","bookmark":{"url":"https://example.com"}},
+			{"id":"l","type":"bulleted_list_item","bulleted_list_item":{"rich_text":[{"plain_text":"one two three"}]}}
+		]`,
+		"sub": `[{"id":"x","type":"paragraph","paragraph":{"rich_text":[{"plain_text":"not counted"}]}}]`,
+	}
+
+	stats := pageStats{BlockTypes: make(map[string]int)}
+	if err := collectPageStats(context.Background(), lister, "page", &stats); err != nil {
+		t.Fatalf("collectPageStats: %v", err)
+	}
+	if stats.Blocks != 7 || stats.Words != 10 || stats.Images != 1 || stats.Links != 2 || stats.ChildPages != 1 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	if stats.BlockTypes["paragraph"] != 1 || stats.BlockTypes["child_page"] != 1 {
+		t.Fatalf("block types = %v", stats.BlockTypes)
+	}
+
+	var out bytes.Buffer
+	if err := printPageStats(&out, stats, false); err != nil {
+		t.Fatalf("printPageStats: %v", err)
+	}
+	for _, want := range []string{"Blocks:      7\n", "Words:       10\n", "Child pages: 1\n", "  bookmark             1\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// runPageSyncProperties pushes only the frontmatter properties of a synced
+// file to its page with update_properties, without replacing the content.
+func runPageSyncProperties(ctx *Context, file, splitOn string) error {
+	if splitOn != "" {
+		err := &output.UserError{Message: "--properties-only cannot be combined with --split-on"}
+		output.PrintError(err)
+		return err
+	}
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	fm, _ := cli.ParseFrontmatter(string(raw))
+	if fm.NotionID == "" {
+		err := &output.UserError{Message: "--properties-only requires a notion-id in the frontmatter of " + file + "; run page sync without it first"}
+		output.PrintError(err)
+		return err
+	}
+
+	properties, err := frontmatterProperties(fm)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if len(properties) == 0 {
+		err := &output.UserError{Message: "no properties found in the frontmatter of " + file}
+		output.PrintError(err)
+		return err
+	}
+
+	client, err := cli.RequireClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	req := mcp.UpdatePageRequest{
+		PageID:     fm.NotionID,
+		Command:    "update_properties",
+		Properties: properties,
+	}
+	if err := client.UpdatePage(context.Background(), req); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	recordAudit(ctx, "page.sync", fm.NotionID, "update_properties "+file)
+
+	if ctx.JSON {
+		return output.PrintPage(output.Page{ID: fm.NotionID}, true)
+	}
+	output.PrintSuccess("Synced properties: " + file)
+	return nil
+}
+
+// frontmatterProperties converts frontmatter properties with the same value
+// parsing as page edit --prop, so JSON values like numbers and arrays work.
+func frontmatterProperties(fm cli.Frontmatter) (map[string]any, error) {
+	props := make([]string, 0, len(fm.Properties))
+	for k, v := range fm.Properties {
+		props = append(props, k+"="+v)
+	}
+	return parsePageEditProperties(props)
+}
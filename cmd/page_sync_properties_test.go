@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/output"
+)
+
+func TestPageSyncPropertiesOnlyRequiresNotionID(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(file, []byte("---\nStatus: Done\n---\n\n# Notes\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	var userErr *output.UserError
+	if err := runPageSyncProperties(&Context{}, file, ""); !errors.As(err, &userErr) {
+		t.Fatalf("error = %v, want UserError", err)
+	}
+	if !strings.Contains(userErr.Message, "notion-id") {
+		t.Fatalf("unexpected message: %q", userErr.Message)
+	}
+}
+
+func TestPageSyncTargetsRejectsNotionID(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(file, []byte("---\nnotion-id: abc\nnotion-targets: [def, ghi]\n---\n\n# Notes\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	var userErr *output.UserError
+	if err := runPageSync(&Context{JSON: true}, file, pageFileOptions{}); !errors.As(err, &userErr) {
+		t.Fatalf("runPageSync error = %v, want a user error", err)
+	}
+	if !strings.Contains(userErr.Message, "notion-id") {
+		t.Fatalf("message = %q", userErr.Message)
+	}
+
+	if err := runPageSplit(&Context{JSON: true}, file, pageFileOptions{SplitOn: "^---$"}, true); !errors.As(err, &userErr) {
+		t.Fatalf("runPageSplit error = %v, want a user error", err)
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// syncPageTargets writes body to every page in the file's notion-targets
+// list, for docs mirrored to several pages. Each target is synced and
+// reported on its own. It stops at the first failed target unless
+// --continue-on-error is given.
+func syncPageTargets(ctx *Context, file string, fm cli.Frontmatter, body string, opts pageFileOptions) error {
+	if fm.NotionID != "" {
+		err := &output.UserError{Message: file + " has both notion-id and a notion-targets list; keep one of them"}
+		output.PrintError(err)
+		return err
+	}
+
+	title := opts.Title
+	if title == "" {
+		title = defaultPageTitle(body, file, opts)
+	}
+	title, icon := pageTitleIcon(title, opts.Icon, opts.StripEmoji)
+	displayTitle := title
+	if icon != "" {
+		displayTitle = icon + " " + title
+	}
+
+	client, err := cli.RequireClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	bgCtx := context.Background()
+	appendOnly := opts.AppendOnly || fm.AppendOnly
+	pages := make([]output.Page, 0, len(fm.NotionTargets))
+	var batch cli.BatchResult
+	for _, target := range fm.NotionTargets {
+		uploads, err := syncPageTarget(ctx, bgCtx, client, file, target, body, appendOnly, opts.NoImageUpload)
+		if err != nil {
+			batch.Fail("target "+target, err)
+			if !opts.ContinueOnError {
+				break
+			}
+			continue
+		}
+		batch.Success()
+		recordAudit(ctx, "page.sync", target, file)
+
+		pages = append(pages, output.Page{ID: target, Title: displayTitle, Icon: icon, UploadedAssets: uploadedAssets(uploads)})
+		if !ctx.JSON {
+			output.PrintSuccess("Synced: " + displayTitle + " (" + target + ")")
+		}
+	}
+
+	printBatchSummary(&batch, ctx.JSON)
+	if ctx.JSON {
+		if err := output.PrintPages(pages, true); err != nil {
+			return err
+		}
+	}
+	return batch.Err(opts.IgnoreFailures)
+}
+
+// hasNotionTargets reports whether file has a notion-targets list, so batch
+// flags can be checked before anything is synced.
+func hasNotionTargets(file string) bool {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return false
+	}
+	fm, _ := cli.ParseFrontmatter(string(raw))
+	return len(fm.NotionTargets) > 0
+}
+
+// syncPageTarget replaces the content of one mirrored page. Local images
+// are uploaded for each target so no page shares another's file uploads.
+func syncPageTarget(ctx *Context, bgCtx context.Context, client *mcp.Client, file, pageID, body string, appendOnly, noImageUpload bool) ([]uploadedLocalImage, error) {
+	if err := checkAppendOnly(ctx, pageID, "replace_content", appendOnly); err != nil {
+		return nil, err
+	}
+	body, uploads, err := prepareLocalImageUploads(ctx, bgCtx, file, body, noImageUpload, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := replaceMarkdownPage(ctx, bgCtx, client, pageID, body, uploads); err != nil {
+		return nil, err
+	}
+	return uploads, nil
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// applyTemplateVars parses --var pairs into opts and expands them in
+// --title. Without --var or --strict-vars, files are left untouched.
+func applyTemplateVars(opts *pageFileOptions, pairs []string, strict bool) error {
+	if len(pairs) == 0 && !strict {
+		return nil
+	}
+	vars, err := cli.ParseTemplateVars(pairs)
+	if err != nil {
+		return &output.UserError{Message: err.Error()}
+	}
+	opts.Vars, opts.StrictVars = vars, strict
+	opts.Title, err = expandTemplate(opts.Title, *opts)
+	return err
+}
+
+// expandTemplate fills {{key}} placeholders in text from --var. Without
+// --var or --strict-vars, text is returned as is.
+func expandTemplate(text string, opts pageFileOptions) (string, error) {
+	if opts.Vars == nil {
+		return text, nil
+	}
+	expanded, err := cli.ExpandTemplateVars(text, opts.Vars, opts.StrictVars)
+	if err != nil {
+		return "", &output.UserError{Message: err.Error()}
+	}
+	return expanded, nil
+}
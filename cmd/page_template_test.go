@@ -0,0 +1,54 @@
+package cmd
+
+import "testing"
+
+func TestApplyTemplateVarsExpandsTitleAndBody(t *testing.T) {
+	opts := pageFileOptions{Title: "Release {{version}}"}
+	if err := applyTemplateVars(&opts, []string{"version=2.1", "team=core"}, false); err != nil {
+		t.Fatalf("applyTemplateVars: %v", err)
+	}
+	if opts.Title != "Release 2.1" {
+		t.Fatalf("title = %q", opts.Title)
+	}
+
+	content := "---\nnotion-id: abc\nowner: {{team}}\n---\n# Release {{version}} by {{team}}\n"
+	got, err := preprocessPageFile(content, opts)
+	if err != nil {
+		t.Fatalf("preprocessPageFile: %v", err)
+	}
+	if want := "---\nnotion-id: abc\nowner: {{team}}\n---\n# Release 2.1 by core\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestApplyTemplateVarsStrict(t *testing.T) {
+	opts := pageFileOptions{Title: "{{missing}}"}
+	if err := applyTemplateVars(&opts, nil, true); err == nil {
+		t.Fatal("expected error for undefined title variable")
+	}
+
+	opts = pageFileOptions{}
+	if err := applyTemplateVars(&opts, nil, false); err != nil || opts.Vars != nil {
+		t.Fatalf("without flags: vars = %v, err = %v", opts.Vars, err)
+	}
+	if got, _ := preprocessPageFile("{{left}}", opts); got != "{{left}}" {
+		t.Fatalf("body changed without --var: %q", got)
+	}
+}
+
+func TestExpandPageBodyStripsComments(t *testing.T) {
+	content := "---\nnotion-id: abc\n---\n# Plan\n<!-- ask {{owner}} first -->\nShip it.\n"
+	opts := pageFileOptions{Vars: map[string]string{}, StrictVars: true}
+	if _, err := preprocessPageFile(content, opts); err == nil {
+		t.Fatal("expected error for placeholder in comment without --strip-comments")
+	}
+
+	opts.StripComments = true
+	got, err := preprocessPageFile(content, opts)
+	if err != nil {
+		t.Fatalf("preprocessPageFile: %v", err)
+	}
+	if want := "---\nnotion-id: abc\n---\n# Plan\nShip it.\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/lox/notion-cli/internal/mcp"
 	"github.com/lox/notion-cli/internal/output"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
 )
 
 func TestShouldLoadPageViewComments(t *testing.T) {
@@ -94,7 +98,7 @@ func TestRenderFetchedPageViewContinuesWhenCommentsFail(t *testing.T) {
 		}
 	}
 
-	err := renderFetchedPageView(context.Background(), &Context{}, nil, "page-123", &mcp.FetchResult{Content: "page body"}, false, true)
+	err := renderFetchedPageView(context.Background(), &Context{}, nil, "page-123", &mcp.FetchResult{Content: "page body"}, pageViewOptions{Comments: true})
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -136,7 +140,7 @@ func TestRenderFetchedPageViewJSONSkipsWarningsWhenCommentsFail(t *testing.T) {
 		t.Fatalf("unexpected warning in JSON mode: %q", message)
 	}
 
-	err := renderFetchedPageView(context.Background(), &Context{JSON: true}, nil, "page-123", &mcp.FetchResult{Content: "page body"}, false, true)
+	err := renderFetchedPageView(context.Background(), &Context{JSON: true}, nil, "page-123", &mcp.FetchResult{Content: "page body"}, pageViewOptions{Comments: true})
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -144,3 +148,28 @@ func TestRenderFetchedPageViewJSONSkipsWarningsWhenCommentsFail(t *testing.T) {
 		t.Fatalf("expected JSON page output")
 	}
 }
+
+func TestSaveRawFetchWritesContentAndToolResult(t *testing.T) {
+	dir := t.TempDir()
+	contentPath := filepath.Join(dir, "content.txt")
+	jsonPath := filepath.Join(dir, "result.json")
+	result := &mcp.FetchResult{
+		Content: "<page>\n# Title\n</page>",
+		ToolResult: &mcpgo.CallToolResult{
+			Content: []mcpgo.Content{mcpgo.NewTextContent(`{"text":"<page>"}`)},
+		},
+	}
+
+	if err := saveRawFetch(result, pageViewOptions{RawContentFile: contentPath, RawJSON: jsonPath}); err != nil {
+		t.Fatalf("saveRawFetch: %v", err)
+	}
+	content, err := os.ReadFile(contentPath)
+	if err != nil || string(content) != result.Content {
+		t.Fatalf("content file = %q, %v", content, err)
+	}
+	raw, err := os.ReadFile(jsonPath)
+	var decoded mcpgo.CallToolResult
+	if err != nil || json.Unmarshal(raw, &decoded) != nil || mcpgo.GetTextFromContent(decoded.Content[0]) != `{"text":"<page>"}` {
+		t.Fatalf("JSON file = %s, %v", raw, err)
+	}
+}
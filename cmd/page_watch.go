@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+const watchDebounce = 500 * time.Millisecond
+
+var watchOutput io.Writer = os.Stderr
+
+// runPageSyncWatch syncs file, then re-syncs whenever it or one of its local
+// images changes, until interrupted. Bursts of events from a single save are
+// debounced, and a change that leaves the file and its images as they were
+// after the last sync (such as the notion-id written by the first sync) is
+// ignored.
+func runPageSyncWatch(ctx *Context, file string, opts pageFileOptions) error {
+	if ctx.JSON {
+		err := &output.UserError{Message: "--watch cannot be combined with --json"}
+		output.PrintError(err)
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	defer func() { _ = watcher.Close() }()
+
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watched := newWatchSet(watcher)
+	var last string
+	syncOnce := func() {
+		fingerprint, paths := watchFingerprint(file)
+		if fingerprint == last {
+			return
+		}
+		stamp := time.Now().Format("15:04:05")
+		if err := runPageSync(ctx, file, opts); err != nil {
+			_, _ = fmt.Fprintf(watchOutput, "[%s] sync failed; waiting for the next change\n", stamp)
+		} else {
+			_, _ = fmt.Fprintf(watchOutput, "[%s] synced %s\n", stamp, file)
+		}
+		// Fingerprint again so our own frontmatter update is not a change.
+		last, paths = watchFingerprint(file)
+		watched.set(paths)
+	}
+
+	syncOnce()
+	_, _ = fmt.Fprintf(watchOutput, "Watching %s for changes (Ctrl+C to stop)\n", file)
+
+	events := make(chan struct{}, 1)
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 && watched.has(event.Name) {
+					select {
+					case events <- struct{}{}:
+					default:
+					}
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				printWarningFn("Watch error: " + err.Error())
+			}
+		}
+	}()
+
+	debounceEvents(sigCtx, events, watchDebounce, syncOnce)
+	return nil
+}
+
+// debounceEvents calls fn once no event has arrived for delay, and returns
+// when ctx is done.
+func debounceEvents(ctx context.Context, events <-chan struct{}, delay time.Duration, fn func()) {
+	timer := time.NewTimer(delay)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-events:
+			timer.Reset(delay)
+		case <-timer.C:
+			fn()
+		}
+	}
+}
+
+// watchFingerprint summarises the file's content and the size and
+// modification time of each local image it references, returning the
+// paths to watch along with it.
+func watchFingerprint(file string) (string, []string) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		abs = file
+	}
+	paths := []string{abs}
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return "", paths
+	}
+
+	var b strings.Builder
+	b.Write(raw)
+	for _, path := range cli.LocalImagePaths(string(raw), file) {
+		paths = append(paths, path)
+		if info, err := os.Stat(path); err == nil {
+			fmt.Fprintf(&b, "\x00%s %d %d", path, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	return b.String(), paths
+}
+
+// watchSet tracks the files being watched. fsnotify watches their
+// directories, since editors often save by replacing the file.
+type watchSet struct {
+	watcher *fsnotify.Watcher
+	mu      sync.Mutex
+	files   map[string]bool
+	dirs    map[string]bool
+}
+
+func newWatchSet(watcher *fsnotify.Watcher) *watchSet {
+	return &watchSet{watcher: watcher, files: make(map[string]bool), dirs: make(map[string]bool)}
+}
+
+func (w *watchSet) set(paths []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.files = make(map[string]bool, len(paths))
+	for _, path := range paths {
+		w.files[filepath.Clean(path)] = true
+		dir := filepath.Dir(path)
+		if w.dirs[dir] {
+			continue
+		}
+		if err := w.watcher.Add(dir); err != nil {
+			printWarningFn("Unable to watch " + dir + ": " + err.Error())
+			continue
+		}
+		w.dirs[dir] = true
+	}
+}
+
+func (w *watchSet) has(path string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return w.files[filepath.Clean(path)]
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDebounceEventsCoalescesBursts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan struct{})
+	calls := make(chan struct{}, 10)
+	done := make(chan struct{})
+	go func() {
+		debounceEvents(ctx, events, 20*time.Millisecond, func() { calls <- struct{}{} })
+		close(done)
+	}()
+
+	for i := 0; i < 3; i++ {
+		events <- struct{}{}
+	}
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Fatal("debounced call never happened")
+	}
+	select {
+	case <-calls:
+		t.Fatal("burst produced more than one call")
+	case <-time.After(60 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("debounceEvents did not stop on cancel")
+	}
+}
+
+func TestWatchFingerprintTracksImages(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "doc.md")
+	img := filepath.Join(dir, "a.png")
+	if err := os.WriteFile(file, []byte("![A](./a.png)\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.WriteFile(img, []byte("one"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	before, paths := watchFingerprint(file)
+	if len(paths) != 2 || paths[1] != img {
+		t.Fatalf("paths = %v", paths)
+	}
+	if again, _ := watchFingerprint(file); again != before {
+		t.Fatal("fingerprint changed without any edit")
+	}
+
+	if err := os.WriteFile(img, []byte("two, longer"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if after, _ := watchFingerprint(file); after == before {
+		t.Fatal("fingerprint did not change after the image changed")
+	}
+}
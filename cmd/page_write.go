@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lox/notion-cli/internal/api"
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// resolveCreateParent fills in the parent of req from --parent-db or --parent.
+// --parent workspace targets the top level of the workspace.
+// For a database parent it also detects the title property from the schema
+// unless req.TitleProperty was already set.
+func resolveCreateParent(bgCtx context.Context, client *mcp.Client, parents *parentResolver, parent, parentDB string, req *mcp.CreatePageRequest) error {
+	if parentDB != "" {
+		dbID, schema, err := parents.resolveDatabase(bgCtx, client, parentDB)
+		if err != nil {
+			return err
+		}
+		req.ParentDatabaseID = dbID
+		if req.TitleProperty == "" {
+			req.TitleProperty = schemaTitleProperty(schema)
+		}
+		return nil
+	}
+	if isWorkspaceParent(parent) {
+		req.Workspace = true
+		return nil
+	}
+	if parent != "" {
+		parentID, err := parents.resolve(bgCtx, client, parent)
+		if err != nil {
+			return err
+		}
+		req.ParentPageID = parentID
+	}
+	return nil
+}
+
+// resolveDataSource fetches a database once to find its data source ID and
+// its schema. If the fetch fails, dbID is assumed to already be a data
+// source ID and the schema is left empty.
+func resolveDataSource(bgCtx context.Context, client *mcp.Client, dbID string) (string, output.DatabaseSchema) {
+	result, err := client.Fetch(bgCtx, dbID)
+	if err != nil {
+		return dbID, output.DatabaseSchema{}
+	}
+	if dsID := mcp.DataSourceIDFromContent(result.Content); dsID != "" {
+		dbID = dsID
+	}
+	return dbID, output.ParseDatabaseSchema(result.Content)
+}
+
+// schemaTitleProperty returns the name of the title-typed column, or "" if
+// the schema does not include one.
+func schemaTitleProperty(schema output.DatabaseSchema) string {
+	return schemaColumnType(schema, "title")
+}
+
+// schemaColumnType returns the name of the first column of the given type.
+func schemaColumnType(schema output.DatabaseSchema, columnType string) string {
+	for _, col := range schema.Columns {
+		if col.Type == columnType {
+			return col.Name
+		}
+	}
+	return ""
+}
+
+// createMarkdownPage creates a page and swaps in any uploaded local images.
+// Content over --chunk-size is created with its first part and the rest
+// appended. If the remaining parts or the images cannot be added, the new
+// page is trashed again.
+func createMarkdownPage(ctx *Context, bgCtx context.Context, client *mcp.Client, req mcp.CreatePageRequest, uploads []uploadedLocalImage) (*mcp.CreatePageResponse, string, error) {
+	chunks := chunkPageContent(ctx, req.Content)
+	req.Content = chunks[0]
+	resp, err := client.CreatePage(bgCtx, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pageID := pageIDFromCreateResponse(resp)
+	if len(chunks) > 1 {
+		err := fmt.Errorf("page created but its ID is unknown, so the remaining %d parts of the content were not added", len(chunks)-1)
+		if pageID != "" {
+			err = appendMarkdownChunks(bgCtx, client, pageID, chunks[1:])
+		}
+		if err != nil {
+			return nil, "", trashCreatedPage(ctx, bgCtx, pageID, err)
+		}
+	}
+	if err := substituteUploadedLocalImages(ctx, bgCtx, pageID, uploads); err != nil {
+		return nil, "", trashCreatedPage(ctx, bgCtx, pageID, fmt.Errorf("insert uploaded local images: %w", err))
+	}
+	return resp, pageID, nil
+}
+
+// trashCreatedPage moves a page that could not be completed to trash, when
+// its ID is known and the official API is configured, and returns err with
+// any cleanup failure noted.
+func trashCreatedPage(ctx *Context, bgCtx context.Context, pageID string, err error) error {
+	if pageID == "" {
+		return err
+	}
+	if apiClient, apiErr := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx)); apiErr == nil {
+		if cleanupErr := apiClient.TrashPage(bgCtx, pageID); cleanupErr != nil {
+			err = fmt.Errorf("%w (cleanup failed: %v)", err, cleanupErr)
+		}
+	}
+	return err
+}
+
+// replaceMarkdownPage replaces a page's content and swaps in any uploaded
+// local images. Content over --chunk-size replaces the page with its first
+// part and appends the rest. When the content is sent in several parts or
+// has images to insert, a snapshot is taken before the replace and the
+// previous content is restored from it if a later step fails.
+func replaceMarkdownPage(ctx *Context, bgCtx context.Context, client *mcp.Client, pageID, body string, uploads []uploadedLocalImage) error {
+	chunks := chunkPageContent(ctx, body)
+	var snapshot *api.PageMarkdown
+	if len(uploads) > 0 || len(chunks) > 1 {
+		var err error
+		if snapshot, err = snapshotPage(ctx, bgCtx, client, pageID, len(uploads) > 0); err != nil {
+			return err
+		}
+	}
+
+	req := mcp.UpdatePageRequest{
+		PageID:     pageID,
+		Command:    "replace_content",
+		NewContent: chunks[0],
+	}
+	if err := client.UpdatePage(bgCtx, req); err != nil {
+		return err
+	}
+	if err := appendMarkdownChunks(bgCtx, client, pageID, chunks[1:]); err != nil {
+		finalErr := fmt.Errorf("page content is incomplete: %w", err)
+		if rollbackErr := rollbackSyncedPage(bgCtx, client, pageID, snapshot); rollbackErr != nil {
+			finalErr = fmt.Errorf("%w (rollback failed: %v)", finalErr, rollbackErr)
+		}
+		return finalErr
+	}
+	if err := substituteUploadedLocalImages(ctx, bgCtx, pageID, uploads); err != nil {
+		finalErr := fmt.Errorf("insert uploaded local images: %w", err)
+		if rollbackErr := rollbackSyncedPage(bgCtx, client, pageID, snapshot); rollbackErr != nil {
+			finalErr = fmt.Errorf("%w (rollback failed: %v)", finalErr, rollbackErr)
+		}
+		return finalErr
+	}
+	return nil
+}
+
+// snapshotPage returns a page's current content for rollbackSyncedPage.
+// With local images it is read through the official API, which uploading
+// them needs anyway; otherwise it is read over MCP, so chunked syncs work
+// without an API token.
+func snapshotPage(ctx *Context, bgCtx context.Context, client pageContentAppender, pageID string, withUploads bool) (*api.PageMarkdown, error) {
+	if withUploads {
+		apiClient, err := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return apiClient.GetPageMarkdown(bgCtx, pageID)
+	}
+	result, err := client.Fetch(bgCtx, pageID)
+	if err != nil {
+		return nil, err
+	}
+	body, _ := output.PageNotionMarkup(result.Content)
+	return &api.PageMarkdown{ID: pageID, Markdown: body}, nil
+}
+
+// writeSourceFile rewrites a synced markdown file, keeping its permissions
+// and, with preserveMtime, its modification time.
+func writeSourceFile(file, content string, preserveMtime bool) error {
+	fileMode := os.FileMode(0o644)
+	info, statErr := os.Stat(file)
+	if statErr == nil {
+		fileMode = info.Mode()
+	}
+	if err := os.WriteFile(file, []byte(content), fileMode); err != nil {
+		return err
+	}
+	if preserveMtime && statErr == nil {
+		return os.Chtimes(file, time.Time{}, info.ModTime())
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteSourceFilePreserveMtime(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(file, []byte("# Notes\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	old := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(file, old, old); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	if err := writeSourceFile(file, "---\nnotion-id: abc\n---\n# Notes\n", true); err != nil {
+		t.Fatalf("writeSourceFile: %v", err)
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if !info.ModTime().Equal(old) {
+		t.Fatalf("mtime = %v, want %v", info.ModTime(), old)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	if err := writeSourceFile(file, "# Notes\n", false); err != nil {
+		t.Fatalf("writeSourceFile: %v", err)
+	}
+	if info, _ := os.Stat(file); info.ModTime().Equal(old) {
+		t.Fatal("mtime unchanged without preserveMtime")
+	}
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// expandDateProperties rewrites plain values for the schema's date columns,
+// such as Due=2024-03-01 or Due=2024-03-01..2024-03-05, into the expanded
+// date:<Name>:start/end/is_datetime keys Notion expects. Values for other
+// columns, and keys already in expanded form, are left alone.
+func expandDateProperties(properties map[string]any, schema output.DatabaseSchema) error {
+	for _, col := range schema.Columns {
+		if col.Type != "date" {
+			continue
+		}
+		raw, ok := properties[col.Name].(string)
+		if !ok {
+			continue
+		}
+		date, err := cli.ParseDateValue(raw)
+		if err != nil {
+			return &output.UserError{Message: fmt.Sprintf("property %q: %v", col.Name, err)}
+		}
+		delete(properties, col.Name)
+		properties["date:"+col.Name+":start"] = date.Start
+		if date.End != "" {
+			properties["date:"+col.Name+":end"] = date.End
+		}
+		isDatetime := 0
+		if date.IsDatetime {
+			isDatetime = 1
+		}
+		properties["date:"+col.Name+":is_datetime"] = isDatetime
+	}
+	return nil
+}
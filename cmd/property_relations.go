@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// resolveRelationProperties rewrites values for the schema's relation
+// columns from comma-separated page names, URLs, or IDs into the JSON array
+// of page URLs Notion expects. Values that are already JSON arrays are left
+// alone, as are values for other columns.
+func resolveRelationProperties(ctx context.Context, client *mcp.Client, resolve pageIDResolver, properties map[string]any, schema output.DatabaseSchema) error {
+	for _, col := range schema.Columns {
+		if col.Type != "relation" {
+			continue
+		}
+		raw, ok := properties[col.Name].(string)
+		if !ok || strings.HasPrefix(strings.TrimSpace(raw), "[") {
+			continue
+		}
+
+		urls := []string{}
+		for _, ref := range strings.Split(raw, ",") {
+			ref = strings.TrimSpace(ref)
+			if ref == "" {
+				continue
+			}
+			id, err := resolve(ctx, client, ref)
+			if err != nil {
+				return fmt.Errorf("relation %q: %w", col.Name, err)
+			}
+			urls = append(urls, "https://www.notion.so/"+strings.ReplaceAll(id, "-", ""))
+		}
+		encoded, err := json.Marshal(urls)
+		if err != nil {
+			return err
+		}
+		properties[col.Name] = string(encoded)
+	}
+	return nil
+}
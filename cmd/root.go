@@ -7,14 +7,20 @@ type Context struct {
 	APIToken         string
 	APIBaseURL       string
 	APINotionVersion string
+	NonInteractive   bool
+	ChunkSize        int
 }
 
 type CLI struct {
 	Profile          string `help:"Config profile name" env:"NOTION_PROFILE"`
+	Format           string `help:"Output format: table, json, or yaml (--json is the same as --format json)" enum:"table,json,yaml" default:"table" env:"NOTION_CLI_FORMAT"`
 	Token            string `help:"Access token (skips OAuth)" env:"NOTION_ACCESS_TOKEN" hidden:""`
 	APIToken         string `env:"NOTION_API_TOKEN" hidden:""`
 	APIBaseURL       string `env:"NOTION_API_BASE_URL" hidden:""`
 	APINotionVersion string `env:"NOTION_API_NOTION_VERSION" hidden:""`
+	FollowRedirects  bool   `help:"Follow redirects on Notion share links that don't embed an ID" env:"NOTION_FOLLOW_REDIRECTS"`
+	NonInteractive   bool   `help:"Never prompt, even on a terminal; fail where input would be needed" name:"non-interactive" env:"NOTION_NON_INTERACTIVE"`
+	ChunkSize        int    `help:"Send page content longer than this many bytes in several requests (0 sends it in one)" name:"chunk-size" default:"50000" env:"NOTION_CHUNK_SIZE"`
 
 	Auth    AuthCmd    `cmd:"" help:"Authentication commands"`
 	Page    PageCmd    `cmd:"" help:"Page commands"`
@@ -22,7 +28,16 @@ type CLI struct {
 	DB      DBCmd      `cmd:"" name:"db" help:"Database commands"`
 	Comment CommentCmd `cmd:"" help:"Comment commands"`
 	Tools   ToolsCmd   `cmd:"" help:"List available MCP tools"`
+	Log     LogCmd     `cmd:"" help:"Show the local audit log of mutating commands"`
+	Config  ConfigCmd  `cmd:"" help:"Config file commands"`
 	Version VersionCmd `cmd:"" help:"Show version"`
+
+	// Shortcuts for the most common page commands, so "notion-cli sync
+	// notes.md" works without the "page" prefix.
+	View   PageViewCmd   `cmd:"" help:"View a page (same as page view)"`
+	Create PageCreateCmd `cmd:"" help:"Create a page (same as page create)"`
+	Upload PageUploadCmd `cmd:"" help:"Upload a markdown file as a page (same as page upload)"`
+	Sync   PageSyncCmd   `cmd:"" help:"Sync a markdown file to a page (same as page sync)"`
 }
 
 type VersionCmd struct {
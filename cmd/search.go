@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"time"
 
 	"github.com/lox/notion-cli/internal/cli"
 	"github.com/lox/notion-cli/internal/mcp"
@@ -9,18 +10,90 @@ import (
 )
 
 type SearchCmd struct {
-	Query      string `arg:"" help:"Search query"`
-	Limit      int    `help:"Maximum number of results" short:"l" default:"20"`
-	JSON       bool   `help:"Output as JSON" short:"j"`
-	SearchMode string `help:"Search mode: 'workspace' (default) or 'ai' (includes connected sources like Linear, Slack)" short:"m" default:"workspace" enum:"workspace,ai"`
+	Query        string `arg:"" help:"Search query"`
+	Limit        int    `help:"Maximum number of results" short:"l" default:"20"`
+	JSON         bool   `help:"Output as JSON" short:"j"`
+	SearchMode   string `help:"Search mode: 'workspace' (default) or 'ai' (includes connected sources like Linear, Slack)" short:"m" default:"workspace" enum:"workspace,ai"`
+	CreatedAfter string `help:"Only results created after this time (RFC3339, YYYY-MM-DD, or relative like 7d, 2w)" name:"created-after" placeholder:"TIME"`
+	EditedAfter  string `help:"Only results edited after this time (RFC3339, YYYY-MM-DD, or relative like 7d, 2w)" name:"edited-after" placeholder:"TIME"`
+	Template     string `help:"Print each result with a Go template, e.g. '{{.Title}}\\t{{.URL}}'" aliases:"output-template" placeholder:"TMPL"`
+
+	WithContent    bool `help:"Fetch each result and include its markdown body in the JSON output (requires --json)" name:"with-content" aliases:"include-page-content"`
+	MaxConcurrency int  `help:"Maximum number of results fetched at once with --with-content" name:"max-concurrency" default:"4"`
 }
 
 func (c *SearchCmd) Run(ctx *Context) error {
-	ctx.JSON = c.JSON
-	return runSearch(ctx, c.Query, c.Limit, c.SearchMode)
+	ctx.JSON = ctx.JSON || c.JSON
+	if err := applyListTemplate(ctx, c.Template); err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if c.WithContent && !ctx.JSON {
+		err := &output.UserError{Message: "--with-content requires --json"}
+		output.PrintError(err)
+		return err
+	}
+	if c.MaxConcurrency < 1 {
+		err := &output.UserError{Message: "--max-concurrency must be at least 1"}
+		output.PrintError(err)
+		return err
+	}
+	content := searchContentOptions{Enabled: c.WithContent, MaxConcurrency: c.MaxConcurrency}
+
+	filter, err := parseTimeFilter(c.CreatedAfter, c.EditedAfter, time.Now())
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+	if filter.active() {
+		if c.SearchMode == "ai" {
+			err := &output.UserError{Message: "--created-after and --edited-after cannot be combined with --search-mode ai"}
+			output.PrintError(err)
+			return err
+		}
+		return runSearchTimed(ctx, c.Query, c.Limit, filter, content)
+	}
+	return runSearch(ctx, c.Query, c.Limit, c.SearchMode, content)
+}
+
+// runSearchTimed searches through the official API so results can be
+// filtered by their created and last edited times.
+func runSearchTimed(ctx *Context, query string, limit int, filter timeFilter, content searchContentOptions) error {
+	found, err := searchWithTimestamps(ctx, query, filter)
+	if err != nil {
+		output.PrintError(err)
+		return err
+	}
+
+	results := make([]output.SearchResult, 0, len(found))
+	for _, r := range found {
+		if limit > 0 && len(results) >= limit {
+			break
+		}
+		resultType := r.Object
+		if resultType == "data_source" {
+			resultType = "database"
+		}
+		results = append(results, output.SearchResult{
+			ID:    r.ID,
+			Type:  resultType,
+			Title: r.Title(),
+			URL:   r.URL,
+		})
+	}
+
+	if content.Enabled {
+		client, err := cli.RequireClient()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = client.Close() }()
+		attachSearchContent(context.Background(), client.Fetch, results, content.MaxConcurrency)
+	}
+	return output.PrintSearchResults(results, ctx.JSON)
 }
 
-func runSearch(ctx *Context, query string, limit int, searchMode string) error {
+func runSearch(ctx *Context, query string, limit int, searchMode string, content searchContentOptions) error {
 	client, err := cli.RequireClient()
 	if err != nil {
 		return err
@@ -40,6 +113,9 @@ func runSearch(ctx *Context, query string, limit int, searchMode string) error {
 	}
 
 	results := convertSearchResults(resp.Results, limit)
+	if content.Enabled {
+		attachSearchContent(bgCtx, client.Fetch, results, content.MaxConcurrency)
+	}
 	return output.PrintSearchResults(results, ctx.JSON)
 }
 
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// searchContentOptions controls search --with-content.
+type searchContentOptions struct {
+	Enabled        bool
+	MaxConcurrency int
+}
+
+type fetchFunc func(context.Context, string) (*mcp.FetchResult, error)
+
+// attachSearchContent fetches every result, at most maxConcurrency at a
+// time, and stores its markdown body on the result. A failed fetch is
+// recorded on that result rather than failing the whole search.
+func attachSearchContent(ctx context.Context, fetch fetchFunc, results []output.SearchResult, maxConcurrency int) {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(r *output.SearchResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			fetched, err := fetch(ctx, r.ID)
+			if err != nil {
+				r.ContentError = err.Error()
+				return
+			}
+			r.Content = output.PageMarkdown(fetched.Content)
+		}(&results[i])
+	}
+	wg.Wait()
+}
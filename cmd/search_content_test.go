@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lox/notion-cli/internal/mcp"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+func TestAttachSearchContentRespectsConcurrencyAndRecordsErrors(t *testing.T) {
+	var running, peak int32
+	fetch := func(ctx context.Context, id string) (*mcp.FetchResult, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+
+		if id == "bad" {
+			return nil, errors.New("object not found")
+		}
+		return &mcp.FetchResult{Content: "<page>\n<content>\nBody of " + id + "\n</content>\n</page>"}, nil
+	}
+
+	results := []output.SearchResult{{ID: "a"}, {ID: "bad"}, {ID: "c"}, {ID: "d"}, {ID: "e"}}
+	attachSearchContent(context.Background(), fetch, results, 2)
+
+	if peak > 2 {
+		t.Fatalf("peak concurrency = %d, want <= 2", peak)
+	}
+	if results[0].Content != "Body of a" || results[4].Content != "Body of e" {
+		t.Fatalf("unexpected content: %q / %q", results[0].Content, results[4].Content)
+	}
+	if results[1].Content != "" || results[1].ContentError != "object not found" {
+		t.Fatalf("unexpected failed result: %+v", results[1])
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/lox/notion-cli/internal/api"
+	"github.com/lox/notion-cli/internal/cli"
+	"github.com/lox/notion-cli/internal/output"
+)
+
+// timeFilter holds the --created-after and --edited-after bounds of
+// page list and search. Zero bounds are unset.
+type timeFilter struct {
+	CreatedAfter time.Time
+	EditedAfter  time.Time
+}
+
+func parseTimeFilter(createdAfter, editedAfter string, now time.Time) (timeFilter, error) {
+	var f timeFilter
+	if createdAfter != "" {
+		t, err := cli.ParseTimeBound(createdAfter, now)
+		if err != nil {
+			return timeFilter{}, &output.UserError{Message: "--created-after: " + err.Error()}
+		}
+		f.CreatedAfter = t
+	}
+	if editedAfter != "" {
+		t, err := cli.ParseTimeBound(editedAfter, now)
+		if err != nil {
+			return timeFilter{}, &output.UserError{Message: "--edited-after: " + err.Error()}
+		}
+		f.EditedAfter = t
+	}
+	return f, nil
+}
+
+func (f timeFilter) active() bool {
+	return !f.CreatedAfter.IsZero() || !f.EditedAfter.IsZero()
+}
+
+func (f timeFilter) match(created, edited time.Time) bool {
+	if !f.CreatedAfter.IsZero() && !created.After(f.CreatedAfter) {
+		return false
+	}
+	if !f.EditedAfter.IsZero() && !edited.After(f.EditedAfter) {
+		return false
+	}
+	return true
+}
+
+func (f timeFilter) apply(results []api.QueriedPage) []api.QueriedPage {
+	if !f.active() {
+		return results
+	}
+	kept := make([]api.QueriedPage, 0, len(results))
+	for _, r := range results {
+		if f.match(r.CreatedTime, r.LastEditedTime) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// searchWithTimestamps runs a workspace search through the official API,
+// whose results carry the created and last edited times that MCP search
+// does not return, and applies the time filter to them.
+func searchWithTimestamps(ctx *Context, query string, filter timeFilter) ([]api.QueriedPage, error) {
+	apiClient, err := cli.RequireOfficialAPIClient(officialAPIOverrides(ctx))
+	if err != nil {
+		return nil, err
+	}
+	results, err := apiClient.Search(context.Background(), query, 0)
+	if err != nil {
+		return nil, err
+	}
+	return filter.apply(results), nil
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lox/notion-cli/internal/api"
+)
+
+func TestTimeFilterApply(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	filter, err := parseTimeFilter("2026-03-01", "7d", now)
+	if err != nil {
+		t.Fatalf("parseTimeFilter: %v", err)
+	}
+
+	results := []api.QueriedPage{
+		{ID: "new-and-edited", CreatedTime: now.AddDate(0, 0, -3), LastEditedTime: now.AddDate(0, 0, -1)},
+		{ID: "old", CreatedTime: now.AddDate(0, -2, 0), LastEditedTime: now.AddDate(0, 0, -1)},
+		{ID: "stale", CreatedTime: now.AddDate(0, 0, -10), LastEditedTime: now.AddDate(0, 0, -9)},
+	}
+	kept := filter.apply(results)
+	if len(kept) != 1 || kept[0].ID != "new-and-edited" {
+		t.Fatalf("unexpected results: %+v", kept)
+	}
+
+	if _, err := parseTimeFilter("soon", "", now); err == nil {
+		t.Fatal("expected error for invalid --created-after")
+	}
+	if (timeFilter{}).active() {
+		t.Fatal("empty filter should be inactive")
+	}
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -21,7 +20,7 @@ type toolSummary struct {
 }
 
 func (c *ToolsCmd) Run(ctx *Context) error {
-	ctx.JSON = c.JSON
+	ctx.JSON = ctx.JSON || c.JSON
 
 	client, err := cli.RequireClient()
 	if err != nil {
@@ -41,14 +40,12 @@ func (c *ToolsCmd) Run(ctx *Context) error {
 		summaries[i] = toolSummary{Name: t.Name, Description: t.Description}
 	}
 
-	return printTools(os.Stdout, summaries, c.JSON)
+	return printTools(os.Stdout, summaries, ctx.JSON)
 }
 
 func printTools(w io.Writer, tools []toolSummary, asJSON bool) error {
 	if asJSON {
-		enc := json.NewEncoder(w)
-		enc.SetIndent("", "  ")
-		return enc.Encode(tools)
+		return output.WriteStructured(w, tools)
 	}
 
 	for _, t := range tools {
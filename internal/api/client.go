@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime"
@@ -40,7 +41,31 @@ type Self struct {
 }
 
 type SelfBot struct {
-	WorkspaceName string `json:"workspace_name,omitempty"`
+	Owner           *SelfBotOwner    `json:"owner,omitempty"`
+	WorkspaceID     string           `json:"workspace_id,omitempty"`
+	WorkspaceName   string           `json:"workspace_name,omitempty"`
+	WorkspaceLimits *WorkspaceLimits `json:"workspace_limits,omitempty"`
+}
+
+type SelfBotOwner struct {
+	Type      string `json:"type"`
+	Workspace bool   `json:"workspace,omitempty"`
+}
+
+type WorkspaceLimits struct {
+	MaxFileUploadSizeInBytes int64 `json:"max_file_upload_size_in_bytes,omitempty"`
+}
+
+// Error is a non-2xx response from the official API.
+type Error struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("official API %s %s failed (%d): %s", e.Method, e.Path, e.StatusCode, e.Message)
 }
 
 type FileUpload struct {
@@ -62,10 +87,95 @@ type PageMarkdown struct {
 }
 
 type Block struct {
-	ID        string          `json:"id"`
-	Object    string          `json:"object"`
-	Type      string          `json:"type"`
-	Paragraph *ParagraphBlock `json:"paragraph,omitempty"`
+	ID          string          `json:"id"`
+	Object      string          `json:"object"`
+	Type        string          `json:"type"`
+	HasChildren bool            `json:"has_children"`
+	Paragraph   *ParagraphBlock `json:"paragraph,omitempty"`
+
+	// text is the plain text of the type-specific body, whatever the type,
+	// and links counts the linked spans in it.
+	text  string
+	links int
+	// file is set for file, pdf, audio, and video blocks.
+	file *BlockFile
+}
+
+// BlockFile is the file held by a file, pdf, audio, or video block.
+type BlockFile struct {
+	Name string
+	URL  string
+	// ExpiresAt is when a Notion-hosted file's signed URL stops working;
+	// it is zero for external files.
+	ExpiresAt time.Time
+}
+
+// fileBlockTypes are the block types whose body is a file object.
+var fileBlockTypes = map[string]bool{"file": true, "pdf": true, "audio": true, "video": true}
+
+// UnmarshalJSON decodes a block and keeps the plain text of its
+// type-specific body (rich_text, or the title of child pages and databases).
+func (b *Block) UnmarshalJSON(data []byte) error {
+	type plainBlock Block
+	if err := json.Unmarshal(data, (*plainBlock)(b)); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	var body struct {
+		RichText []RichText `json:"rich_text"`
+		Title    string     `json:"title"`
+
+		Name string `json:"name"`
+		File *struct {
+			URL        string    `json:"url"`
+			ExpiryTime time.Time `json:"expiry_time"`
+		} `json:"file"`
+		External *struct {
+			URL string `json:"url"`
+		} `json:"external"`
+	}
+	if raw, ok := fields[b.Type]; ok {
+		_ = json.Unmarshal(raw, &body)
+	}
+	b.text = plainText(body.RichText)
+	if b.text == "" {
+		b.text = body.Title
+	}
+	for _, rt := range body.RichText {
+		if rt.Href != "" {
+			b.links++
+		}
+	}
+	if fileBlockTypes[b.Type] {
+		switch {
+		case body.File != nil:
+			b.file = &BlockFile{Name: body.Name, URL: body.File.URL, ExpiresAt: body.File.ExpiryTime}
+		case body.External != nil:
+			b.file = &BlockFile{Name: body.Name, URL: body.External.URL}
+		}
+	}
+	return nil
+}
+
+// Text returns the block's plain text, or "" for blocks without any.
+func (b Block) Text() string {
+	return b.text
+}
+
+// File returns the file of a file, pdf, audio, or video block.
+func (b Block) File() (BlockFile, bool) {
+	if b.file == nil {
+		return BlockFile{}, false
+	}
+	return *b.file, true
+}
+
+// Links returns how many spans of the block's text are links.
+func (b Block) Links() int {
+	return b.links
 }
 
 type ParagraphBlock struct {
@@ -73,7 +183,10 @@ type ParagraphBlock struct {
 }
 
 type RichText struct {
-	PlainText string `json:"plain_text"`
+	Type        string       `json:"type,omitempty"`
+	PlainText   string       `json:"plain_text"`
+	Href        string       `json:"href,omitempty"`
+	Annotations *Annotations `json:"annotations,omitempty"`
 }
 
 type listBlocksResponse struct {
@@ -82,7 +195,34 @@ type listBlocksResponse struct {
 	HasMore    bool    `json:"has_more"`
 }
 
-func NewClient(cfg config.APIConfig, token string) (*Client, error) {
+// Option customises a Client built by NewClient.
+type Option func(*Client)
+
+// WithHTTPClient sends requests through httpClient instead of the default
+// client with a 20 second timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithTransport keeps the default client and timeout but sends requests
+// through transport, for logging, mocking, or custom auth. Options apply in
+// order, so it wraps whichever client an earlier WithHTTPClient set.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		if transport == nil {
+			return
+		}
+		httpClient := *c.httpClient
+		httpClient.Transport = transport
+		c.httpClient = &httpClient
+	}
+}
+
+func NewClient(cfg config.APIConfig, token string, opts ...Option) (*Client, error) {
 	token = strings.TrimSpace(token)
 	if token == "" {
 		return nil, fmt.Errorf("official API token is required")
@@ -92,17 +232,24 @@ func NewClient(cfg config.APIConfig, token string) (*Client, error) {
 	if baseURL == "" {
 		baseURL = "https://api.notion.com/v1"
 	}
+	if err := config.ValidateBaseURL(baseURL); err != nil {
+		return nil, err
+	}
 	notionVersion := strings.TrimSpace(cfg.NotionVersion)
 	if notionVersion == "" {
 		notionVersion = "2026-03-11"
 	}
 
-	return &Client{
+	client := &Client{
 		httpClient:    &http.Client{Timeout: defaultHTTPTimeout},
 		baseURL:       strings.TrimRight(baseURL, "/"),
 		notionVersion: notionVersion,
 		token:         token,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client, nil
 }
 
 func (c *Client) GetSelf(ctx context.Context) (*Self, error) {
@@ -113,6 +260,21 @@ func (c *Client) GetSelf(ctx context.Context) (*Self, error) {
 	return &out, nil
 }
 
+// CanReadContent probes whether the integration has the read content
+// capability by running a one-result search. The API does not report
+// capabilities directly, so a 403 is taken to mean the capability is missing.
+func (c *Client) CanReadContent(ctx context.Context) (bool, error) {
+	err := c.doJSON(ctx, http.MethodPost, "/search", map[string]any{"page_size": 1}, nil)
+	if err == nil {
+		return true, nil
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden {
+		return false, nil
+	}
+	return false, err
+}
+
 func (c *Client) GetPageMarkdown(ctx context.Context, pageID string) (*PageMarkdown, error) {
 	pageID = strings.TrimSpace(pageID)
 	if pageID == "" {
@@ -208,6 +370,51 @@ func (c *Client) AppendUploadedImageAfter(ctx context.Context, parentID, afterBl
 	return c.doJSON(ctx, http.MethodPatch, "/blocks/"+parentID+"/children", payload, nil)
 }
 
+// AppendBlocksAfter inserts blocks directly after afterBlockID, which must be
+// a child of parentID. Blocks are sent MaxBlockChildren at a time, each
+// batch anchored after the last block created by the one before, so they
+// keep their order.
+func (c *Client) AppendBlocksAfter(ctx context.Context, parentID, afterBlockID string, blocks []map[string]any) error {
+	parentID = strings.TrimSpace(parentID)
+	afterBlockID = strings.TrimSpace(afterBlockID)
+	if parentID == "" {
+		return fmt.Errorf("parent ID is required")
+	}
+	if afterBlockID == "" {
+		return fmt.Errorf("after block ID is required")
+	}
+	if len(blocks) == 0 {
+		return fmt.Errorf("content is required")
+	}
+
+	for start := 0; start < len(blocks); start += MaxBlockChildren {
+		batch := blocks[start:min(start+MaxBlockChildren, len(blocks))]
+		payload := map[string]any{
+			"children": batch,
+			"position": map[string]any{
+				"type": "after_block",
+				"after_block": map[string]any{
+					"id": afterBlockID,
+				},
+			},
+		}
+
+		var resp struct {
+			Results []Block `json:"results"`
+		}
+		if err := c.doJSON(ctx, http.MethodPatch, "/blocks/"+parentID+"/children", payload, &resp); err != nil {
+			return err
+		}
+		if start+MaxBlockChildren < len(blocks) {
+			if len(resp.Results) == 0 {
+				return fmt.Errorf("append response listed no created blocks")
+			}
+			afterBlockID = resp.Results[len(resp.Results)-1].ID
+		}
+	}
+	return nil
+}
+
 func (c *Client) ListAllBlockChildren(ctx context.Context, blockID string) ([]Block, error) {
 	blockID = strings.TrimSpace(blockID)
 	if blockID == "" {
@@ -268,9 +475,15 @@ func (c *Client) doJSON(ctx context.Context, method, path string, payload any, o
 }
 
 func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader, contentType string, out any) error {
+	_, err := c.doRequestHeader(ctx, method, path, body, contentType, out)
+	return err
+}
+
+// doRequestHeader is doRequest that also returns the response headers.
+func (c *Client) doRequestHeader(ctx context.Context, method, path string, body io.Reader, contentType string, out any) (http.Header, error) {
 	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Authorization", "Bearer "+c.token)
@@ -281,13 +494,13 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if resp.StatusCode >= 400 {
 		message := strings.TrimSpace(string(respBody))
@@ -301,15 +514,15 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 				message = strings.TrimSpace(errResp.Message)
 			}
 		}
-		return fmt.Errorf("official API %s %s failed (%d): %s", method, path, resp.StatusCode, message)
+		return nil, &Error{Method: method, Path: path, StatusCode: resp.StatusCode, Message: message}
 	}
 	if out == nil || len(respBody) == 0 {
-		return nil
+		return resp.Header, nil
 	}
 	if err := json.Unmarshal(respBody, out); err != nil {
-		return fmt.Errorf("parse official API response for %s %s: %w", method, path, err)
+		return nil, fmt.Errorf("parse official API response for %s %s: %w", method, path, err)
 	}
-	return nil
+	return resp.Header, nil
 }
 
 func (c *Client) sendFileUploadPart(ctx context.Context, fileUploadID, filename string, data []byte) (*FileUpload, error) {
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"mime"
 	"mime/multipart"
@@ -211,6 +212,77 @@ func TestListAllBlockChildrenPaginates(t *testing.T) {
 	}
 }
 
+func TestBlockFile(t *testing.T) {
+	var blocks []Block
+	data := `[
+		{"id":"a","type":"pdf","pdf":{"name":"spec.pdf","type":"file","file":{"url":"https://files.example/spec","expiry_time":"2026-01-01T00:00:00Z"}}},
+		{"id":"b","type":"video","video":{"name":"demo","type":"external","external":{"url":"https://video.example/demo"}}},
+		{"id":"c","type":"paragraph","paragraph":{"rich_text":[{"plain_text":"text"}]}}
+	]`
+	if err := json.Unmarshal([]byte(data), &blocks); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	file, ok := blocks[0].File()
+	if !ok || file.Name != "spec.pdf" || file.URL != "https://files.example/spec" || !file.ExpiresAt.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("pdf File() = %#v, %v", file, ok)
+	}
+	file, ok = blocks[1].File()
+	if !ok || file.URL != "https://video.example/demo" || !file.ExpiresAt.IsZero() {
+		t.Fatalf("video File() = %#v, %v", file, ok)
+	}
+	if _, ok := blocks[2].File(); ok {
+		t.Fatal("paragraph has a file")
+	}
+}
+
+func TestAppendBlocksAfterBatchesInOrder(t *testing.T) {
+	var payloads []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/blocks/page_123/children" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		payloads = append(payloads, payload)
+		_, _ = fmt.Fprintf(w, `{"object":"list","results":[{"id":"created_%d"}]}`, len(payloads))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(config.APIConfig{BaseURL: srv.URL}, "secret-token")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	blocks := make([]map[string]any, MaxBlockChildren+5)
+	for i := range blocks {
+		blocks[i] = map[string]any{"object": "block", "type": "divider", "divider": map[string]any{}}
+	}
+	if err := client.AppendBlocksAfter(context.Background(), "page_123", "block_9", blocks); err != nil {
+		t.Fatalf("AppendBlocksAfter: %v", err)
+	}
+	if len(payloads) != 2 {
+		t.Fatalf("requests = %d, want 2", len(payloads))
+	}
+	for i, want := range []struct {
+		children int
+		after    string
+	}{{MaxBlockChildren, "block_9"}, {5, "created_1"}} {
+		children, _ := payloads[i]["children"].([]any)
+		position, _ := payloads[i]["position"].(map[string]any)
+		after, _ := position["after_block"].(map[string]any)
+		if len(children) != want.children || position["type"] != "after_block" || after["id"] != want.after {
+			t.Fatalf("request %d: %d children after %v, want %d after %s", i, len(children), after["id"], want.children, want.after)
+		}
+	}
+
+	if err := client.AppendBlocksAfter(context.Background(), "page_123", "", blocks); err == nil {
+		t.Fatal("expected error without after block")
+	}
+}
+
 func TestNewClientRejectsEmptyToken(t *testing.T) {
 	_, err := NewClient(config.APIConfig{}, "")
 	if err == nil {
@@ -218,6 +290,13 @@ func TestNewClientRejectsEmptyToken(t *testing.T) {
 	}
 }
 
+func TestNewClientRejectsInvalidBaseURL(t *testing.T) {
+	_, err := NewClient(config.APIConfig{BaseURL: "htps://api.notion.com/v1"}, "token")
+	if err == nil || !strings.Contains(err.Error(), "invalid api.base_url") {
+		t.Fatalf("err = %v, want invalid api.base_url", err)
+	}
+}
+
 func TestClientErrorIncludesAPIMessage(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, `{"message":"bad token"}`, http.StatusUnauthorized)
@@ -260,3 +339,60 @@ func TestTrashPageUsesPatch(t *testing.T) {
 		t.Fatalf("TrashPage: %v", err)
 	}
 }
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestWithTransportInterceptsRequests(t *testing.T) {
+	var seen *http.Request
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		seen = r
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"object":"user","id":"bot_1","type":"bot"}`)),
+			Request:    r,
+		}, nil
+	})
+
+	client, err := NewClient(config.APIConfig{BaseURL: "https://api.example.test/v1"}, "secret-token", WithTransport(transport))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if client.httpClient.Timeout != defaultHTTPTimeout {
+		t.Fatalf("Timeout = %v, want default %v", client.httpClient.Timeout, defaultHTTPTimeout)
+	}
+
+	self, err := client.GetSelf(context.Background())
+	if err != nil {
+		t.Fatalf("GetSelf: %v", err)
+	}
+	if self.ID != "bot_1" {
+		t.Fatalf("self = %#v", self)
+	}
+	if seen == nil || seen.URL.String() != "https://api.example.test/v1/users/me" || seen.Header.Get("Authorization") != "Bearer secret-token" {
+		t.Fatalf("unexpected request: %#v", seen)
+	}
+}
+
+func TestWithHTTPClientReplacesDefault(t *testing.T) {
+	custom := &http.Client{Timeout: time.Second}
+	client, err := NewClient(config.APIConfig{}, "secret-token", WithHTTPClient(custom))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if client.httpClient != custom {
+		t.Fatal("expected custom http client")
+	}
+
+	defaults, err := NewClient(config.APIConfig{}, "secret-token", WithHTTPClient(nil), WithTransport(nil))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if defaults.httpClient.Timeout != defaultHTTPTimeout || defaults.httpClient.Transport != nil {
+		t.Fatalf("nil options changed the default client: %#v", defaults.httpClient)
+	}
+}
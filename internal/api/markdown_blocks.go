@@ -0,0 +1,263 @@
+package api
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxRichTextLength is how many characters Notion accepts in one rich
+	// text item.
+	MaxRichTextLength = 2000
+	// MaxBlockChildren is how many blocks Notion accepts in one append
+	// request.
+	MaxBlockChildren = 100
+)
+
+var (
+	numberedItemRe = regexp.MustCompile(`^\d+[.)]\s+`)
+	dividerRe      = regexp.MustCompile(`^(?:-{3,}|\*{3,}|_{3,})$`)
+)
+
+// codeLanguages maps fence info strings to Notion code block languages.
+// Anything else is sent as plain text, since Notion rejects languages it
+// does not know.
+var codeLanguages = map[string]string{
+	"bash": "bash", "sh": "shell", "shell": "shell", "zsh": "shell",
+	"c": "c", "cpp": "c++", "c++": "c++", "cs": "c#", "csharp": "c#",
+	"css": "css", "diff": "diff", "dockerfile": "docker", "go": "go", "golang": "go",
+	"graphql": "graphql", "html": "html", "java": "java", "js": "javascript",
+	"javascript": "javascript", "json": "json", "kotlin": "kotlin", "lua": "lua",
+	"makefile": "makefile", "markdown": "markdown", "md": "markdown", "php": "php",
+	"py": "python", "python": "python", "rb": "ruby", "ruby": "ruby", "rust": "rust",
+	"scala": "scala", "sql": "sql", "swift": "swift", "toml": "toml", "ts": "typescript",
+	"typescript": "typescript", "xml": "xml", "yaml": "yaml", "yml": "yaml",
+}
+
+// MarkdownBlocks converts markdown into Notion blocks for the append
+// endpoint. Headings, bulleted, numbered, and to-do list items, quotes,
+// dividers, fenced code, and paragraphs become their block types, and
+// bold, italic, strikethrough, inline code, and links become rich text
+// annotations. Nested list items are added at the top level, and text
+// longer than MaxRichTextLength is split across rich text items.
+func MarkdownBlocks(markdown string) []map[string]any {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	var blocks []map[string]any
+	var paragraph, quote []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, textBlock("paragraph", strings.Join(paragraph, "\n"), nil))
+			paragraph = nil
+		}
+		if len(quote) > 0 {
+			blocks = append(blocks, textBlock("quote", strings.Join(quote, "\n"), nil))
+			quote = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if fence, ok := strings.CutPrefix(line, "```"); ok {
+			flush()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			blocks = append(blocks, codeBlock(strings.Join(code, "\n"), fence))
+			continue
+		}
+		if rest, ok := strings.CutPrefix(line, ">"); ok {
+			if len(paragraph) > 0 {
+				flush()
+			}
+			quote = append(quote, strings.TrimSpace(rest))
+			continue
+		}
+		if len(quote) > 0 {
+			flush()
+		}
+
+		switch {
+		case line == "":
+			flush()
+		case dividerRe.MatchString(line):
+			flush()
+			blocks = append(blocks, map[string]any{"object": "block", "type": "divider", "divider": map[string]any{}})
+		case strings.HasPrefix(line, "#"):
+			level := len(line) - len(strings.TrimLeft(line, "#"))
+			text, ok := strings.CutPrefix(line[level:], " ")
+			if !ok {
+				paragraph = append(paragraph, line)
+				continue
+			}
+			flush()
+			blockType := "heading_" + string(rune('0'+min(level, 3)))
+			blocks = append(blocks, textBlock(blockType, strings.TrimSpace(text), nil))
+		case strings.HasPrefix(line, "- [ ] "), strings.HasPrefix(line, "- [x] "), strings.HasPrefix(line, "- [X] "):
+			flush()
+			blocks = append(blocks, textBlock("to_do", line[6:], map[string]any{"checked": line[3] != ' '}))
+		case strings.HasPrefix(line, "- "), strings.HasPrefix(line, "* "), strings.HasPrefix(line, "+ "):
+			flush()
+			blocks = append(blocks, textBlock("bulleted_list_item", line[2:], nil))
+		case numberedItemRe.MatchString(line):
+			flush()
+			blocks = append(blocks, textBlock("numbered_list_item", line[len(numberedItemRe.FindString(line)):], nil))
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+	return blocks
+}
+
+func textBlock(blockType, text string, extra map[string]any) map[string]any {
+	content := map[string]any{"rich_text": MarkdownRichText(text)}
+	for k, v := range extra {
+		content[k] = v
+	}
+	return map[string]any{"object": "block", "type": blockType, blockType: content}
+}
+
+func codeBlock(code, info string) map[string]any {
+	language, ok := codeLanguages[strings.ToLower(strings.TrimSpace(info))]
+	if !ok {
+		language = "plain text"
+	}
+	return map[string]any{"object": "block", "type": "code", "code": map[string]any{
+		"rich_text": richTextItems(code, Annotations{}, ""),
+		"language":  language,
+	}}
+}
+
+// MarkdownRichText converts inline markdown into rich text items: **bold**,
+// *italic* or _italic_, ~~strikethrough~~, `code`, and [text](url) links.
+// Markers without a closing pair are kept as text.
+func MarkdownRichText(s string) []map[string]any {
+	var items []map[string]any
+	var style Annotations
+	var text strings.Builder
+	emit := func(href string) {
+		items = append(items, richTextItems(text.String(), style, href)...)
+		text.Reset()
+	}
+
+	for i := 0; i < len(s); {
+		rest := s[i:]
+		switch {
+		case strings.HasPrefix(rest, "`"):
+			if end := strings.Index(rest[1:], "`"); end >= 0 {
+				emit("")
+				code := style
+				code.Code = true
+				items = append(items, richTextItems(rest[1:end+1], code, "")...)
+				i += end + 2
+				continue
+			}
+		case strings.HasPrefix(rest, "["):
+			if label, href, n, ok := markdownLink(rest); ok {
+				emit("")
+				text.WriteString(label)
+				emit(href)
+				i += n
+				continue
+			}
+		case strings.HasPrefix(rest, "**"), strings.HasPrefix(rest, "__"):
+			if toggleStyle(&style.Bold, rest[2:], rest[:2]) {
+				emit("")
+				style.Bold = !style.Bold
+				i += 2
+				continue
+			}
+		case strings.HasPrefix(rest, "~~"):
+			if toggleStyle(&style.Strikethrough, rest[2:], "~~") {
+				emit("")
+				style.Strikethrough = !style.Strikethrough
+				i += 2
+				continue
+			}
+		case strings.HasPrefix(rest, "*"), strings.HasPrefix(rest, "_") && underscoreBoundary(s, i, style.Italic):
+			if toggleStyle(&style.Italic, rest[1:], rest[:1]) {
+				emit("")
+				style.Italic = !style.Italic
+				i++
+				continue
+			}
+		}
+		r, size := utf8.DecodeRuneInString(rest)
+		text.WriteRune(r)
+		i += size
+	}
+	emit("")
+	return items
+}
+
+// toggleStyle reports whether a marker can switch a style: it always can
+// close an open style, and can open one only if the marker appears again.
+func toggleStyle(on *bool, after, marker string) bool {
+	return *on || strings.Contains(after, marker)
+}
+
+// underscoreBoundary reports whether the _ at i opens or closes italics
+// rather than sitting inside a word like snake_case.
+func underscoreBoundary(s string, i int, closing bool) bool {
+	if closing {
+		next, _ := utf8.DecodeRuneInString(s[i+1:])
+		return i+1 == len(s) || !isWordRune(next)
+	}
+	prev, _ := utf8.DecodeLastRuneInString(s[:i])
+	return i == 0 || !isWordRune(prev)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// markdownLink parses a [label](url) link at the start of s, returning how
+// many bytes it spans.
+func markdownLink(s string) (label, href string, n int, ok bool) {
+	closeLabel := strings.Index(s, "](")
+	if closeLabel < 0 {
+		return "", "", 0, false
+	}
+	closeURL := strings.Index(s[closeLabel+2:], ")")
+	if closeURL < 0 {
+		return "", "", 0, false
+	}
+	label = s[1:closeLabel]
+	href = strings.TrimSpace(s[closeLabel+2 : closeLabel+2+closeURL])
+	if label == "" || href == "" || strings.Contains(label, "\n") {
+		return "", "", 0, false
+	}
+	return label, href, closeLabel + 3 + closeURL, true
+}
+
+// richTextItems returns text as rich text items of at most
+// MaxRichTextLength characters each, all with the same style and link.
+func richTextItems(text string, style Annotations, href string) []map[string]any {
+	var items []map[string]any
+	for text != "" {
+		part := text
+		if utf8.RuneCountInString(part) > MaxRichTextLength {
+			part = string([]rune(part)[:MaxRichTextLength])
+		}
+		text = text[len(part):]
+
+		content := map[string]any{"content": part}
+		if href != "" {
+			content["link"] = map[string]any{"url": href}
+		}
+		item := map[string]any{"type": "text", "text": content}
+		if style != (Annotations{}) {
+			item["annotations"] = map[string]any{
+				"bold":          style.Bold,
+				"italic":        style.Italic,
+				"strikethrough": style.Strikethrough,
+				"code":          style.Code,
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
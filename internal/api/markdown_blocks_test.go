@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMarkdownBlocks(t *testing.T) {
+	blocks := MarkdownBlocks("# Title\n\nSome **bold** and [a link](https://example.com)\nsame paragraph\n\n- one\n1. first\n- [x] done\n> quoted\n---\n```js\nlet x = 1\n```")
+
+	var types []string
+	for _, b := range blocks {
+		types = append(types, b["type"].(string))
+	}
+	want := "heading_1 paragraph bulleted_list_item numbered_list_item to_do quote divider code"
+	if got := strings.Join(types, " "); got != want {
+		t.Fatalf("types = %s, want %s", got, want)
+	}
+
+	data, _ := json.Marshal(blocks)
+	for _, s := range []string{
+		`"content":"Title"`,
+		`"annotations":{"bold":true`,
+		`"link":{"url":"https://example.com"}`,
+		`"content":"\nsame paragraph"`,
+		`"checked":true`,
+		`"language":"javascript"`,
+		`"content":"let x = 1"`,
+	} {
+		if !strings.Contains(string(data), s) {
+			t.Errorf("blocks missing %s: %s", s, data)
+		}
+	}
+}
+
+func TestMarkdownBlocksEmpty(t *testing.T) {
+	if blocks := MarkdownBlocks(" \n\n\t\n"); len(blocks) != 0 {
+		t.Fatalf("blocks = %#v, want none", blocks)
+	}
+}
+
+func TestMarkdownRichTextKeepsUnmatchedMarkers(t *testing.T) {
+	items := MarkdownRichText("snake_case and 2 * 3")
+	if len(items) != 1 {
+		t.Fatalf("items = %#v, want one plain run", items)
+	}
+	if _, styled := items[0]["annotations"]; styled {
+		t.Fatalf("items[0] = %#v, want no annotations", items[0])
+	}
+}
+
+func TestMarkdownRichTextSplitsLongText(t *testing.T) {
+	items := MarkdownRichText(strings.Repeat("é", MaxRichTextLength+10))
+	if len(items) != 2 {
+		t.Fatalf("items = %d, want 2", len(items))
+	}
+	first := items[0]["text"].(map[string]any)["content"].(string)
+	if n := utf8.RuneCountInString(first); n != MaxRichTextLength {
+		t.Fatalf("first item has %d characters, want %d", n, MaxRichTextLength)
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type Page struct {
+	Object string    `json:"object"`
+	ID     string    `json:"id"`
+	URL    string    `json:"url,omitempty"`
+	Icon   *PageIcon `json:"icon,omitempty"`
+}
+
+// PageIcon is a page icon. Emoji, external, and custom_emoji icons can be
+// copied to other pages; Notion-hosted file icons use expiring URLs and
+// cannot be set directly.
+type PageIcon struct {
+	Type        string            `json:"type"`
+	Emoji       string            `json:"emoji,omitempty"`
+	External    *PageIconExternal `json:"external,omitempty"`
+	CustomEmoji *PageIconCustom   `json:"custom_emoji,omitempty"`
+}
+
+type PageIconExternal struct {
+	URL string `json:"url"`
+}
+
+type PageIconCustom struct {
+	ID string `json:"id"`
+}
+
+// Settable reports whether the icon can be applied to another page as is.
+func (i *PageIcon) Settable() bool {
+	if i == nil {
+		return false
+	}
+	switch i.Type {
+	case "emoji":
+		return i.Emoji != ""
+	case "external":
+		return i.External != nil && i.External.URL != ""
+	case "custom_emoji":
+		return i.CustomEmoji != nil && i.CustomEmoji.ID != ""
+	}
+	return false
+}
+
+func (c *Client) RetrievePage(ctx context.Context, pageID string) (*Page, error) {
+	pageID = strings.TrimSpace(pageID)
+	if pageID == "" {
+		return nil, fmt.Errorf("page ID is required")
+	}
+
+	var out Page
+	if err := c.doJSON(ctx, http.MethodGet, "/pages/"+pageID, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) SetPageIcon(ctx context.Context, pageID string, icon *PageIcon) error {
+	pageID = strings.TrimSpace(pageID)
+	if pageID == "" {
+		return fmt.Errorf("page ID is required")
+	}
+	if !icon.Settable() {
+		return fmt.Errorf("icon cannot be set on a page")
+	}
+	return c.doJSON(ctx, http.MethodPatch, "/pages/"+pageID, map[string]any{"icon": icon}, nil)
+}
+
+// UpdatePageProperties patches a page's property values. Values must already
+// be in the API's property value shape; see PropertyValue.
+func (c *Client) UpdatePageProperties(ctx context.Context, pageID string, properties map[string]any) error {
+	pageID = strings.TrimSpace(pageID)
+	if pageID == "" {
+		return fmt.Errorf("page ID is required")
+	}
+	if len(properties) == 0 {
+		return fmt.Errorf("no properties to update")
+	}
+	return c.doJSON(ctx, http.MethodPatch, "/pages/"+pageID, map[string]any{"properties": properties}, nil)
+}
+
+// PropertyValue converts a plain string into the API value for a property of
+// the given type. An empty value clears select, status, number, url, email,
+// and phone number properties.
+func PropertyValue(name string, prop DataSourceProperty, value string) (any, error) {
+	trimmed := strings.TrimSpace(value)
+	switch prop.Type {
+	case "title", "rich_text":
+		return map[string]any{prop.Type: []map[string]any{{"text": map[string]any{"content": value}}}}, nil
+	case "select", "status":
+		if trimmed == "" {
+			return map[string]any{prop.Type: nil}, nil
+		}
+		return map[string]any{prop.Type: map[string]any{"name": trimmed}}, nil
+	case "multi_select":
+		options := make([]map[string]any, 0)
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				options = append(options, map[string]any{"name": part})
+			}
+		}
+		return map[string]any{"multi_select": options}, nil
+	case "url", "email", "phone_number":
+		if trimmed == "" {
+			return map[string]any{prop.Type: nil}, nil
+		}
+		return map[string]any{prop.Type: trimmed}, nil
+	case "number":
+		if trimmed == "" {
+			return map[string]any{"number": nil}, nil
+		}
+		n, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil {
+			return nil, fmt.Errorf("property %q is a number, got %q", name, value)
+		}
+		return map[string]any{"number": n}, nil
+	case "checkbox":
+		b, err := strconv.ParseBool(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("property %q is a checkbox, got %q", name, value)
+		}
+		return map[string]any{"checkbox": b}, nil
+	default:
+		return nil, fmt.Errorf("property %q has unsupported type %q for updating", name, prop.Type)
+	}
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/config"
+)
+
+func TestRetrievePageAndSetPageIcon(t *testing.T) {
+	var patched map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/pages/parent":
+			_, _ = w.Write([]byte(`{"object":"page","id":"parent","icon":{"type":"emoji","emoji":"📚"}}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/pages/child":
+			defer func() { _ = r.Body.Close() }()
+			if err := json.NewDecoder(r.Body).Decode(&patched); err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"object":"page","id":"child"}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(config.APIConfig{BaseURL: srv.URL + "/v1"}, "secret-token")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	page, err := client.RetrievePage(context.Background(), "parent")
+	if err != nil {
+		t.Fatalf("RetrievePage: %v", err)
+	}
+	if !page.Icon.Settable() || page.Icon.Emoji != "📚" {
+		t.Fatalf("unexpected icon: %#v", page.Icon)
+	}
+	if err := client.SetPageIcon(context.Background(), "child", page.Icon); err != nil {
+		t.Fatalf("SetPageIcon: %v", err)
+	}
+	icon, ok := patched["icon"].(map[string]any)
+	if !ok || icon["type"] != "emoji" || icon["emoji"] != "📚" {
+		t.Fatalf("patched = %#v", patched)
+	}
+}
+
+func TestPageIconSettable(t *testing.T) {
+	tests := []struct {
+		icon *PageIcon
+		want bool
+	}{
+		{nil, false},
+		{&PageIcon{Type: "emoji", Emoji: "🚀"}, true},
+		{&PageIcon{Type: "external", External: &PageIconExternal{URL: "https://example.com/i.png"}}, true},
+		{&PageIcon{Type: "file"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.icon.Settable(); got != tt.want {
+			t.Fatalf("Settable(%#v) = %v, want %v", tt.icon, got, tt.want)
+		}
+	}
+}
+
+func TestUpdatePageProperties(t *testing.T) {
+	var patched map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/v1/pages/row" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		defer func() { _ = r.Body.Close() }()
+		if err := json.NewDecoder(r.Body).Decode(&patched); err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"object":"page","id":"row"}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(config.APIConfig{BaseURL: srv.URL + "/v1"}, "secret-token")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	value, err := PropertyValue("Status", DataSourceProperty{Type: "status"}, "Done")
+	if err != nil {
+		t.Fatalf("PropertyValue: %v", err)
+	}
+	if err := client.UpdatePageProperties(context.Background(), "row", map[string]any{"Status": value}); err != nil {
+		t.Fatalf("UpdatePageProperties: %v", err)
+	}
+	props, _ := patched["properties"].(map[string]any)
+	status, _ := props["Status"].(map[string]any)
+	inner, _ := status["status"].(map[string]any)
+	if inner["name"] != "Done" {
+		t.Fatalf("patched = %#v", patched)
+	}
+}
+
+func TestPropertyValue(t *testing.T) {
+	tests := []struct {
+		typ   string
+		value string
+		want  string
+	}{
+		{"select", "High", `{"select":{"name":"High"}}`},
+		{"select", "", `{"select":null}`},
+		{"multi_select", "a, b", `{"multi_select":[{"name":"a"},{"name":"b"}]}`},
+		{"number", "3.5", `{"number":3.5}`},
+		{"checkbox", "true", `{"checkbox":true}`},
+		{"rich_text", "hi", `{"rich_text":[{"text":{"content":"hi"}}]}`},
+		{"url", "https://example.com", `{"url":"https://example.com"}`},
+	}
+	for _, tt := range tests {
+		got, err := PropertyValue("P", DataSourceProperty{Type: tt.typ}, tt.value)
+		if err != nil {
+			t.Fatalf("PropertyValue(%s, %q): %v", tt.typ, tt.value, err)
+		}
+		data, _ := json.Marshal(got)
+		if string(data) != tt.want {
+			t.Errorf("PropertyValue(%s, %q) = %s, want %s", tt.typ, tt.value, data, tt.want)
+		}
+	}
+
+	if _, err := PropertyValue("P", DataSourceProperty{Type: "number"}, "many"); err == nil {
+		t.Error("expected error for invalid number")
+	}
+	if _, err := PropertyValue("P", DataSourceProperty{Type: "relation"}, "x"); err == nil {
+		t.Error("expected error for unsupported type")
+	}
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type propertyItemResponse struct {
+	Object     string            `json:"object"`
+	Results    []json.RawMessage `json:"results"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	HasMore    bool              `json:"has_more"`
+}
+
+// RetrievePagePropertyItems returns every item of a page property. List
+// responses (title, rich_text, relation, people, rollup) are followed through
+// next_cursor and aggregated; any other property is returned as its single
+// property item.
+func (c *Client) RetrievePagePropertyItems(ctx context.Context, pageID, propertyID string) ([]any, error) {
+	pageID = strings.TrimSpace(pageID)
+	propertyID = strings.TrimSpace(propertyID)
+	if pageID == "" {
+		return nil, fmt.Errorf("page ID is required")
+	}
+	if propertyID == "" {
+		return nil, fmt.Errorf("property ID is required")
+	}
+
+	var items []any
+	cursor := ""
+	for {
+		// Property IDs from the API are already URL-encoded.
+		path := "/pages/" + pageID + "/properties/" + propertyID + "?page_size=100"
+		if cursor != "" {
+			path += "&start_cursor=" + url.QueryEscape(cursor)
+		}
+
+		var raw json.RawMessage
+		if err := c.doJSON(ctx, http.MethodGet, path, nil, &raw); err != nil {
+			return nil, err
+		}
+
+		var page propertyItemResponse
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return nil, fmt.Errorf("parse property items: %w", err)
+		}
+		if page.Object != "list" {
+			var item any
+			if err := json.Unmarshal(raw, &item); err != nil {
+				return nil, fmt.Errorf("parse property item: %w", err)
+			}
+			return []any{item}, nil
+		}
+
+		for _, r := range page.Results {
+			var item any
+			if err := json.Unmarshal(r, &item); err != nil {
+				return nil, fmt.Errorf("parse property item: %w", err)
+			}
+			items = append(items, item)
+		}
+		if !page.HasMore || strings.TrimSpace(page.NextCursor) == "" {
+			return items, nil
+		}
+		cursor = page.NextCursor
+	}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/config"
+)
+
+func TestRetrievePagePropertyItemsFollowsCursor(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/pages/page_123/properties/rel" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		calls++
+		switch r.URL.Query().Get("start_cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"object":"list","results":[{"object":"property_item","type":"relation","relation":{"id":"a"}}],"has_more":true,"next_cursor":"cur/2"}`))
+		case "cur/2":
+			_, _ = w.Write([]byte(`{"object":"list","results":[{"object":"property_item","type":"relation","relation":{"id":"b"}}],"has_more":false,"next_cursor":null}`))
+		default:
+			t.Fatalf("unexpected cursor: %q", r.URL.RawQuery)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(config.APIConfig{BaseURL: srv.URL + "/v1"}, "secret-token")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	items, err := client.RetrievePagePropertyItems(context.Background(), "page_123", "rel")
+	if err != nil {
+		t.Fatalf("RetrievePagePropertyItems: %v", err)
+	}
+	if calls != 2 || len(items) != 2 {
+		t.Fatalf("calls = %d, items = %#v", calls, items)
+	}
+	second, ok := items[1].(map[string]any)
+	if !ok || second["relation"].(map[string]any)["id"] != "b" {
+		t.Fatalf("unexpected second item: %#v", items[1])
+	}
+}
+
+func TestRetrievePagePropertyItemsReturnsSingleItem(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"object":"property_item","type":"number","number":3}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(config.APIConfig{BaseURL: srv.URL}, "secret-token")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	items, err := client.RetrievePagePropertyItems(context.Background(), "page_123", "num")
+	if err != nil {
+		t.Fatalf("RetrievePagePropertyItems: %v", err)
+	}
+	if len(items) != 1 || items[0].(map[string]any)["number"] != float64(3) {
+		t.Fatalf("unexpected items: %#v", items)
+	}
+}
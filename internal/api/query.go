@@ -0,0 +1,237 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type DataSource struct {
+	Object     string                        `json:"object"`
+	ID         string                        `json:"id"`
+	Properties map[string]DataSourceProperty `json:"properties"`
+}
+
+type DataSourceProperty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type QueriedPage struct {
+	Object         string                  `json:"object"`
+	ID             string                  `json:"id"`
+	URL            string                  `json:"url"`
+	CreatedTime    time.Time               `json:"created_time"`
+	LastEditedTime time.Time               `json:"last_edited_time"`
+	Properties     map[string]PageProperty `json:"properties,omitempty"`
+
+	// DataSourceTitle is set instead of a title property when a search
+	// result is a data source.
+	DataSourceTitle []RichText `json:"title,omitempty"`
+}
+
+// PageProperty is a page property value. Only the field named by Type is
+// set; types without a field here (rollups, files, and the like) carry
+// just their type. HasMore is set on a relation that lists only its first
+// related pages; RetrievePagePropertyItems reads the rest by ID.
+type PageProperty struct {
+	ID             string         `json:"id,omitempty"`
+	Type           string         `json:"type"`
+	Title          []RichText     `json:"title,omitempty"`
+	RichText       []RichText     `json:"rich_text,omitempty"`
+	Number         *float64       `json:"number,omitempty"`
+	Select         *SelectOption  `json:"select,omitempty"`
+	Status         *SelectOption  `json:"status,omitempty"`
+	MultiSelect    []SelectOption `json:"multi_select,omitempty"`
+	Date           *DateValue     `json:"date,omitempty"`
+	Checkbox       bool           `json:"checkbox,omitempty"`
+	URL            string         `json:"url,omitempty"`
+	Email          string         `json:"email,omitempty"`
+	PhoneNumber    string         `json:"phone_number,omitempty"`
+	People         []User         `json:"people,omitempty"`
+	Relation       []Relation     `json:"relation,omitempty"`
+	Formula        *FormulaValue  `json:"formula,omitempty"`
+	CreatedTime    string         `json:"created_time,omitempty"`
+	LastEditedTime string         `json:"last_edited_time,omitempty"`
+	UniqueID       *UniqueIDValue `json:"unique_id,omitempty"`
+	HasMore        bool           `json:"has_more,omitempty"`
+}
+
+type SelectOption struct {
+	Name string `json:"name"`
+}
+
+type DateValue struct {
+	Start string `json:"start"`
+	End   string `json:"end,omitempty"`
+}
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+type Relation struct {
+	ID string `json:"id"`
+}
+
+type FormulaValue struct {
+	Type    string     `json:"type"`
+	String  string     `json:"string,omitempty"`
+	Number  *float64   `json:"number,omitempty"`
+	Boolean bool       `json:"boolean,omitempty"`
+	Date    *DateValue `json:"date,omitempty"`
+}
+
+type UniqueIDValue struct {
+	Prefix string `json:"prefix,omitempty"`
+	Number int    `json:"number"`
+}
+
+// PlainText returns the text of a title or rich_text property.
+func (p PageProperty) PlainText() string {
+	if p.Type == "title" {
+		return plainText(p.Title)
+	}
+	return plainText(p.RichText)
+}
+
+// Markdown returns the text of a title or rich_text property with its
+// annotations as markdown; see RichTextMarkdown.
+func (p PageProperty) Markdown() string {
+	if p.Type == "title" {
+		return RichTextMarkdown(p.Title)
+	}
+	return RichTextMarkdown(p.RichText)
+}
+
+// Title returns the plain text of the page's title property, or of the
+// data source title for data source results.
+func (p QueriedPage) Title() string {
+	for _, prop := range p.Properties {
+		if prop.Type == "title" {
+			return plainText(prop.Title)
+		}
+	}
+	return plainText(p.DataSourceTitle)
+}
+
+func plainText(parts []RichText) string {
+	var b strings.Builder
+	for _, part := range parts {
+		b.WriteString(part.PlainText)
+	}
+	return b.String()
+}
+
+type listPagesResponse struct {
+	Results    []QueriedPage `json:"results"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+	HasMore    bool          `json:"has_more"`
+}
+
+func (c *Client) GetDataSource(ctx context.Context, dataSourceID string) (*DataSource, error) {
+	dataSourceID = strings.TrimSpace(dataSourceID)
+	if dataSourceID == "" {
+		return nil, fmt.Errorf("data source ID is required")
+	}
+
+	var out DataSource
+	if err := c.doJSON(ctx, http.MethodGet, "/data_sources/"+dataSourceID, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// QueryDataSource returns the pages in a data source matching filter,
+// following pagination until limit pages are collected. A nil filter
+// matches all pages and a limit of 0 returns every match.
+func (c *Client) QueryDataSource(ctx context.Context, dataSourceID string, filter map[string]any, limit int) ([]QueriedPage, error) {
+	dataSourceID = strings.TrimSpace(dataSourceID)
+	if dataSourceID == "" {
+		return nil, fmt.Errorf("data source ID is required")
+	}
+
+	payload := map[string]any{}
+	if filter != nil {
+		payload["filter"] = filter
+	}
+	return c.collectPages(ctx, "/data_sources/"+dataSourceID+"/query", payload, limit)
+}
+
+// Search returns pages and data sources shared with the integration whose
+// title matches query, following pagination until limit results are
+// collected (0 for all). Unlike MCP search, results carry timestamps.
+func (c *Client) Search(ctx context.Context, query string, limit int) ([]QueriedPage, error) {
+	payload := map[string]any{}
+	if strings.TrimSpace(query) != "" {
+		payload["query"] = query
+	}
+	return c.collectPages(ctx, "/search", payload, limit)
+}
+
+// collectPages POSTs payload to a paginated list endpoint, adding page_size
+// and start_cursor, until limit results are collected (0 for all).
+func (c *Client) collectPages(ctx context.Context, path string, payload map[string]any, limit int) ([]QueriedPage, error) {
+	var all []QueriedPage
+	cursor := ""
+	for {
+		pageSize := 100
+		if limit > 0 && limit-len(all) < pageSize {
+			pageSize = limit - len(all)
+		}
+		payload["page_size"] = pageSize
+		delete(payload, "start_cursor")
+		if cursor != "" {
+			payload["start_cursor"] = cursor
+		}
+
+		var out listPagesResponse
+		if err := c.doJSON(ctx, http.MethodPost, path, payload, &out); err != nil {
+			return nil, err
+		}
+		all = append(all, out.Results...)
+		if limit > 0 && len(all) >= limit {
+			return all[:limit], nil
+		}
+		if !out.HasMore || strings.TrimSpace(out.NextCursor) == "" {
+			return all, nil
+		}
+		cursor = out.NextCursor
+	}
+}
+
+// PropertyEqualsFilter builds a query filter matching pages whose property
+// equals value, converting value to the property's type.
+func PropertyEqualsFilter(name string, prop DataSourceProperty, value string) (map[string]any, error) {
+	var condition any
+	switch prop.Type {
+	case "title", "rich_text", "url", "email", "phone_number", "select", "status":
+		condition = map[string]any{"equals": value}
+	case "multi_select":
+		condition = map[string]any{"contains": value}
+	case "number":
+		n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("property %q is a number, got %q", name, value)
+		}
+		condition = map[string]any{"equals": n}
+	case "checkbox":
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("property %q is a checkbox, got %q", name, value)
+		}
+		condition = map[string]any{"equals": b}
+	default:
+		return nil, fmt.Errorf("property %q has unsupported type %q for matching", name, prop.Type)
+	}
+
+	return map[string]any{
+		"property": name,
+		prop.Type:  condition,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/config"
+)
+
+func TestQueryDataSourcePaginatesWithFilter(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/data_sources/ds_123/query" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		defer func() { _ = r.Body.Close() }()
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		filter, ok := payload["filter"].(map[string]any)
+		if !ok || filter["property"] != "Slug" {
+			t.Fatalf("filter = %#v", payload["filter"])
+		}
+		calls++
+		if calls == 1 {
+			if _, ok := payload["start_cursor"]; ok {
+				t.Fatalf("unexpected start_cursor on first call: %#v", payload)
+			}
+			_, _ = w.Write([]byte(`{"results":[{"object":"page","id":"one","url":"https://www.notion.so/one"}],"has_more":true,"next_cursor":"next"}`))
+			return
+		}
+		if payload["start_cursor"] != "next" {
+			t.Fatalf("start_cursor = %#v", payload["start_cursor"])
+		}
+		_, _ = w.Write([]byte(`{"results":[{"object":"page","id":"two"}],"has_more":false}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(config.APIConfig{BaseURL: srv.URL + "/v1"}, "secret-token")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	filter, err := PropertyEqualsFilter("Slug", DataSourceProperty{Type: "rich_text"}, "intro")
+	if err != nil {
+		t.Fatalf("PropertyEqualsFilter: %v", err)
+	}
+	pages, err := client.QueryDataSource(context.Background(), "ds_123", filter, 0)
+	if err != nil {
+		t.Fatalf("QueryDataSource: %v", err)
+	}
+	if len(pages) != 2 || pages[0].ID != "one" || pages[1].ID != "two" {
+		t.Fatalf("unexpected pages: %#v", pages)
+	}
+}
+
+func TestPropertyEqualsFilterConvertsTypes(t *testing.T) {
+	filter, err := PropertyEqualsFilter("Count", DataSourceProperty{Type: "number"}, "3")
+	if err != nil {
+		t.Fatalf("PropertyEqualsFilter: %v", err)
+	}
+	cond, ok := filter["number"].(map[string]any)
+	if !ok || cond["equals"] != float64(3) {
+		t.Fatalf("unexpected filter: %#v", filter)
+	}
+
+	if _, err := PropertyEqualsFilter("Count", DataSourceProperty{Type: "number"}, "three"); err == nil {
+		t.Fatal("expected error for non-numeric value")
+	}
+	if _, err := PropertyEqualsFilter("When", DataSourceProperty{Type: "date"}, "2026-01-01"); err == nil {
+		t.Fatal("expected error for unsupported type")
+	}
+}
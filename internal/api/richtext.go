@@ -0,0 +1,99 @@
+package api
+
+import "strings"
+
+// Annotations are the styles Notion applies to a span of rich text.
+type Annotations struct {
+	Bold          bool   `json:"bold"`
+	Italic        bool   `json:"italic"`
+	Strikethrough bool   `json:"strikethrough"`
+	Underline     bool   `json:"underline"`
+	Code          bool   `json:"code"`
+	Color         string `json:"color,omitempty"`
+}
+
+// RichTextMarkdown renders rich text as markdown. Bold, italic,
+// strikethrough, and code become **, *, ~~, and backticks, links become
+// [text](url), and equations $expression$. Underline and color have no
+// markdown form and are dropped. Adjacent spans with the same styles are
+// merged so markers are not repeated mid-word.
+func RichTextMarkdown(parts []RichText) string {
+	var b strings.Builder
+	for i := 0; i < len(parts); {
+		span := parts[i]
+		text := span.PlainText
+		j := i + 1
+		for ; j < len(parts) && sameMarkdownStyle(span, parts[j]); j++ {
+			text += parts[j].PlainText
+		}
+		b.WriteString(spanMarkdown(span, text))
+		i = j
+	}
+	return b.String()
+}
+
+func sameMarkdownStyle(a, b RichText) bool {
+	if a.Type == "equation" || b.Type == "equation" || a.Href != b.Href {
+		return false
+	}
+	return markdownStyle(a.Annotations) == markdownStyle(b.Annotations)
+}
+
+// markdownStyle keeps only the annotations markdown can express.
+func markdownStyle(a *Annotations) Annotations {
+	if a == nil {
+		return Annotations{}
+	}
+	return Annotations{Bold: a.Bold, Italic: a.Italic, Strikethrough: a.Strikethrough, Code: a.Code}
+}
+
+// spanMarkdown wraps text in the markers for span's styles. Surrounding
+// whitespace stays outside the markers, where markdown needs it.
+func spanMarkdown(span RichText, text string) string {
+	if span.Type == "equation" {
+		return "$" + text + "$"
+	}
+	core := strings.TrimSpace(text)
+	if core == "" {
+		return text
+	}
+	lead := text[:strings.Index(text, core)]
+	trail := text[len(lead)+len(core):]
+
+	if a := span.Annotations; a != nil {
+		if a.Code {
+			core = codeSpan(core)
+		}
+		if a.Strikethrough {
+			core = "~~" + core + "~~"
+		}
+		if a.Italic {
+			core = "*" + core + "*"
+		}
+		if a.Bold {
+			core = "**" + core + "**"
+		}
+	}
+	if span.Href != "" {
+		core = "[" + core + "](" + span.Href + ")"
+	}
+	return lead + core + trail
+}
+
+// codeSpan wraps text in enough backticks that any it contains are literal.
+func codeSpan(text string) string {
+	longest, run := 0, 0
+	for _, r := range text {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", longest+1)
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		text = " " + text + " "
+	}
+	return fence + text + fence
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRichTextMarkdownAnnotations(t *testing.T) {
+	tests := []struct {
+		name string
+		span RichText
+		want string
+	}{
+		{"plain", RichText{PlainText: "text"}, "text"},
+		{"bold", RichText{PlainText: "text", Annotations: &Annotations{Bold: true}}, "**text**"},
+		{"italic", RichText{PlainText: "text", Annotations: &Annotations{Italic: true}}, "*text*"},
+		{"strikethrough", RichText{PlainText: "text", Annotations: &Annotations{Strikethrough: true}}, "~~text~~"},
+		{"code", RichText{PlainText: "x := 1", Annotations: &Annotations{Code: true}}, "`x := 1`"},
+		{"code with backticks", RichText{PlainText: "`a`", Annotations: &Annotations{Code: true}}, "`` `a` ``"},
+		{"bold italic", RichText{PlainText: "text", Annotations: &Annotations{Bold: true, Italic: true}}, "***text***"},
+		{"underline and color dropped", RichText{PlainText: "text", Annotations: &Annotations{Underline: true, Color: "red"}}, "text"},
+		{"whitespace outside markers", RichText{PlainText: " text ", Annotations: &Annotations{Bold: true}}, " **text** "},
+		{"link", RichText{PlainText: "docs", Href: "https://example.com", Annotations: &Annotations{Bold: true}}, "[**docs**](https://example.com)"},
+		{"equation", RichText{Type: "equation", PlainText: "e=mc^2"}, "$e=mc^2$"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RichTextMarkdown([]RichText{tt.span}); got != tt.want {
+				t.Fatalf("RichTextMarkdown = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRichTextMarkdownMergesAdjacentSpans(t *testing.T) {
+	var parts []RichText
+	if err := json.Unmarshal([]byte(`[
+		{"type":"text","plain_text":"Ship ","annotations":{"bold":false,"color":"default"}},
+		{"type":"text","plain_text":"fa","annotations":{"bold":true,"color":"default"}},
+		{"type":"text","plain_text":"st","annotations":{"bold":true,"color":"red"}},
+		{"type":"text","plain_text":" today"}
+	]`), &parts); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got, want := RichTextMarkdown(parts), "Ship **fast** today"; got != want {
+		t.Fatalf("RichTextMarkdown = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultVerifyTimeout bounds each VerifyToken attempt.
+const DefaultVerifyTimeout = 10 * time.Second
+
+var (
+	// ErrInvalidToken means Notion rejected the token (401).
+	ErrInvalidToken = errors.New("official API token is invalid")
+	// ErrUnreachable means Notion could not be reached, or kept failing,
+	// on every verification attempt.
+	ErrUnreachable = errors.New("couldn't reach Notion")
+)
+
+var (
+	verifyAttempts   = 3
+	verifyRetryDelay = time.Second
+)
+
+// Verification is the result of a successful VerifyToken.
+type Verification struct {
+	Self *Self
+	// Latency is the round trip of the attempt that succeeded.
+	Latency  time.Duration
+	Attempts int
+	// RequestedVersion is the Notion-Version sent; ServerVersion is the one
+	// the response echoed back, or "" when it did not include one.
+	RequestedVersion string
+	ServerVersion    string
+}
+
+// VerifyToken checks the token against /users/me. Each attempt is bounded
+// by timeout (DefaultVerifyTimeout when zero or less). Network errors,
+// timeouts, 429s, and 5xx responses are retried a few times with a short
+// backoff; other responses are final. The error wraps ErrInvalidToken for
+// a 401 and ErrUnreachable when every attempt failed to get an answer.
+func (c *Client) VerifyToken(ctx context.Context, timeout time.Duration) (*Verification, error) {
+	if timeout <= 0 {
+		timeout = DefaultVerifyTimeout
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= verifyAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("%w: %w", ErrUnreachable, ctx.Err())
+			case <-time.After(verifyRetryDelay * time.Duration(attempt-1)):
+			}
+		}
+
+		var self Self
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+		start := time.Now()
+		header, err := c.doRequestHeader(attemptCtx, http.MethodGet, "/users/me", nil, "", &self)
+		latency := time.Since(start)
+		cancel()
+		if err == nil {
+			return &Verification{
+				Self:             &self,
+				Latency:          latency,
+				Attempts:         attempt,
+				RequestedVersion: c.notionVersion,
+				ServerVersion:    header.Get("Notion-Version"),
+			}, nil
+		}
+
+		var apiErr *Error
+		if errors.As(err, &apiErr) {
+			switch {
+			case apiErr.StatusCode == http.StatusUnauthorized:
+				return nil, fmt.Errorf("%w: %s", ErrInvalidToken, apiErr.Message)
+			case apiErr.StatusCode != http.StatusTooManyRequests && apiErr.StatusCode < 500:
+				return nil, err
+			}
+		}
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: %w", ErrUnreachable, ctx.Err())
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("%w at %s after %d attempts: %w", ErrUnreachable, c.baseURL, verifyAttempts, lastErr)
+}
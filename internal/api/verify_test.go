@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lox/notion-cli/internal/config"
+)
+
+func newVerifyTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *httptest.Server) {
+	t.Helper()
+	oldDelay := verifyRetryDelay
+	verifyRetryDelay = time.Millisecond
+	t.Cleanup(func() { verifyRetryDelay = oldDelay })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	client, err := NewClient(config.APIConfig{BaseURL: srv.URL + "/v1"}, "secret-token")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client, srv
+}
+
+func TestVerifyTokenRetriesServerErrors(t *testing.T) {
+	calls := 0
+	client, _ := newVerifyTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"object":"user","id":"user_123","type":"bot"}`))
+	})
+
+	v, err := client.VerifyToken(context.Background(), time.Second)
+	if err != nil {
+		t.Fatalf("VerifyToken: %v", err)
+	}
+	if v.Self.ID != "user_123" || v.Attempts != 3 || calls != 3 {
+		t.Fatalf("verification = %#v after %d calls", v, calls)
+	}
+}
+
+func TestVerifyTokenDoesNotRetryUnauthorized(t *testing.T) {
+	calls := 0
+	client, _ := newVerifyTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"object":"error","status":401,"message":"API token is invalid."}`))
+	})
+
+	_, err := client.VerifyToken(context.Background(), time.Second)
+	if !errors.Is(err, ErrInvalidToken) || errors.Is(err, ErrUnreachable) {
+		t.Fatalf("err = %v, want ErrInvalidToken", err)
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1", calls)
+	}
+}
+
+func TestVerifyTokenReportsUnreachable(t *testing.T) {
+	client, srv := newVerifyTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	srv.Close()
+
+	_, err := client.VerifyToken(context.Background(), time.Second)
+	if !errors.Is(err, ErrUnreachable) || errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("err = %v, want ErrUnreachable", err)
+	}
+}
+
+func TestVerifyTokenTimesOutEachAttempt(t *testing.T) {
+	calls := 0
+	client, _ := newVerifyTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			<-r.Context().Done()
+			return
+		}
+		_, _ = w.Write([]byte(`{"object":"user","id":"user_123","type":"bot"}`))
+	})
+
+	if _, err := client.VerifyToken(context.Background(), 50*time.Millisecond); err != nil {
+		t.Fatalf("VerifyToken: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+}
+
+func TestVerifyTokenReportsLatencyAndVersion(t *testing.T) {
+	client, _ := newVerifyTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Notion-Version", r.Header.Get("Notion-Version"))
+		time.Sleep(5 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"object":"user","id":"user_123","type":"bot","name":"My Integration"}`))
+	})
+
+	v, err := client.VerifyToken(context.Background(), time.Second)
+	if err != nil {
+		t.Fatalf("VerifyToken: %v", err)
+	}
+	if v.Latency < 5*time.Millisecond || v.Self.Name != "My Integration" {
+		t.Fatalf("verification = %#v", v)
+	}
+	if v.RequestedVersion != "2026-03-11" || v.ServerVersion != "2026-03-11" {
+		t.Fatalf("versions = %q, %q", v.RequestedVersion, v.ServerVersion)
+	}
+}
@@ -0,0 +1,42 @@
+package api
+
+import "strings"
+
+// Feature is an official API capability that needs a minimum Notion-Version.
+type Feature struct {
+	Name       string
+	MinVersion string
+}
+
+// FeatureDataSources covers the /data_sources endpoints used for database
+// queries, which replaced database queries in 2025-09-03.
+var FeatureDataSources = Feature{Name: "data source queries", MinVersion: "2025-09-03"}
+
+// VersionOlderThan reports whether the Notion-Version date version is older
+// than min. Versions are YYYY-MM-DD dates, so they compare as strings;
+// anything that is not in that form is not considered older.
+func VersionOlderThan(version, min string) bool {
+	version = strings.TrimSpace(version)
+	if !isDateVersion(version) || !isDateVersion(min) {
+		return false
+	}
+	return version < min
+}
+
+func isDateVersion(v string) bool {
+	if len(v) != len("2006-01-02") {
+		return false
+	}
+	for i, r := range v {
+		if i == 4 || i == 7 {
+			if r != '-' {
+				return false
+			}
+			continue
+		}
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
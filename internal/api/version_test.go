@@ -0,0 +1,20 @@
+package api
+
+import "testing"
+
+func TestVersionOlderThan(t *testing.T) {
+	tests := []struct {
+		version, min string
+		want         bool
+	}{
+		{"2022-06-28", "2025-09-03", true},
+		{"2025-09-03", "2025-09-03", false},
+		{"2026-03-11", "2025-09-03", false},
+		{"latest", "2025-09-03", false},
+	}
+	for _, tt := range tests {
+		if got := VersionOlderThan(tt.version, tt.min); got != tt.want {
+			t.Fatalf("VersionOlderThan(%q, %q) = %v, want %v", tt.version, tt.min, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BatchResult accumulates the per-item outcome of a bulk command so every
+// batch reports the same "N succeeded, M failed" summary and exit status.
+type BatchResult struct {
+	Succeeded int
+	Failures  []BatchFailure
+}
+
+// BatchFailure is one item of a batch that failed.
+type BatchFailure struct {
+	Item string
+	Err  error
+}
+
+func (b *BatchResult) Success() {
+	b.Succeeded++
+}
+
+func (b *BatchResult) Fail(item string, err error) {
+	b.Failures = append(b.Failures, BatchFailure{Item: item, Err: err})
+}
+
+func (b *BatchResult) Failed() int {
+	return len(b.Failures)
+}
+
+// Summary returns a one-line count such as "12 succeeded, 2 failed".
+func (b *BatchResult) Summary() string {
+	return fmt.Sprintf("%d succeeded, %d failed", b.Succeeded, b.Failed())
+}
+
+// Err returns an error describing the failures, or nil when every item
+// succeeded or ignoreFailures is set.
+func (b *BatchResult) Err(ignoreFailures bool) error {
+	if b.Failed() == 0 || ignoreFailures {
+		return nil
+	}
+	items := make([]string, 0, len(b.Failures))
+	for _, f := range b.Failures {
+		items = append(items, f.Item)
+	}
+	return fmt.Errorf("batch incomplete (%s): %s", b.Summary(), strings.Join(items, ", "))
+}
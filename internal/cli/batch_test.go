@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBatchResultSummaryAndErr(t *testing.T) {
+	var b BatchResult
+	b.Success()
+	b.Success()
+	if err := b.Err(false); err != nil {
+		t.Fatalf("Err with no failures = %v", err)
+	}
+
+	b.Fail("notes.md#intro", errors.New("boom"))
+	if got := b.Summary(); got != "2 succeeded, 1 failed" {
+		t.Fatalf("Summary = %q", got)
+	}
+	err := b.Err(false)
+	if err == nil || !strings.Contains(err.Error(), "2 succeeded, 1 failed") || !strings.Contains(err.Error(), "notes.md#intro") {
+		t.Fatalf("Err = %v", err)
+	}
+	if err := b.Err(true); err != nil {
+		t.Fatalf("Err with ignoreFailures = %v", err)
+	}
+}
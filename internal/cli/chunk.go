@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ChunkMarkdown splits markdown into chunks of at most limit bytes so each
+// can be sent in its own request. Chunks break between blocks, at blank
+// lines outside fenced code. A block longer than limit is broken between
+// lines, closing and reopening a code fence around each break, and a line
+// longer than limit is broken at the last space that fits. Markdown within
+// limit, or a limit of zero or less, is returned as a single chunk.
+func ChunkMarkdown(markdown string, limit int) []string {
+	if limit <= 0 || len(markdown) <= limit {
+		return []string{markdown}
+	}
+
+	var chunks []string
+	var current strings.Builder
+	add := func(piece string) {
+		if current.Len() > 0 && current.Len()+2+len(piece) > limit {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		if current.Len() > 0 {
+			current.WriteString("\n\n")
+		}
+		current.WriteString(piece)
+	}
+	for _, block := range markdownBlocks(markdown) {
+		for _, piece := range splitMarkdownBlock(block, limit) {
+			add(piece)
+		}
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
+
+// markdownBlocks splits markdown at blank lines outside fenced code.
+func markdownBlocks(markdown string) []string {
+	var blocks []string
+	var current []string
+	inFence := false
+
+	flush := func() {
+		block := strings.Trim(strings.Join(current, "\n"), "\n")
+		if strings.TrimSpace(block) != "" {
+			blocks = append(blocks, block)
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if isFenceLine(trimmed) {
+			inFence = !inFence
+		}
+		if !inFence && trimmed == "" {
+			flush()
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+	return blocks
+}
+
+func isFenceLine(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+// splitMarkdownBlock breaks a block longer than limit into pieces that fit.
+func splitMarkdownBlock(block string, limit int) []string {
+	if len(block) <= limit {
+		return []string{block}
+	}
+
+	lines := strings.Split(block, "\n")
+	if len(lines) >= 2 && isFenceLine(strings.TrimSpace(lines[0])) && isFenceLine(strings.TrimSpace(lines[len(lines)-1])) {
+		open, closing := lines[0], lines[len(lines)-1]
+		budget := max(limit-len(open)-len(closing)-2, 1)
+		var pieces []string
+		for _, body := range packLines(lines[1:len(lines)-1], budget) {
+			pieces = append(pieces, open+"\n"+body+"\n"+closing)
+		}
+		return pieces
+	}
+	return packLines(lines, limit)
+}
+
+// packLines joins lines greedily into pieces of at most limit bytes.
+func packLines(lines []string, limit int) []string {
+	var pieces []string
+	var current strings.Builder
+	for _, line := range lines {
+		for _, part := range splitLongLine(line, limit) {
+			if current.Len() > 0 && current.Len()+1+len(part) > limit {
+				pieces = append(pieces, current.String())
+				current.Reset()
+			}
+			if current.Len() > 0 {
+				current.WriteByte('\n')
+			}
+			current.WriteString(part)
+		}
+	}
+	if current.Len() > 0 {
+		pieces = append(pieces, current.String())
+	}
+	return pieces
+}
+
+// splitLongLine breaks line at spaces (or, failing that, at a rune
+// boundary) into parts of at most limit bytes.
+func splitLongLine(line string, limit int) []string {
+	var parts []string
+	for len(line) > limit {
+		cut := strings.LastIndexByte(line[:limit+1], ' ')
+		if cut <= 0 {
+			cut = limit
+			for cut > 0 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			if cut == 0 {
+				cut = limit
+			}
+		}
+		parts = append(parts, strings.TrimRight(line[:cut], " "))
+		line = strings.TrimLeft(line[cut:], " ")
+	}
+	return append(parts, line)
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChunkMarkdownWithinLimit(t *testing.T) {
+	md := "# Title\n\nBody"
+	if got := ChunkMarkdown(md, 100); len(got) != 1 || got[0] != md {
+		t.Fatalf("ChunkMarkdown = %q", got)
+	}
+	if got := ChunkMarkdown(md, 0); len(got) != 1 || got[0] != md {
+		t.Fatalf("ChunkMarkdown with no limit = %q", got)
+	}
+}
+
+func TestChunkMarkdownBreaksBetweenBlocks(t *testing.T) {
+	md := "# Title\n\nFirst paragraph.\n\nSecond paragraph.\n\nThird paragraph."
+	got := ChunkMarkdown(md, 40)
+	want := []string{"# Title\n\nFirst paragraph.", "Second paragraph.\n\nThird paragraph."}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("ChunkMarkdown = %q, want %q", got, want)
+	}
+}
+
+func TestChunkMarkdownReopensCodeFences(t *testing.T) {
+	md := "```go\nline one\n\nline two\nline three\n```"
+	got := ChunkMarkdown(md, 30)
+	if len(got) < 2 {
+		t.Fatalf("ChunkMarkdown = %q, want several chunks", got)
+	}
+	for _, chunk := range got {
+		if len(chunk) > 30 || !strings.HasPrefix(chunk, "```go\n") || !strings.HasSuffix(chunk, "\n```") {
+			t.Fatalf("chunk %q is not a complete fence within the limit", chunk)
+		}
+	}
+}
+
+func TestChunkMarkdownSplitsLongLines(t *testing.T) {
+	md := strings.Repeat("word ", 20) + "\n\nnext"
+	got := ChunkMarkdown(md, 32)
+	for _, chunk := range got {
+		if len(chunk) > 32 {
+			t.Fatalf("chunk %q exceeds limit", chunk)
+		}
+	}
+	if joined := strings.Join(strings.Fields(strings.Join(got, " ")), " "); joined != strings.TrimSpace(strings.Join(strings.Fields(md), " ")) {
+		t.Fatalf("words changed: %q", joined)
+	}
+
+	runes := strings.Repeat("é", 20)
+	for _, chunk := range ChunkMarkdown(runes, 7) {
+		if !strings.HasPrefix(runes, chunk) && strings.Trim(chunk, "é") != "" {
+			t.Fatalf("chunk %q split a rune", chunk)
+		}
+	}
+}
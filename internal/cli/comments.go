@@ -0,0 +1,70 @@
+package cli
+
+import "strings"
+
+// StripHTMLComments removes <!-- ... --> comments from markdown, including
+// ones spanning several lines. Comments inside fenced code or inline code
+// are kept, and a line left empty by removing a comment is dropped so
+// the comment leaves no stray blank line behind.
+func StripHTMLComments(markdown string) string {
+	lines := strings.Split(markdown, "\n")
+	out := make([]string, 0, len(lines))
+	inFence, inComment := false, false
+	for _, line := range lines {
+		if !inComment && isFenceLine(strings.TrimSpace(line)) {
+			inFence = !inFence
+		}
+		if inFence {
+			out = append(out, line)
+			continue
+		}
+		stripped, removed, open := stripLineComments(line, inComment)
+		inComment = open
+		if removed && strings.TrimSpace(stripped) == "" {
+			continue
+		}
+		out = append(out, stripped)
+	}
+	return strings.Join(out, "\n")
+}
+
+// stripLineComments removes the comments from one line. inComment says
+// whether the line starts inside a comment opened on an earlier line, and
+// open whether it ends inside one.
+func stripLineComments(line string, inComment bool) (stripped string, removed, open bool) {
+	var b strings.Builder
+	removed = inComment
+	for i := 0; i < len(line); {
+		if inComment {
+			end := strings.Index(line[i:], "-->")
+			if end < 0 {
+				return b.String(), true, true
+			}
+			i += end + len("-->")
+			inComment = false
+			continue
+		}
+		if line[i] == '`' {
+			// Copy an inline code span whole, so comments in it survive.
+			ticks := len(line[i:]) - len(strings.TrimLeft(line[i:], "`"))
+			fence := line[i : i+ticks]
+			if end := strings.Index(line[i+ticks:], fence); end >= 0 {
+				span := line[i : i+ticks+end+ticks]
+				b.WriteString(span)
+				i += len(span)
+				continue
+			}
+			b.WriteString(fence)
+			i += ticks
+			continue
+		}
+		if strings.HasPrefix(line[i:], "<!--") {
+			i += len("<!--")
+			inComment, removed = true, true
+			continue
+		}
+		b.WriteByte(line[i])
+		i++
+	}
+	return b.String(), removed, inComment
+}
@@ -0,0 +1,28 @@
+package cli
+
+import "testing"
+
+func TestStripHTMLComments(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"inline", "Ship it <!-- after review --> today", "Ship it  today"},
+		{"own line", "# Title\n<!-- TODO: add numbers -->\nBody", "# Title\nBody"},
+		{"multi line", "Intro\n<!--\nnotes\nmore notes\n-->\nOutro", "Intro\nOutro"},
+		{"spanning text", "Keep <!-- drop\nstill drop --> this", "Keep \n this"},
+		{"several", "a<!--1-->b<!--2-->c", "abc"},
+		{"fenced code", "```html\n<!-- shown -->\n```\n<!-- hidden -->", "```html\n<!-- shown -->\n```"},
+		{"inline code", "Write `<!-- x -->` to comment", "Write `<!-- x -->` to comment"},
+		{"blank lines kept", "One\n\n<!-- note -->\n\nTwo", "One\n\n\nTwo"},
+		{"unterminated", "Text\n<!-- never closed\nrest", "Text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripHTMLComments(tt.in); got != tt.want {
+				t.Fatalf("StripHTMLComments(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
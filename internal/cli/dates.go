@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateValue is a Notion date property value: a start date or datetime and
+// an optional end for ranges.
+type DateValue struct {
+	Start      string
+	End        string
+	IsDatetime bool
+}
+
+var isoDatetimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+}
+
+// ParseDateValue parses an ISO 8601 date (2024-03-01), datetime
+// (2024-03-01T09:30 or 2024-03-01T09:30:00Z), or a range of two joined by
+// "..". The values are returned as given once they are known to be valid.
+func ParseDateValue(value string) (DateValue, error) {
+	value = strings.TrimSpace(value)
+	start, end, isRange := strings.Cut(value, "..")
+	start = strings.TrimSpace(start)
+	end = strings.TrimSpace(end)
+
+	startTime, startIsDatetime, err := parseISODate(start)
+	if err != nil {
+		return DateValue{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD, an ISO 8601 datetime, or START..END)", value)
+	}
+	out := DateValue{Start: start, IsDatetime: startIsDatetime}
+	if !isRange {
+		return out, nil
+	}
+
+	endTime, endIsDatetime, err := parseISODate(end)
+	if err != nil {
+		return DateValue{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD, an ISO 8601 datetime, or START..END)", value)
+	}
+	if endTime.Before(startTime) {
+		return DateValue{}, fmt.Errorf("invalid date range %q: end is before start", value)
+	}
+	out.End = end
+	out.IsDatetime = startIsDatetime || endIsDatetime
+	return out, nil
+}
+
+func parseISODate(value string) (time.Time, bool, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, false, nil
+	}
+	for _, layout := range isoDatetimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+}
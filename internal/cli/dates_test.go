@@ -0,0 +1,33 @@
+package cli
+
+import "testing"
+
+func TestParseDateValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want DateValue
+	}{
+		{"2024-03-01", DateValue{Start: "2024-03-01"}},
+		{"2024-03-01T09:30", DateValue{Start: "2024-03-01T09:30", IsDatetime: true}},
+		{"2024-03-01T09:30:00Z", DateValue{Start: "2024-03-01T09:30:00Z", IsDatetime: true}},
+		{"2024-03-01..2024-03-05", DateValue{Start: "2024-03-01", End: "2024-03-05"}},
+		{"2024-03-01 .. 2024-03-01T17:00", DateValue{Start: "2024-03-01", End: "2024-03-01T17:00", IsDatetime: true}},
+	}
+	for _, tt := range tests {
+		got, err := ParseDateValue(tt.in)
+		if err != nil {
+			t.Fatalf("ParseDateValue(%q): %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Fatalf("ParseDateValue(%q) = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseDateValueRejectsInvalid(t *testing.T) {
+	for _, in := range []string{"", "tomorrow", "2024-02-30", "2024-03-01..", "2024-03-05..2024-03-01"} {
+		if _, err := ParseDateValue(in); err == nil {
+			t.Fatalf("ParseDateValue(%q) succeeded, want error", in)
+		}
+	}
+}
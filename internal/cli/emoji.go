@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// StripEmoji removes every emoji from s, treating each grapheme cluster
+// (flags, skin tones, ZWJ families, keycaps) as one unit, and collapses the
+// whitespace left behind. Symbols that are only emoji with a variation
+// selector, such as © or ™, are kept when written as plain text.
+func StripEmoji(s string) string {
+	var b strings.Builder
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		if !isEmojiCluster(g.Runes()) {
+			b.WriteString(g.Str())
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// IsEmoji reports whether s is made up only of emoji. It reads s as
+// grapheme clusters, so flags, skin tones, ZWJ sequences, and keycaps such
+// as 1️⃣ each count as one emoji. An empty string is not an emoji.
+func IsEmoji(s string) bool {
+	if s == "" {
+		return false
+	}
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		if !isEmojiCluster(g.Runes()) {
+			return false
+		}
+	}
+	return true
+}
+
+// LeadingEmoji splits an emoji off the start of s, taking its whole
+// grapheme cluster, and returns it with the rest of s with leading spaces
+// trimmed.
+func LeadingEmoji(s string) (emoji, rest string, ok bool) {
+	g := uniseg.NewGraphemes(s)
+	if !g.Next() || !isEmojiCluster(g.Runes()) {
+		return "", s, false
+	}
+	_, end := g.Positions()
+	return g.Str(), strings.TrimLeft(s[end:], " \t"), true
+}
+
+func isEmojiCluster(runes []rune) bool {
+	for _, r := range runes {
+		switch {
+		case r == 0xFE0F, r == 0x20E3: // emoji presentation, keycap
+			return true
+		case r >= 0x1F000 && r <= 0x1FAFF, // pictographs, flags, symbols
+			r >= 0x2600 && r <= 0x27BF, // misc symbols, dingbats
+			r >= 0x2B00 && r <= 0x2BFF, // arrows and stars such as ⭐
+			r >= 0x231A && r <= 0x23FF: // watches, clocks, media controls
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+package cli
+
+import "testing"
+
+func TestStripEmoji(t *testing.T) {
+	tests := map[string]string{
+		"🚀 Launch plan":              "Launch plan",
+		"Launch 🚀 plan ✅":            "Launch plan",
+		"Team 👩‍💻 sync 👍🏽":           "Team sync",
+		"Trip 🇯🇵 notes":              "Trip notes",
+		"Step 1️⃣ done":              "Step done",
+		"Copyright © 2026 – v2.0 €5": "Copyright © 2026 – v2.0 €5",
+		"Café naïve 日本語":             "Café naïve 日本語",
+		"⭐⭐⭐":                        "",
+	}
+	for in, want := range tests {
+		if got := StripEmoji(in); got != want {
+			t.Fatalf("StripEmoji(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
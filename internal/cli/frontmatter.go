@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -8,6 +10,25 @@ const frontmatterDelimiter = "---"
 
 type Frontmatter struct {
 	NotionID string
+	// NotionIDs maps section keys to page IDs for files synced with
+	// --split-on, stored as an indented notion-ids block.
+	NotionIDs map[string]string
+	// NotionTargets lists the pages a file is mirrored to, written as a
+	// notion-targets list ("[id1, id2]" or "- id" lines). A notion-ids key
+	// holding a list instead of a section map is read the same way. page
+	// sync writes the same content to each of them.
+	NotionTargets []string
+	// Properties holds the remaining top-level scalar keys, which page sync
+	// --properties-only pushes as Notion page properties.
+	Properties map[string]string
+	// AppendOnly is set by "append-only: true" and stops page sync from
+	// replacing the content of the synced page.
+	AppendOnly bool
+	// Parent and ParentDB come from the notion-parent and notion-parent-db
+	// keys and name where page upload and page sync create the page. The
+	// prefix leaves parent and parent-db free for database properties.
+	Parent   string
+	ParentDB string
 }
 
 // ParseFrontmatter extracts frontmatter and body from a markdown string.
@@ -41,28 +62,96 @@ func ParseFrontmatter(content string) (Frontmatter, string) {
 	body := strings.TrimLeft(afterClose, "\r\n")
 
 	fm := Frontmatter{}
+	// block is the top-level key whose indented lines are being read.
+	block := ""
 	for _, line := range strings.Split(fmBlock, "\n") {
 		trimLine := strings.TrimRight(line, " \t\r")
 		if trimLine == "" || strings.HasPrefix(trimLine, "#") {
 			continue
 		}
 		if strings.HasPrefix(trimLine, " ") || strings.HasPrefix(trimLine, "\t") {
+			if block != "notion-targets" && block != "notion-ids" {
+				continue
+			}
+			if item, ok := strings.CutPrefix(strings.TrimSpace(trimLine), "- "); ok {
+				if id := unquoteFrontmatterValue(strings.TrimSpace(item)); id != "" {
+					fm.NotionTargets = append(fm.NotionTargets, id)
+				}
+				continue
+			}
+			if block != "notion-ids" {
+				continue
+			}
+			k, v, ok := strings.Cut(strings.TrimSpace(trimLine), ":")
+			if !ok || strings.TrimSpace(v) == "" {
+				continue
+			}
+			if fm.NotionIDs == nil {
+				fm.NotionIDs = make(map[string]string)
+			}
+			fm.NotionIDs[strings.TrimSpace(k)] = strings.TrimSpace(v)
 			continue
 		}
+		block = ""
 		k, v, ok := strings.Cut(trimLine, ":")
 		if !ok {
 			continue
 		}
 		k = strings.TrimSpace(k)
 		v = strings.TrimSpace(v)
-		if k == "notion-id" {
+		switch k {
+		case "notion-id":
 			fm.NotionID = v
+		case "notion-ids", "notion-targets":
+			block = k
+			fm.NotionTargets = append(fm.NotionTargets, parseFrontmatterList(v)...)
+		case "append-only":
+			fm.AppendOnly, _ = strconv.ParseBool(unquoteFrontmatterValue(v))
+		case "notion-parent":
+			fm.Parent = unquoteFrontmatterValue(v)
+		case "notion-parent-db":
+			fm.ParentDB = unquoteFrontmatterValue(v)
+		default:
+			if k == "" || v == "" {
+				continue
+			}
+			if fm.Properties == nil {
+				fm.Properties = make(map[string]string)
+			}
+			fm.Properties[k] = unquoteFrontmatterValue(v)
 		}
 	}
 
 	return fm, body
 }
 
+// parseFrontmatterList parses an inline "[a, b]" list, returning nil for
+// anything else.
+func parseFrontmatterList(v string) []string {
+	inner, ok := strings.CutPrefix(v, "[")
+	if !ok {
+		return nil
+	}
+	inner, ok = strings.CutSuffix(inner, "]")
+	if !ok {
+		return nil
+	}
+	var items []string
+	for _, item := range strings.Split(inner, ",") {
+		if item = unquoteFrontmatterValue(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func unquoteFrontmatterValue(v string) string {
+	if len(v) >= 2 && (v[0] == '"' && v[len(v)-1] == '"' || v[0] == '\'' && v[len(v)-1] == '\'') {
+		return v[1 : len(v)-1]
+	}
+	return v
+}
+
 // SetFrontmatterID returns the content with notion-id set in frontmatter.
 // If frontmatter already exists, it updates or adds the notion-id field.
 // If no frontmatter exists, it prepends a new frontmatter block.
@@ -96,6 +185,52 @@ func SetFrontmatterID(content string, notionID string) string {
 	return ensureTrailingNewline(frontmatterDelimiter+"\n"+strings.Join(newLines, "\n")+"\n"+frontmatterDelimiter+"\n\n"+body, hasTrailingNewline)
 }
 
+// SetFrontmatterIDs returns the content with a notion-ids block mapping
+// section keys to page IDs, replacing any existing block. Keys are written
+// in sorted order so repeated syncs produce stable output.
+func SetFrontmatterIDs(content string, ids map[string]string) string {
+	hasTrailingNewline := strings.HasSuffix(content, "\n")
+	_, body := ParseFrontmatter(content)
+
+	keys := make([]string, 0, len(ids))
+	for k := range ids {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	idLines := []string{"notion-ids:"}
+	for _, k := range keys {
+		idLines = append(idLines, "  "+k+": "+ids[k])
+	}
+
+	var newLines []string
+	inIDs := false
+	replaced := false
+	if fmBlock := extractFrontmatterBlock(content); fmBlock != "" {
+		for _, line := range strings.Split(fmBlock, "\n") {
+			trimLine := strings.TrimRight(line, " \t\r")
+			isTopLevel := trimLine != "" && !strings.HasPrefix(trimLine, " ") && !strings.HasPrefix(trimLine, "\t")
+			if inIDs && !isTopLevel {
+				continue
+			}
+			inIDs = false
+			if isTopLevel {
+				if k, _, ok := strings.Cut(trimLine, ":"); ok && strings.TrimSpace(k) == "notion-ids" {
+					newLines = append(newLines, idLines...)
+					inIDs = true
+					replaced = true
+					continue
+				}
+			}
+			newLines = append(newLines, line)
+		}
+	}
+	if !replaced {
+		newLines = append(newLines, idLines...)
+	}
+
+	return ensureTrailingNewline(frontmatterDelimiter+"\n"+strings.Join(newLines, "\n")+"\n"+frontmatterDelimiter+"\n\n"+body, hasTrailingNewline)
+}
+
 func ensureTrailingNewline(s string, want bool) string {
 	has := strings.HasSuffix(s, "\n")
 	if want && !has {
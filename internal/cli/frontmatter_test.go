@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -122,3 +123,99 @@ func TestSetFrontmatterID(t *testing.T) {
 		})
 	}
 }
+
+func TestFrontmatterNotionIDsRoundTrip(t *testing.T) {
+	input := "---\ntitle: Notes\nnotion-ids:\n  old: stale\ntags: x\n---\n\n# A\n"
+	updated := SetFrontmatterIDs(input, map[string]string{"b": "id-b", "a": "id-a"})
+
+	want := "---\ntitle: Notes\nnotion-ids:\n  a: id-a\n  b: id-b\ntags: x\n---\n\n# A\n"
+	if updated != want {
+		t.Fatalf("SetFrontmatterIDs =\n%q\nwant\n%q", updated, want)
+	}
+
+	fm, body := ParseFrontmatter(updated)
+	if fm.NotionIDs["a"] != "id-a" || fm.NotionIDs["b"] != "id-b" || len(fm.NotionIDs) != 2 {
+		t.Fatalf("NotionIDs = %v", fm.NotionIDs)
+	}
+	if body != "# A\n" {
+		t.Fatalf("body = %q", body)
+	}
+
+	fresh := SetFrontmatterIDs("# A", map[string]string{"a": "id-a"})
+	if fresh != "---\nnotion-ids:\n  a: id-a\n---\n\n# A" {
+		t.Fatalf("SetFrontmatterIDs without frontmatter = %q", fresh)
+	}
+}
+
+func TestParseFrontmatterProperties(t *testing.T) {
+	input := "---\nnotion-id: abc\nStatus: \"In progress\"\nPriority: 2\ntags:\n  - a\nnotion-ids:\n  s: id\n---\n\nBody"
+
+	fm, _ := ParseFrontmatter(input)
+	want := map[string]string{"Status": "In progress", "Priority": "2"}
+	if len(fm.Properties) != len(want) {
+		t.Fatalf("Properties = %v, want %v", fm.Properties, want)
+	}
+	for k, v := range want {
+		if fm.Properties[k] != v {
+			t.Fatalf("Properties[%q] = %q, want %q", k, fm.Properties[k], v)
+		}
+	}
+}
+
+func TestParseFrontmatterAppendOnly(t *testing.T) {
+	fm, _ := ParseFrontmatter("---\nnotion-id: abc\nappend-only: true\nStatus: Done\n---\n\nBody")
+	if !fm.AppendOnly {
+		t.Fatal("expected AppendOnly")
+	}
+	if _, ok := fm.Properties["append-only"]; ok {
+		t.Fatalf("append-only leaked into Properties: %v", fm.Properties)
+	}
+
+	fm, _ = ParseFrontmatter("---\nappend-only: false\n---\n\nBody")
+	if fm.AppendOnly {
+		t.Fatal("expected AppendOnly to be false")
+	}
+}
+
+func TestParseFrontmatterParent(t *testing.T) {
+	fm, _ := ParseFrontmatter("---\nnotion-parent: \"Engineering Notes\"\nnotion-parent-db: Tasks\nparent: Epic\n---\n\nBody")
+	if fm.Parent != "Engineering Notes" || fm.ParentDB != "Tasks" {
+		t.Fatalf("Parent = %q, ParentDB = %q", fm.Parent, fm.ParentDB)
+	}
+	if _, ok := fm.Properties["notion-parent"]; ok {
+		t.Fatalf("notion-parent leaked into Properties: %v", fm.Properties)
+	}
+	if fm.Properties["parent"] != "Epic" {
+		t.Fatalf("parent property = %q, want it kept as a property", fm.Properties["parent"])
+	}
+}
+
+func TestParseFrontmatterNotionTargets(t *testing.T) {
+	fm, _ := ParseFrontmatter("---\nnotion-targets: [id-1, \"id-2\"]\n---\n\nBody")
+	if !reflect.DeepEqual(fm.NotionTargets, []string{"id-1", "id-2"}) {
+		t.Fatalf("inline NotionTargets = %v", fm.NotionTargets)
+	}
+	if len(fm.NotionIDs) != 0 || len(fm.Properties) != 0 {
+		t.Fatalf("inline list leaked: NotionIDs = %v, Properties = %v", fm.NotionIDs, fm.Properties)
+	}
+
+	fm, _ = ParseFrontmatter("---\nnotion-targets:\n  - id-1\n  - 'id-2'\ntags:\n  - a\n---\n\nBody")
+	if !reflect.DeepEqual(fm.NotionTargets, []string{"id-1", "id-2"}) {
+		t.Fatalf("block NotionTargets = %v", fm.NotionTargets)
+	}
+
+	fm, _ = ParseFrontmatter("---\nnotion-ids: [id-1, id-2]\n---\n\nBody")
+	if !reflect.DeepEqual(fm.NotionTargets, []string{"id-1", "id-2"}) || len(fm.NotionIDs) != 0 {
+		t.Fatalf("inline notion-ids list: NotionTargets = %v, NotionIDs = %v", fm.NotionTargets, fm.NotionIDs)
+	}
+
+	fm, _ = ParseFrontmatter("---\nnotion-ids:\n  - id-1\n---\n\nBody")
+	if !reflect.DeepEqual(fm.NotionTargets, []string{"id-1"}) {
+		t.Fatalf("block notion-ids list: NotionTargets = %v", fm.NotionTargets)
+	}
+
+	fm, _ = ParseFrontmatter("---\nnotion-ids:\n  intro: id-1\n---\n\nBody")
+	if len(fm.NotionTargets) != 0 || fm.NotionIDs["intro"] != "id-1" {
+		t.Fatalf("section map parsed as NotionTargets = %v, NotionIDs = %v", fm.NotionTargets, fm.NotionIDs)
+	}
+}
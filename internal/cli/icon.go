@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// emojiShortcodes maps common GitHub/Slack-style shortcodes to emoji.
+var emojiShortcodes = map[string]string{
+	"+1":                       "👍",
+	"-1":                       "👎",
+	"bar_chart":                "📊",
+	"bell":                     "🔔",
+	"book":                     "📖",
+	"books":                    "📚",
+	"bookmark":                 "🔖",
+	"bug":                      "🐛",
+	"bulb":                     "💡",
+	"calendar":                 "📅",
+	"chart_with_upwards_trend": "📈",
+	"check":                    "✅",
+	"white_check_mark":         "✅",
+	"clipboard":                "📋",
+	"clock":                    "🕒",
+	"cloud":                    "☁️",
+	"computer":                 "💻",
+	"construction":             "🚧",
+	"dart":                     "🎯",
+	"date":                     "📅",
+	"email":                    "📧",
+	"exclamation":              "❗",
+	"eyes":                     "👀",
+	"file_folder":              "📁",
+	"fire":                     "🔥",
+	"gear":                     "⚙️",
+	"globe_with_meridians":     "🌐",
+	"hammer":                   "🔨",
+	"hammer_and_wrench":        "🛠️",
+	"heart":                    "❤️",
+	"heavy_exclamation_mark":   "❗",
+	"house":                    "🏠",
+	"information_source":       "ℹ️",
+	"inbox_tray":               "📥",
+	"key":                      "🔑",
+	"label":                    "🏷️",
+	"link":                     "🔗",
+	"lock":                     "🔒",
+	"mag":                      "🔍",
+	"memo":                     "📝",
+	"money_with_wings":         "💸",
+	"package":                  "📦",
+	"page_facing_up":           "📄",
+	"pencil":                   "📝",
+	"pencil2":                  "✏️",
+	"pushpin":                  "📌",
+	"question":                 "❓",
+	"rocket":                   "🚀",
+	"rotating_light":           "🚨",
+	"scroll":                   "📜",
+	"seedling":                 "🌱",
+	"shield":                   "🛡️",
+	"sparkles":                 "✨",
+	"speech_balloon":           "💬",
+	"star":                     "⭐",
+	"tada":                     "🎉",
+	"test_tube":                "🧪",
+	"thinking":                 "🤔",
+	"trophy":                   "🏆",
+	"warning":                  "⚠️",
+	"wrench":                   "🔧",
+	"x":                        "❌",
+	"zap":                      "⚡",
+}
+
+// ParseIcon validates an --icon value, expanding :shortcode: forms such as
+// :rocket: to their emoji first. An empty value means no icon.
+func ParseIcon(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	if len(value) > 2 && strings.HasPrefix(value, ":") && strings.HasSuffix(value, ":") {
+		emoji, ok := ShortcodeEmoji(value)
+		if !ok {
+			return "", fmt.Errorf("unknown emoji shortcode %q (pass the emoji character instead)", value)
+		}
+		return emoji, nil
+	}
+	if !IsEmoji(value) {
+		return "", fmt.Errorf("invalid icon %q: expected an emoji or a :shortcode:", value)
+	}
+	return value, nil
+}
+
+// ShortcodeEmoji returns the emoji for a :shortcode: (case-insensitive).
+func ShortcodeEmoji(shortcode string) (string, bool) {
+	if len(shortcode) < 3 || !strings.HasPrefix(shortcode, ":") || !strings.HasSuffix(shortcode, ":") {
+		return "", false
+	}
+	emoji, ok := emojiShortcodes[strings.ToLower(shortcode[1:len(shortcode)-1])]
+	return emoji, ok
+}
+
+// LeadingShortcode splits a known :shortcode: off the start of s, returning
+// its emoji and the rest of s with leading spaces trimmed.
+func LeadingShortcode(s string) (emoji, rest string, ok bool) {
+	if !strings.HasPrefix(s, ":") {
+		return "", s, false
+	}
+	end := strings.Index(s[1:], ":")
+	if end < 0 {
+		return "", s, false
+	}
+	emoji, ok = ShortcodeEmoji(s[:end+2])
+	if !ok {
+		return "", s, false
+	}
+	return emoji, strings.TrimLeft(s[end+2:], " "), true
+}
+
+var calloutIconShortcode = regexp.MustCompile(`(<callout\b[^>]*\bicon=")(:[A-Za-z0-9_+-]+:)(")`)
+
+// ExpandCalloutShortcodes replaces :shortcode: callout icons with their
+// emoji, both in <callout icon=":bulb:"> tags and at the start of
+// blockquote callouts like "> :bulb: Note". Fenced code and unknown
+// shortcodes are left as written.
+func ExpandCalloutShortcodes(markdown string) string {
+	lines := strings.Split(markdown, "\n")
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if isFenceLine(trimmed) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		line = calloutIconShortcode.ReplaceAllStringFunc(line, func(m string) string {
+			parts := calloutIconShortcode.FindStringSubmatch(m)
+			if emoji, ok := ShortcodeEmoji(parts[2]); ok {
+				return parts[1] + emoji + parts[3]
+			}
+			return m
+		})
+		if quoted, found := strings.CutPrefix(line, "> "); found {
+			if emoji, rest, ok := LeadingShortcode(quoted); ok {
+				line = "> " + emoji + " " + rest
+			}
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,54 @@
+package cli
+
+import "testing"
+
+func TestParseIcon(t *testing.T) {
+	tests := map[string]string{
+		"":          "",
+		"🚀":         "🚀",
+		":rocket:":  "🚀",
+		":Memo:":    "📝",
+		":warning:": "⚠️",
+		"👩‍💻":       "👩‍💻",
+		"1️⃣":       "1️⃣",
+		"#️⃣":       "#️⃣",
+		"🇳🇿":        "🇳🇿",
+	}
+	for in, want := range tests {
+		got, err := ParseIcon(in)
+		if err != nil {
+			t.Fatalf("ParseIcon(%q): %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("ParseIcon(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseIconRejectsUnknownShortcodesAndText(t *testing.T) {
+	for _, in := range []string{":not_a_real_emoji:", "rocket", "A", "1", "€", "🚀 Launch"} {
+		if _, err := ParseIcon(in); err == nil {
+			t.Fatalf("ParseIcon(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestLeadingShortcode(t *testing.T) {
+	emoji, rest, ok := LeadingShortcode(":fire: Hot takes")
+	if !ok || emoji != "🔥" || rest != "Hot takes" {
+		t.Fatalf("LeadingShortcode = %q, %q, %v", emoji, rest, ok)
+	}
+	for _, in := range []string{"Hot :fire:", ":nope: title", "10:30 standup", ":"} {
+		if _, rest, ok := LeadingShortcode(in); ok || rest != in {
+			t.Fatalf("LeadingShortcode(%q) = %q, %v; want no match", in, rest, ok)
+		}
+	}
+}
+
+func TestExpandCalloutShortcodes(t *testing.T) {
+	in := "<callout icon=\":bulb:\">Tip</callout>\n> :warning: Careful\n> :nope: Unknown\nText :fire: inline\n```\n> :bulb: in code\n```"
+	want := "<callout icon=\"💡\">Tip</callout>\n> ⚠️ Careful\n> :nope: Unknown\nText :fire: inline\n```\n> :bulb: in code\n```"
+	if got := ExpandCalloutShortcodes(in); got != want {
+		t.Fatalf("ExpandCalloutShortcodes =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var imageCheckClient = &http.Client{Timeout: 10 * time.Second}
+
+// CheckImages confirms that every markdown image in markdown can be found:
+// local files must exist relative to sourceFile, and http(s) images must
+// answer a HEAD request (or a GET, for servers that reject HEAD) with a
+// non-error status. Images inside fenced code blocks are ignored. It returns
+// one error per broken image, in document order.
+func CheckImages(ctx context.Context, markdown, sourceFile string) []error {
+	sourceFileAbs, err := filepath.Abs(sourceFile)
+	if err != nil {
+		return []error{fmt.Errorf("resolve source file path: %w", err)}
+	}
+	sourceDir := filepath.Dir(sourceFileAbs)
+
+	var failures []error
+	checked := make(map[string]error)
+	inFence := false
+	for i, line := range strings.Split(markdown, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		for _, match := range markdownImageRE.FindAllStringSubmatch(line, -1) {
+			dest, ok := parseMarkdownDestination(match[2])
+			if !ok {
+				continue
+			}
+			err, seen := checked[dest]
+			if !seen {
+				err = checkImage(ctx, dest, sourceDir)
+				checked[dest] = err
+			}
+			if err != nil {
+				failures = append(failures, fmt.Errorf("line %d: image %q: %w", i+1, dest, err))
+			}
+		}
+	}
+	return failures
+}
+
+func checkImage(ctx context.Context, dest, sourceDir string) error {
+	if isLocalDestination(dest) {
+		path, err := resolveLocalPath(dest, sourceDir)
+		if err != nil {
+			return err
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("not found at %s", path)
+		}
+		if info.IsDir() {
+			return fmt.Errorf("%s is a directory", path)
+		}
+		return nil
+	}
+
+	lower := strings.ToLower(dest)
+	switch {
+	case strings.HasPrefix(lower, "data:"):
+		return nil
+	case strings.HasPrefix(lower, "http://"), strings.HasPrefix(lower, "https://"):
+		return checkRemoteImage(ctx, dest)
+	}
+	return fmt.Errorf("cannot check images with this URL scheme")
+}
+
+func checkRemoteImage(ctx context.Context, rawURL string) error {
+	status, err := requestStatus(ctx, http.MethodHead, rawURL)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = requestStatus(ctx, http.MethodGet, rawURL)
+	}
+	if err != nil {
+		return err
+	}
+	if status >= 400 {
+		return fmt.Errorf("server returned %d %s", status, http.StatusText(status))
+	}
+	return nil
+}
+
+func requestStatus(ctx context.Context, method, rawURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := imageCheckClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
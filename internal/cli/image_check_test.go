@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckImages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok.png":
+		case "/get-only.png":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "here.png"), []byte("png"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	doc := filepath.Join(dir, "doc.md")
+
+	markdown := strings.Join([]string{
+		"![ok](./here.png)",
+		"Inline ![remote](" + srv.URL + "/ok.png) and ![get](" + srv.URL + "/get-only.png)",
+		"![missing](./missing.png)",
+		"![gone](" + srv.URL + "/gone.png)",
+		"```",
+		"![in code](./also-missing.png)",
+		"```",
+		"![again](./missing.png)",
+	}, "\n")
+
+	failures := CheckImages(context.Background(), markdown, doc)
+	if len(failures) != 3 {
+		t.Fatalf("failures = %v", failures)
+	}
+	for i, want := range []string{"line 3: image \"./missing.png\"", "line 4:", "line 8: image \"./missing.png\""} {
+		if !strings.Contains(failures[i].Error(), want) {
+			t.Errorf("failure %d = %q, want %q", i, failures[i], want)
+		}
+	}
+	if !strings.Contains(failures[1].Error(), "404") {
+		t.Errorf("remote failure = %q", failures[1])
+	}
+}
@@ -173,3 +173,29 @@ func resolveLocalPath(dest, sourceDir string) (string, error) {
 	}
 	return filepath.Clean(abs), nil
 }
+
+// LocalImagePaths returns the resolved paths of the local images referenced
+// in markdown, standalone or inline, skipping any that cannot be resolved.
+func LocalImagePaths(markdown, sourceFile string) []string {
+	sourceFileAbs, err := filepath.Abs(sourceFile)
+	if err != nil {
+		return nil
+	}
+	sourceDir := filepath.Dir(sourceFileAbs)
+
+	var paths []string
+	seen := make(map[string]bool)
+	for _, match := range markdownImageRE.FindAllStringSubmatch(markdown, -1) {
+		dest, ok := parseMarkdownDestination(match[2])
+		if !ok || !isLocalDestination(dest) {
+			continue
+		}
+		path, err := resolveLocalPath(dest, sourceDir)
+		if err != nil || seen[path] {
+			continue
+		}
+		seen[path] = true
+		paths = append(paths, path)
+	}
+	return paths
+}
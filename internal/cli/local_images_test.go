@@ -58,3 +58,14 @@ func TestRewriteStandaloneLocalImagesIgnoresRemoteImages(t *testing.T) {
 		t.Fatalf("rewritten = %q", rewritten)
 	}
 }
+
+func TestLocalImagePaths(t *testing.T) {
+	tmp := t.TempDir()
+	doc := filepath.Join(tmp, "doc.md")
+
+	paths := LocalImagePaths("![A](./a.png)\nInline ![B](img/b.png) and ![remote](https://example.com/c.png)\n![A again](./a.png)\n", doc)
+	want := []string{filepath.Join(tmp, "a.png"), filepath.Join(tmp, "img", "b.png")}
+	if len(paths) != len(want) || paths[0] != want[0] || paths[1] != want[1] {
+		t.Fatalf("LocalImagePaths = %v, want %v", paths, want)
+	}
+}
@@ -2,6 +2,8 @@ package cli
 
 import (
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/lox/notion-cli/internal/api"
 	"github.com/lox/notion-cli/internal/config"
@@ -13,6 +15,7 @@ type OfficialAPIConfig struct {
 	ConfigPath     string
 	APITokenSource string
 	HasConfigToken bool
+	Warnings       []string
 }
 
 func LoadOfficialAPIConfig(overrides config.APIOverrides) (*OfficialAPIConfig, error) {
@@ -26,14 +29,25 @@ func LoadOfficialAPIConfig(overrides config.APIOverrides) (*OfficialAPIConfig, e
 		ConfigPath:     loaded.Path,
 		APITokenSource: loaded.APITokenSource,
 		HasConfigToken: loaded.HasConfigToken,
+		Warnings:       loaded.Warnings,
 	}, nil
 }
 
-func RequireOfficialAPIClient(overrides config.APIOverrides) (*api.Client, error) {
+var apiVersionWarningWriter io.Writer = os.Stderr
+
+// RequireOfficialAPIClient builds an official API client from config. For
+// each feature the caller is about to use, it warns when the configured
+// Notion-Version is older than the feature needs, since the API then fails
+// with confusing validation errors rather than a version error.
+func RequireOfficialAPIClient(overrides config.APIOverrides, features ...api.Feature) (*api.Client, error) {
 	loaded, err := LoadOfficialAPIConfig(overrides)
 	if err != nil {
 		return nil, err
 	}
+	for _, w := range loaded.Warnings {
+		_, _ = fmt.Fprintf(apiVersionWarningWriter, "Warning: %s\n", w)
+	}
+	warnOutdatedAPIVersion(loaded.Config.API.NotionVersion, features)
 
 	client, err := api.NewClient(loaded.Config.API, loaded.Config.API.Token)
 	if err != nil {
@@ -41,3 +55,11 @@ func RequireOfficialAPIClient(overrides config.APIOverrides) (*api.Client, error
 	}
 	return client, nil
 }
+
+func warnOutdatedAPIVersion(version string, features []api.Feature) {
+	for _, f := range features {
+		if api.VersionOlderThan(version, f.MinVersion) {
+			_, _ = fmt.Fprintf(apiVersionWarningWriter, "Warning: Notion API version %s is older than %s, which %s need; set api.notion_version in config or NOTION_API_NOTION_VERSION to upgrade.\n", version, f.MinVersion, f.Name)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/api"
+)
+
+func TestWarnOutdatedAPIVersion(t *testing.T) {
+	var buf bytes.Buffer
+	oldWriter := apiVersionWarningWriter
+	apiVersionWarningWriter = &buf
+	t.Cleanup(func() {
+		apiVersionWarningWriter = oldWriter
+	})
+
+	warnOutdatedAPIVersion("2026-03-11", []api.Feature{api.FeatureDataSources})
+	if buf.Len() != 0 {
+		t.Fatalf("unexpected warning for current version: %q", buf.String())
+	}
+
+	warnOutdatedAPIVersion("2022-06-28", []api.Feature{api.FeatureDataSources})
+	out := buf.String()
+	if !strings.Contains(out, "2022-06-28") || !strings.Contains(out, "2025-09-03") || !strings.Contains(out, "NOTION_API_NOTION_VERSION") {
+		t.Fatalf("unexpected warning: %q", out)
+	}
+}
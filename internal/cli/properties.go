@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"strconv"
+
+	"github.com/lox/notion-cli/internal/api"
+)
+
+// FlattenProperties turns page properties into plain values keyed by
+// property name, so query results can be read without knowing Notion's
+// nested property shapes:
+//
+//   - title, rich_text, url, email, phone_number: string
+//   - number: number
+//   - select, status: option name
+//   - multi_select: list of option names
+//   - date: start, or "start/end" for ranges
+//   - checkbox: bool
+//   - people: list of names (IDs when a name is not shared)
+//   - relation: list of page IDs
+//   - formula: its computed value
+//   - created_time, last_edited_time: timestamp
+//   - unique_id: "PREFIX-N", or N without a prefix
+//
+// Empty text is "" and empty lists are []; other empty values and property
+// types not listed are null.
+func FlattenProperties(props map[string]api.PageProperty) map[string]any {
+	return flattenProperties(props, false)
+}
+
+// FlattenPropertiesMarkdown is FlattenProperties with title and rich_text
+// values rendered as markdown, keeping bold, italic, strikethrough, code,
+// and links (see api.RichTextMarkdown).
+func FlattenPropertiesMarkdown(props map[string]api.PageProperty) map[string]any {
+	return flattenProperties(props, true)
+}
+
+func flattenProperties(props map[string]api.PageProperty, markdown bool) map[string]any {
+	flat := make(map[string]any, len(props))
+	for name, prop := range props {
+		flat[name] = flattenProperty(prop, markdown)
+	}
+	return flat
+}
+
+func flattenProperty(prop api.PageProperty, markdown bool) any {
+	switch prop.Type {
+	case "title", "rich_text":
+		if markdown {
+			return prop.Markdown()
+		}
+		return prop.PlainText()
+	case "number":
+		if prop.Number == nil {
+			return nil
+		}
+		return *prop.Number
+	case "select":
+		return optionName(prop.Select)
+	case "status":
+		return optionName(prop.Status)
+	case "multi_select":
+		names := make([]string, 0, len(prop.MultiSelect))
+		for _, opt := range prop.MultiSelect {
+			names = append(names, opt.Name)
+		}
+		return names
+	case "date":
+		return dateString(prop.Date)
+	case "checkbox":
+		return prop.Checkbox
+	case "url":
+		return nonEmpty(prop.URL)
+	case "email":
+		return nonEmpty(prop.Email)
+	case "phone_number":
+		return nonEmpty(prop.PhoneNumber)
+	case "people":
+		people := make([]string, 0, len(prop.People))
+		for _, user := range prop.People {
+			if user.Name != "" {
+				people = append(people, user.Name)
+			} else {
+				people = append(people, user.ID)
+			}
+		}
+		return people
+	case "relation":
+		ids := make([]string, 0, len(prop.Relation))
+		for _, rel := range prop.Relation {
+			ids = append(ids, rel.ID)
+		}
+		return ids
+	case "formula":
+		return formulaValue(prop.Formula)
+	case "created_time":
+		return nonEmpty(prop.CreatedTime)
+	case "last_edited_time":
+		return nonEmpty(prop.LastEditedTime)
+	case "unique_id":
+		if prop.UniqueID == nil {
+			return nil
+		}
+		if prop.UniqueID.Prefix == "" {
+			return prop.UniqueID.Number
+		}
+		return prop.UniqueID.Prefix + "-" + strconv.Itoa(prop.UniqueID.Number)
+	}
+	return nil
+}
+
+func formulaValue(f *api.FormulaValue) any {
+	if f == nil {
+		return nil
+	}
+	switch f.Type {
+	case "string":
+		return nonEmpty(f.String)
+	case "number":
+		if f.Number == nil {
+			return nil
+		}
+		return *f.Number
+	case "boolean":
+		return f.Boolean
+	case "date":
+		return dateString(f.Date)
+	}
+	return nil
+}
+
+func optionName(opt *api.SelectOption) any {
+	if opt == nil {
+		return nil
+	}
+	return opt.Name
+}
+
+func dateString(d *api.DateValue) any {
+	if d == nil || d.Start == "" {
+		return nil
+	}
+	if d.End != "" {
+		return d.Start + "/" + d.End
+	}
+	return d.Start
+}
+
+func nonEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/lox/notion-cli/internal/api"
+)
+
+func TestFlattenProperties(t *testing.T) {
+	raw := `{
+		"Name": {"type":"title","title":[{"plain_text":"Ship "},{"plain_text":"v2"}]},
+		"Notes": {"type":"rich_text","rich_text":[]},
+		"Points": {"type":"number","number":3},
+		"Estimate": {"type":"number","number":null},
+		"Stage": {"type":"select","select":{"name":"Beta"}},
+		"Status": {"type":"status","status":{"name":"Done"}},
+		"Tags": {"type":"multi_select","multi_select":[{"name":"api"},{"name":"cli"}]},
+		"Due": {"type":"date","date":{"start":"2026-03-01","end":"2026-03-05"}},
+		"Started": {"type":"date","date":null},
+		"Shipped": {"type":"checkbox","checkbox":true},
+		"Link": {"type":"url","url":null},
+		"Owner": {"type":"people","people":[{"id":"u1","name":"Ada"},{"id":"u2"}]},
+		"Blocks": {"type":"relation","relation":[{"id":"p1"}]},
+		"Score": {"type":"formula","formula":{"type":"number","number":1.5}},
+		"Key": {"type":"unique_id","unique_id":{"prefix":"ENG","number":42}},
+		"Files": {"type":"files","files":[{"name":"a.pdf"}]}
+	}`
+	var props map[string]api.PageProperty
+	if err := json.Unmarshal([]byte(raw), &props); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	want := map[string]any{
+		"Name":     "Ship v2",
+		"Notes":    "",
+		"Points":   3.0,
+		"Estimate": nil,
+		"Stage":    "Beta",
+		"Status":   "Done",
+		"Tags":     []string{"api", "cli"},
+		"Due":      "2026-03-01/2026-03-05",
+		"Started":  nil,
+		"Shipped":  true,
+		"Link":     nil,
+		"Owner":    []string{"Ada", "u2"},
+		"Blocks":   []string{"p1"},
+		"Score":    1.5,
+		"Key":      "ENG-42",
+		"Files":    nil,
+	}
+	got := FlattenProperties(props)
+	for name, w := range want {
+		if !reflect.DeepEqual(got[name], w) {
+			t.Errorf("%s = %#v, want %#v", name, got[name], w)
+		}
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d properties, want %d", len(got), len(want))
+	}
+}
+
+func TestFlattenPropertiesMarkdown(t *testing.T) {
+	raw := `{
+		"Name": {"type":"title","title":[{"plain_text":"Ship "},{"plain_text":"v2","annotations":{"bold":true}}]},
+		"Notes": {"type":"rich_text","rich_text":[{"plain_text":"run "},{"plain_text":"make","annotations":{"code":true}}]},
+		"Points": {"type":"number","number":3}
+	}`
+	var props map[string]api.PageProperty
+	if err := json.Unmarshal([]byte(raw), &props); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	got := FlattenPropertiesMarkdown(props)
+	if got["Name"] != "Ship **v2**" || got["Notes"] != "run `make`" || got["Points"] != 3.0 {
+		t.Fatalf("FlattenPropertiesMarkdown = %#v", got)
+	}
+	if plain := FlattenProperties(props); plain["Name"] != "Ship v2" {
+		t.Fatalf("FlattenProperties Name = %#v", plain["Name"])
+	}
+}
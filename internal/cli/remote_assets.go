@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Notion page content marks file blocks as tags such as
+// <file src="{{https://...}}">Caption</file>; images may also appear as
+// markdown images.
+var (
+	assetTagRE      = regexp.MustCompile(`<(image|file|pdf|video|audio)\b([^>]*?)(?:/>|>(.*?)</(?:image|file|pdf|video|audio)>)`)
+	assetTagSrcRE   = regexp.MustCompile(`\b(?:src|source)="([^"]*)"`)
+	notionURLWrapRE = regexp.MustCompile(`^\{\{(.*)\}\}$`)
+)
+
+// RemoteAssetURLs returns the http(s) URLs of the images and file
+// attachments in Notion page content, in document order and without
+// duplicates. Anything inside fenced code blocks is ignored.
+func RemoteAssetURLs(content string) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	add := func(raw string) {
+		u := unwrapNotionURL(raw)
+		if !isRemoteAssetURL(u) || seen[u] {
+			return
+		}
+		seen[u] = true
+		urls = append(urls, u)
+	}
+
+	forEachUnfencedLine(content, func(line string) string {
+		for _, match := range markdownImageRE.FindAllStringSubmatch(line, -1) {
+			if dest, ok := parseMarkdownDestination(match[2]); ok {
+				add(dest)
+			}
+		}
+		for _, match := range assetTagRE.FindAllStringSubmatch(line, -1) {
+			if src := assetTagSrcRE.FindStringSubmatch(match[2]); src != nil {
+				add(src[1])
+			}
+		}
+		return line
+	})
+	return urls
+}
+
+// RewriteRemoteAssets is the inverse of RewriteStandaloneLocalImages: it
+// points images and attachments whose URL is a key of localPaths at the
+// local path instead. Attachment tags become markdown links (or images, for
+// image tags) whether or not they were downloaded, so they survive
+// conversion to plain markdown.
+func RewriteRemoteAssets(content string, localPaths map[string]string) string {
+	target := func(raw string) string {
+		u := unwrapNotionURL(raw)
+		if local, ok := localPaths[u]; ok {
+			return local
+		}
+		return u
+	}
+
+	return forEachUnfencedLine(content, func(line string) string {
+		line = markdownImageRE.ReplaceAllStringFunc(line, func(m string) string {
+			match := markdownImageRE.FindStringSubmatch(m)
+			dest, ok := parseMarkdownDestination(match[2])
+			if !ok {
+				return m
+			}
+			if local, ok := localPaths[unwrapNotionURL(dest)]; ok {
+				return "![" + match[1] + "](" + local + ")"
+			}
+			return m
+		})
+		return assetTagRE.ReplaceAllStringFunc(line, func(m string) string {
+			match := assetTagRE.FindStringSubmatch(m)
+			src := assetTagSrcRE.FindStringSubmatch(match[2])
+			if src == nil {
+				return m
+			}
+			dest := target(src[1])
+			caption := strings.TrimSpace(match[3])
+			if match[1] == "image" {
+				return "![" + caption + "](" + dest + ")"
+			}
+			if caption == "" {
+				caption = path.Base(dest)
+			}
+			return "[" + caption + "](" + dest + ")"
+		})
+	})
+}
+
+// forEachUnfencedLine replaces each line of content outside fenced code
+// blocks with fn's result.
+func forEachUnfencedLine(content string, fn func(string) string) string {
+	lines := strings.Split(content, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence {
+			lines[i] = fn(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func unwrapNotionURL(raw string) string {
+	s := strings.TrimSpace(raw)
+	if m := notionURLWrapRE.FindStringSubmatch(s); m != nil {
+		return m[1]
+	}
+	return s
+}
+
+func isRemoteAssetURL(u string) bool {
+	lower := strings.ToLower(u)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
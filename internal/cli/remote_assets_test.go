@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRemoteAssetURLs(t *testing.T) {
+	content := "![Chart]({{https://files.example.com/chart.png}})\n" +
+		"<file src=\"{{https://files.example.com/report.pdf}}\">Q1 report</file>\n" +
+		"```\n![skip](https://files.example.com/code.png)\n```\n" +
+		"![again](https://files.example.com/chart.png) ![local](./here.png)\n" +
+		"<video source=\"https://files.example.com/demo.mp4\"/>"
+
+	got := RemoteAssetURLs(content)
+	want := []string{
+		"https://files.example.com/chart.png",
+		"https://files.example.com/report.pdf",
+		"https://files.example.com/demo.mp4",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("RemoteAssetURLs = %q, want %q", got, want)
+	}
+}
+
+func TestRewriteRemoteAssets(t *testing.T) {
+	content := "![Chart]({{https://files.example.com/chart.png}})\n" +
+		"<file src=\"https://files.example.com/report.pdf\">Q1 report</file>\n" +
+		"<pdf src=\"https://files.example.com/missing.pdf\"></pdf>\n" +
+		"<image src=\"https://files.example.com/photo.jpg\">Team</image>\n" +
+		"```\n![Chart](https://files.example.com/chart.png)\n```"
+
+	got := RewriteRemoteAssets(content, map[string]string{
+		"https://files.example.com/chart.png":  "assets/chart.png",
+		"https://files.example.com/report.pdf": "assets/report.pdf",
+		"https://files.example.com/photo.jpg":  "assets/photo.jpg",
+	})
+	want := "![Chart](assets/chart.png)\n" +
+		"[Q1 report](assets/report.pdf)\n" +
+		"[missing.pdf](https://files.example.com/missing.pdf)\n" +
+		"![Team](assets/photo.jpg)\n" +
+		"```\n![Chart](https://files.example.com/chart.png)\n```"
+	if got != want {
+		t.Fatalf("RewriteRemoteAssets =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -2,10 +2,10 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
-	"unicode"
 
 	"github.com/lox/notion-cli/internal/mcp"
 	"github.com/lox/notion-cli/internal/output"
@@ -25,6 +25,22 @@ type PageRef struct {
 	ID   string // canonical UUID if Kind==RefID
 }
 
+// NotFoundError reports that a name lookup found no matching page or database.
+type NotFoundError struct {
+	Kind string
+	Name string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Kind + " not found: " + e.Name
+}
+
+// IsNotFound reports whether err is a NotFoundError.
+func IsNotFound(err error) bool {
+	var notFound *NotFoundError
+	return errors.As(err, &notFound)
+}
+
 var hexPattern = regexp.MustCompile(`[0-9a-fA-F]{32}`)
 var uuidPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
 
@@ -51,7 +67,8 @@ func ParsePageRef(s string) PageRef {
 }
 
 // ResolvePageID resolves any page reference (URL, ID, or name) to a page ID.
-// For URLs, it extracts the embedded UUID. For names, it searches and requires
+// For URLs, it extracts the embedded UUID, expanding share links when
+// following redirects is enabled. For names, it searches and requires
 // an exact unique match.
 func ResolvePageID(ctx context.Context, client *mcp.Client, input string) (string, error) {
 	ref := ParsePageRef(input)
@@ -59,10 +76,14 @@ func ResolvePageID(ctx context.Context, client *mcp.Client, input string) (strin
 	case RefID:
 		return ref.ID, nil
 	case RefURL:
-		if id, ok := ExtractNotionUUID(input); ok {
+		id, ok, err := extractURLID(ctx, input)
+		if err != nil {
+			return "", err
+		}
+		if ok {
 			return id, nil
 		}
-		return "", &output.UserError{Message: fmt.Sprintf("could not extract page ID from URL: %s\nUse the page ID directly instead.", input)}
+		return "", &output.UserError{Message: fmt.Sprintf("could not extract page ID from URL: %s\nUse the page ID directly instead%s.", input, urlIDHint())}
 	case RefName:
 		return resolvePageByName(ctx, client, input)
 	}
@@ -149,7 +170,7 @@ func resolvePageByName(ctx context.Context, client *mcp.Client, name string) (st
 	}
 
 	if len(partialMatches) == 0 {
-		return "", &output.UserError{Message: "page not found: " + name}
+		return "", &NotFoundError{Kind: "page", Name: name}
 	}
 
 	return "", ambiguousError(name, partialMatches)
@@ -185,10 +206,14 @@ func ResolveDatabaseID(ctx context.Context, client *mcp.Client, input string) (s
 	case RefID:
 		return ref.ID, nil
 	case RefURL:
-		if id, ok := ExtractNotionUUID(input); ok {
+		id, ok, err := extractURLID(ctx, input)
+		if err != nil {
+			return "", err
+		}
+		if ok {
 			return id, nil
 		}
-		return "", &output.UserError{Message: fmt.Sprintf("could not extract database ID from URL: %s\nUse the database ID directly instead.", input)}
+		return "", &output.UserError{Message: fmt.Sprintf("could not extract database ID from URL: %s\nUse the database ID directly instead%s.", input, urlIDHint())}
 	case RefName:
 		return resolveDatabaseByName(ctx, client, input)
 	}
@@ -234,13 +259,8 @@ func resolveDatabaseByName(ctx context.Context, client *mcp.Client, name string)
 	}
 
 	if len(partialMatches) == 0 {
-		return "", &output.UserError{Message: "database not found: " + name}
+		return "", &NotFoundError{Kind: "database", Name: name}
 	}
 
 	return "", ambiguousError(name, partialMatches)
 }
-
-// IsEmoji returns true if the rune is an emoji character.
-func IsEmoji(r rune) bool {
-	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r) && !unicode.IsPunct(r) && r > 127
-}
@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/lox/notion-cli/internal/mcp"
@@ -120,3 +122,16 @@ func TestExtractNotionUUID(t *testing.T) {
 		})
 	}
 }
+
+func TestIsNotFound(t *testing.T) {
+	err := fmt.Errorf("resolve parent: %w", &NotFoundError{Kind: "page", Name: "Engineering"})
+	if !IsNotFound(err) {
+		t.Fatalf("expected wrapped NotFoundError to be detected")
+	}
+	if got := err.Error(); got != "resolve parent: page not found: Engineering" {
+		t.Fatalf("Error() = %q", got)
+	}
+	if IsNotFound(errors.New("page not found: Engineering")) {
+		t.Fatalf("plain errors should not be treated as NotFoundError")
+	}
+}
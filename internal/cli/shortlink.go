@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var followRedirects bool
+var shortLinkClient = &http.Client{Timeout: 10 * time.Second}
+
+// SetFollowRedirects enables expanding Notion links that don't embed an ID
+// by following their redirects.
+func SetFollowRedirects(value bool) {
+	followRedirects = value
+}
+
+// ExpandShortLink sends a HEAD request to rawURL, following redirects, and
+// returns the final URL. The response status is ignored since only the
+// redirect target is needed.
+func ExpandShortLink(ctx context.Context, rawURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, rawURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("expand link: %w", err)
+	}
+	resp, err := shortLinkClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("expand link: %w", err)
+	}
+	_ = resp.Body.Close()
+	return resp.Request.URL.String(), nil
+}
+
+// isNotionLink reports whether rawURL points at a Notion host, so that
+// redirects are only followed for links Notion itself issued.
+func isNotionLink(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, domain := range []string{"notion.so", "notion.site", "notion.com"} {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// extractURLID extracts a Notion ID from a URL, expanding the link first
+// when it has no embedded ID and following redirects is enabled. It fails
+// only when the link could not be expanded; a URL without an ID is
+// reported by ok being false.
+func extractURLID(ctx context.Context, rawURL string) (id string, ok bool, err error) {
+	if id, ok := ExtractNotionUUID(rawURL); ok {
+		return id, true, nil
+	}
+	if !followRedirects || !isNotionLink(rawURL) {
+		return "", false, nil
+	}
+	expanded, err := ExpandShortLink(ctx, rawURL)
+	if err != nil {
+		return "", false, fmt.Errorf("%s: %w", rawURL, err)
+	}
+	id, ok = ExtractNotionUUID(expanded)
+	return id, ok, nil
+}
+
+func urlIDHint() string {
+	if followRedirects {
+		return ""
+	}
+	return " or pass --follow-redirects to expand share links"
+}
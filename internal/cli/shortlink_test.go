@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExpandShortLinkFollowsRedirects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Fatalf("method = %s, want HEAD", r.Method)
+		}
+		if r.URL.Path == "/s/abc" {
+			http.Redirect(w, r, "/My-Page-12345678abcdef1234567890abcdef12", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	final, err := ExpandShortLink(context.Background(), srv.URL+"/s/abc")
+	if err != nil {
+		t.Fatalf("ExpandShortLink: %v", err)
+	}
+	id, ok := ExtractNotionUUID(final)
+	if !ok || id != "12345678-abcd-ef12-3456-7890abcdef12" {
+		t.Fatalf("final URL %q extracted to %q, %v", final, id, ok)
+	}
+}
+
+func TestExtractURLIDOnlyExpandsNotionLinksWhenEnabled(t *testing.T) {
+	t.Cleanup(func() { SetFollowRedirects(false) })
+
+	if _, ok, err := extractURLID(context.Background(), "https://www.notion.so/s/abc"); ok || err != nil {
+		t.Fatalf("expected no ID when following redirects is disabled, got ok=%v err=%v", ok, err)
+	}
+
+	SetFollowRedirects(true)
+	// Not a Notion host, so no request is made.
+	if _, ok, err := extractURLID(context.Background(), "http://127.0.0.1:1/s/abc"); ok || err != nil {
+		t.Fatalf("expected no ID for non-Notion link, got ok=%v err=%v", ok, err)
+	}
+}
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestExtractURLIDReportsExpandError(t *testing.T) {
+	SetFollowRedirects(true)
+	original := shortLinkClient
+	shortLinkClient = &http.Client{Transport: failingTransport{}}
+	t.Cleanup(func() {
+		SetFollowRedirects(false)
+		shortLinkClient = original
+	})
+
+	_, _, err := extractURLID(context.Background(), "https://www.notion.so/s/abc")
+	if err == nil || !strings.Contains(err.Error(), "https://www.notion.so/s/abc") || !strings.Contains(err.Error(), "connection refused") {
+		t.Fatalf("error = %v, want the URL and the expand error", err)
+	}
+}
+
+func TestIsNotionLink(t *testing.T) {
+	tests := map[string]bool{
+		"https://www.notion.so/s/abc":     true,
+		"https://notion.so/abc":           true,
+		"https://team.notion.site/Page":   true,
+		"https://example.com/notion.so/x": false,
+		"https://notnotion.so/x":          false,
+	}
+	for in, want := range tests {
+		if got := isNotionLink(in); got != want {
+			t.Fatalf("isNotionLink(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SplitMarkdownSections splits markdown into sections at every line matching
+// sep. Separator lines are dropped, lines inside fenced code blocks never
+// split, and sections containing only whitespace are skipped.
+func SplitMarkdownSections(markdown string, sep *regexp.Regexp) []string {
+	var sections []string
+	var current []string
+	inFence := false
+
+	flush := func() {
+		section := strings.TrimSpace(strings.Join(current, "\n"))
+		if section != "" {
+			sections = append(sections, section)
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if !inFence && sep.MatchString(strings.TrimRight(line, "\r")) {
+			flush()
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+
+	return sections
+}
+
+var sectionKeyRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// SectionKeys returns stable frontmatter keys for a list of section titles.
+// Keys are lowercase slugs; empty slugs fall back to section-N and
+// duplicates, or slugs equal to one of reserved, get the lowest numeric
+// suffix that leaves every key unique.
+func SectionKeys(titles []string, reserved ...string) []string {
+	keys := make([]string, len(titles))
+	used := make(map[string]bool, len(titles)+len(reserved))
+	for _, key := range reserved {
+		used[key] = true
+	}
+	next := make(map[string]int)
+	for i, title := range titles {
+		base := strings.Trim(sectionKeyRe.ReplaceAllString(strings.ToLower(title), "-"), "-")
+		if base == "" {
+			base = "section-" + strconv.Itoa(i+1)
+		}
+		key := base
+		for used[key] {
+			if next[base] < 2 {
+				next[base] = 2
+			}
+			key = base + "-" + strconv.Itoa(next[base])
+			next[base]++
+		}
+		used[key] = true
+		keys[i] = key
+	}
+	return keys
+}
+
+// HeadingSection is a part of a markdown document that starts with a heading.
+type HeadingSection struct {
+	Title string
+	Body  string
+}
+
+// SplitMarkdownHeadings splits markdown at headings of the given level (1 for
+// "# ", 2 for "## "). The content before the first such heading is returned
+// as the preamble. Each section's heading line becomes its Title and is not
+// repeated in its Body. Headings inside fenced code blocks are ignored.
+func SplitMarkdownHeadings(markdown string, level int) (string, []HeadingSection) {
+	prefix := strings.Repeat("#", level) + " "
+	var preamble string
+	var sections []HeadingSection
+	var current []string
+	var title string
+	started := false
+	inFence := false
+
+	flush := func() {
+		body := strings.TrimSpace(strings.Join(current, "\n"))
+		if started {
+			sections = append(sections, HeadingSection{Title: title, Body: body})
+		} else {
+			preamble = body
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if !inFence && strings.HasPrefix(trimmed, prefix) {
+			flush()
+			title = strings.TrimSpace(strings.TrimPrefix(trimmed, prefix))
+			started = true
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+
+	return preamble, sections
+}
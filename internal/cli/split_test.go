@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestSplitMarkdownSections(t *testing.T) {
+	sep := regexp.MustCompile(`^<!-- page -->$`)
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "no separator",
+			input: "# One\n\nBody",
+			want:  []string{"# One\n\nBody"},
+		},
+		{
+			name:  "splits and drops separators",
+			input: "# One\nfirst\n<!-- page -->\n# Two\nsecond\n",
+			want:  []string{"# One\nfirst", "# Two\nsecond"},
+		},
+		{
+			name:  "skips empty sections",
+			input: "<!-- page -->\n\n<!-- page -->\n# Only\n<!-- page -->\n",
+			want:  []string{"# Only"},
+		},
+		{
+			name:  "ignores separators in code fences",
+			input: "# One\n```\n<!-- page -->\n```\n<!-- page -->\n# Two",
+			want:  []string{"# One\n```\n<!-- page -->\n```", "# Two"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitMarkdownSections(tt.input, sep)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("SplitMarkdownSections = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSectionKeys(t *testing.T) {
+	got := SectionKeys([]string{"Meeting Notes", "meeting notes!", "🎉", "Q3 Plan"})
+	want := []string{"meeting-notes", "meeting-notes-2", "section-3", "q3-plan"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("SectionKeys = %q, want %q", got, want)
+	}
+}
+
+func TestSectionKeysReserved(t *testing.T) {
+	got := SectionKeys([]string{"Assets", "Notes"}, "assets")
+	want := []string{"assets-2", "notes"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("SectionKeys = %q, want %q", got, want)
+	}
+}
+
+func TestSectionKeysNeverRepeat(t *testing.T) {
+	tests := []struct {
+		titles, reserved, want []string
+	}{
+		{[]string{"Notes", "Notes", "Notes 2"}, nil, []string{"notes", "notes-2", "notes-2-2"}},
+		{[]string{"Notes 2", "Notes", "Notes"}, nil, []string{"notes-2", "notes", "notes-3"}},
+		{[]string{"Assets", "Assets 2"}, []string{"assets"}, []string{"assets-2", "assets-2-2"}},
+		{[]string{"Section 2", "🎉"}, nil, []string{"section-2", "section-2-2"}},
+	}
+	for _, tt := range tests {
+		if got := SectionKeys(tt.titles, tt.reserved...); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SectionKeys(%q, %q) = %q, want %q", tt.titles, tt.reserved, got, tt.want)
+		}
+	}
+}
+
+func TestSplitMarkdownHeadings(t *testing.T) {
+	input := "# Guide\nIntro\n\n## Setup\nInstall it.\n```\n## not a heading\n```\n## Usage\nRun it.\n### Flags\nMore.\n"
+
+	preamble, sections := SplitMarkdownHeadings(input, 2)
+	if preamble != "# Guide\nIntro" {
+		t.Fatalf("preamble = %q", preamble)
+	}
+	want := []HeadingSection{
+		{Title: "Setup", Body: "Install it.\n```\n## not a heading\n```"},
+		{Title: "Usage", Body: "Run it.\n### Flags\nMore."},
+	}
+	if !reflect.DeepEqual(sections, want) {
+		t.Fatalf("sections = %#v, want %#v", sections, want)
+	}
+
+	preamble, sections = SplitMarkdownHeadings(input, 1)
+	if preamble != "" || len(sections) != 1 || sections[0].Title != "Guide" {
+		t.Fatalf("h1 split = %q, %#v", preamble, sections)
+	}
+}
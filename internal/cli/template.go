@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// templateVarRE matches {{key}} placeholders. Keys are identifiers, so
+// Notion's {{https://...}} URL wrappers are never taken for one.
+var templateVarRE = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*\}\}`)
+
+// ParseTemplateVars parses KEY=VALUE pairs from --var flags, trimming
+// spaces around both. Later pairs override earlier ones.
+func ParseTemplateVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		k, v, ok := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !ok || !templateVarRE.MatchString("{{"+k+"}}") {
+			return nil, fmt.Errorf("invalid --var %q (expected KEY=VALUE, where KEY is letters, digits, _, ., or -)", pair)
+		}
+		vars[k] = strings.TrimSpace(v)
+	}
+	return vars, nil
+}
+
+// ExpandTemplateVars replaces {{key}} placeholders in content with their
+// values, leaving fenced code blocks untouched. Placeholders without a value
+// are kept as written, or reported together as an error when strict.
+func ExpandTemplateVars(content string, vars map[string]string, strict bool) (string, error) {
+	missing := make(map[string]bool)
+	expanded := forEachUnfencedLine(content, func(line string) string {
+		return templateVarRE.ReplaceAllStringFunc(line, func(m string) string {
+			key := templateVarRE.FindStringSubmatch(m)[1]
+			if v, ok := vars[key]; ok {
+				return v
+			}
+			missing[key] = true
+			return m
+		})
+	})
+	if strict && len(missing) > 0 {
+		keys := make([]string, 0, len(missing))
+		for k := range missing {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return "", fmt.Errorf("undefined template variable(s): %s (pass --var KEY=VALUE)", strings.Join(keys, ", "))
+	}
+	return expanded, nil
+}
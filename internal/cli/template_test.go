@@ -0,0 +1,32 @@
+package cli
+
+import "testing"
+
+func TestExpandTemplateVars(t *testing.T) {
+	vars, err := ParseTemplateVars([]string{"env=staging", "region = eu-west-1", "env=prod"})
+	if err != nil {
+		t.Fatalf("ParseTemplateVars: %v", err)
+	}
+
+	content := "# Deploy {{env}}\n\nRegion: {{ region }}, owner {{owner}}\n![x]({{https://example.com/a.png}})\n```\n{{env}}\n```\n"
+	got, err := ExpandTemplateVars(content, vars, false)
+	if err != nil {
+		t.Fatalf("ExpandTemplateVars: %v", err)
+	}
+	want := "# Deploy prod\n\nRegion: eu-west-1, owner {{owner}}\n![x]({{https://example.com/a.png}})\n```\n{{env}}\n```\n"
+	if got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	if _, err := ExpandTemplateVars(content, vars, true); err == nil || err.Error() != "undefined template variable(s): owner (pass --var KEY=VALUE)" {
+		t.Fatalf("strict err = %v", err)
+	}
+}
+
+func TestParseTemplateVarsRejectsBadKeys(t *testing.T) {
+	for _, pair := range []string{"novalue", "=x", "a b=c", "1st=x"} {
+		if _, err := ParseTemplateVars([]string{pair}); err == nil {
+			t.Fatalf("ParseTemplateVars(%q) succeeded, want error", pair)
+		}
+	}
+}
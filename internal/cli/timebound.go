@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseTimeBound parses an RFC3339 timestamp, a YYYY-MM-DD date, or a
+// relative duration before now such as 36h, 7d, or 2w.
+func ParseTimeBound(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("time is empty")
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t, nil
+	}
+
+	unit := value[len(value)-1]
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 0 {
+		return time.Time{}, fmt.Errorf("invalid time %q (expected RFC3339, YYYY-MM-DD, or a relative duration like 7d or 2w)", value)
+	}
+	switch unit {
+	case 'm':
+		return now.Add(-time.Duration(n) * time.Minute), nil
+	case 'h':
+		return now.Add(-time.Duration(n) * time.Hour), nil
+	case 'd':
+		return now.AddDate(0, 0, -n), nil
+	case 'w':
+		return now.AddDate(0, 0, -7*n), nil
+	default:
+		return time.Time{}, fmt.Errorf("invalid time %q (expected RFC3339, YYYY-MM-DD, or a relative duration like 7d or 2w)", value)
+	}
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeBound(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2026-03-01T09:30:00Z", time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)},
+		{"2026-03-01", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"36h", now.Add(-36 * time.Hour)},
+		{"7d", time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)},
+		{"2w", time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseTimeBound(tt.in, now)
+		if err != nil {
+			t.Fatalf("ParseTimeBound(%q): %v", tt.in, err)
+		}
+		if !got.Equal(tt.want) {
+			t.Fatalf("ParseTimeBound(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "yesterday", "7y", "-3d"} {
+		if _, err := ParseTimeBound(bad, now); err == nil {
+			t.Fatalf("ParseTimeBound(%q): expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+)
+
+// PageChild is a child page found while walking a page tree.
+type PageChild struct {
+	ID    string
+	Title string
+}
+
+// PageNode is a page reached by WalkPageTree.
+type PageNode struct {
+	ID    string
+	Title string
+	// Key is a slug of the title, unique among the node's siblings, for
+	// naming files or directories after the page. The root's Key is empty.
+	Key      string
+	Depth    int
+	Parent   *PageNode `json:"-"`
+	Children []*PageNode
+}
+
+// Path returns the Keys from the root down to n, excluding the root's.
+func (n *PageNode) Path() []string {
+	if n.Parent == nil {
+		return nil
+	}
+	return append(n.Parent.Path(), n.Key)
+}
+
+// Walk calls fn for n and every node below it, parents first.
+func (n *PageNode) Walk(fn func(*PageNode)) {
+	fn(n)
+	for _, child := range n.Children {
+		child.Walk(fn)
+	}
+}
+
+// TreeLimits bounds a WalkPageTree. Zero means no limit.
+type TreeLimits struct {
+	// MaxDepth is how many levels below the root are visited.
+	MaxDepth int
+	// MaxNodes is how many pages are visited in total, root included.
+	MaxNodes int
+}
+
+// PageVisitor handles one page of a walk, typically by fetching it, and
+// returns its child pages in document order.
+type PageVisitor func(ctx context.Context, node *PageNode) ([]PageChild, error)
+
+// WalkPageTree visits root and the pages below it depth first, in document
+// order, and returns the tree of visited nodes. Each page is visited once,
+// so a child that links back to an ancestor, or a page linked from two
+// places, does not loop or repeat. truncated reports whether limits left
+// child pages unvisited. An error from visit stops the walk; errors below
+// the root name the child page they came from. No child is given a Key in
+// reserved, so callers can keep names such as an assets directory for
+// themselves.
+func WalkPageTree(ctx context.Context, root PageChild, limits TreeLimits, reserved []string, visit PageVisitor) (node *PageNode, truncated bool, err error) {
+	w := &treeWalker{limits: limits, reserved: reserved, visit: visit, seen: make(map[string]bool)}
+	node = &PageNode{ID: root.ID, Title: root.Title}
+	if err := w.walk(ctx, node); err != nil {
+		return nil, false, err
+	}
+	return node, w.truncated, nil
+}
+
+type treeWalker struct {
+	limits    TreeLimits
+	reserved  []string
+	visit     PageVisitor
+	seen      map[string]bool
+	visited   int
+	truncated bool
+}
+
+func (w *treeWalker) walk(ctx context.Context, node *PageNode) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	w.seen[node.ID] = true
+	w.visited++
+	found, err := w.visit(ctx, node)
+	if err != nil {
+		return err
+	}
+
+	var children []PageChild
+	for _, child := range found {
+		if !w.seen[child.ID] {
+			children = append(children, child)
+		}
+	}
+	if len(children) == 0 {
+		return nil
+	}
+	if w.limits.MaxDepth > 0 && node.Depth >= w.limits.MaxDepth {
+		w.truncated = true
+		return nil
+	}
+
+	titles := make([]string, len(children))
+	for i, child := range children {
+		titles[i] = child.Title
+	}
+	for i, key := range SectionKeys(titles, w.reserved...) {
+		// A deeper page may have reached this one first.
+		if w.seen[children[i].ID] {
+			continue
+		}
+		if w.limits.MaxNodes > 0 && w.visited >= w.limits.MaxNodes {
+			w.truncated = true
+			return nil
+		}
+		child := &PageNode{ID: children[i].ID, Title: children[i].Title, Key: key, Depth: node.Depth + 1, Parent: node}
+		if err := w.walk(ctx, child); err != nil {
+			return fmt.Errorf("child page %q: %w", children[i].Title, err)
+		}
+		node.Children = append(node.Children, child)
+	}
+	return nil
+}
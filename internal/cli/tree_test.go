@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// treeChildren is a fake page graph: a links to b and c, b links back to a
+// and on to d, and c also links to d.
+var treeChildren = map[string][]PageChild{
+	"a": {{ID: "b", Title: "Guides"}, {ID: "c", Title: "Guides"}},
+	"b": {{ID: "a", Title: "Home"}, {ID: "d", Title: "Setup"}},
+	"c": {{ID: "d", Title: "Setup"}},
+}
+
+func walkTree(t *testing.T, limits TreeLimits) (*PageNode, bool, []string) {
+	t.Helper()
+	var visited []string
+	root, truncated, err := WalkPageTree(context.Background(), PageChild{ID: "a"}, limits, nil, func(_ context.Context, node *PageNode) ([]PageChild, error) {
+		visited = append(visited, node.ID+"@"+strings.Join(node.Path(), "/"))
+		return treeChildren[node.ID], nil
+	})
+	if err != nil {
+		t.Fatalf("WalkPageTree: %v", err)
+	}
+	return root, truncated, visited
+}
+
+func TestWalkPageTreeVisitsEachPageOnce(t *testing.T) {
+	root, truncated, visited := walkTree(t, TreeLimits{})
+	want := []string{"a@", "b@guides", "d@guides/setup", "c@guides-2"}
+	if !reflect.DeepEqual(visited, want) {
+		t.Fatalf("visited = %v, want %v", visited, want)
+	}
+	if truncated {
+		t.Fatal("unlimited walk reported truncation")
+	}
+	if len(root.Children) != 2 || len(root.Children[1].Children) != 0 {
+		t.Fatalf("tree shape wrong: %+v", root)
+	}
+}
+
+func TestWalkPageTreeLimits(t *testing.T) {
+	_, truncated, visited := walkTree(t, TreeLimits{MaxDepth: 1})
+	if want := []string{"a@", "b@guides", "c@guides-2"}; !reflect.DeepEqual(visited, want) || !truncated {
+		t.Fatalf("MaxDepth 1: visited = %v truncated = %v, want %v truncated", visited, truncated, want)
+	}
+
+	_, truncated, visited = walkTree(t, TreeLimits{MaxNodes: 2})
+	if want := []string{"a@", "b@guides"}; !reflect.DeepEqual(visited, want) || !truncated {
+		t.Fatalf("MaxNodes 2: visited = %v truncated = %v, want %v truncated", visited, truncated, want)
+	}
+}
+
+func TestWalkPageTreeNamesFailingChild(t *testing.T) {
+	_, _, err := WalkPageTree(context.Background(), PageChild{ID: "a"}, TreeLimits{}, nil, func(_ context.Context, node *PageNode) ([]PageChild, error) {
+		if node.ID == "d" {
+			return nil, errors.New("boom")
+		}
+		return treeChildren[node.ID], nil
+	})
+	if err == nil || err.Error() != `child page "Guides": child page "Setup": boom` {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -23,7 +25,23 @@ const (
 )
 
 type Config struct {
+	// SchemaVersion records which config layout the file was written in;
+	// config migrate upgrades older files to CurrentSchemaVersion.
+	SchemaVersion int `json:"schema_version,omitempty"`
+
 	API APIConfig `json:"api,omitempty"`
+
+	// AuditLog enables the local audit log. It is a pointer so that an
+	// explicit false in a profile overrides a true it is merged onto.
+	AuditLog *bool `json:"audit_log,omitempty"`
+
+	// DefaultParent is the page used by page create, upload, and sync when
+	// neither --parent nor --parent-db is given.
+	DefaultParent string `json:"default_parent,omitempty"`
+
+	// AppendOnlyPages lists page IDs whose content page edit and page sync
+	// may add to but never replace.
+	AppendOnlyPages []string `json:"append_only_pages,omitempty"`
 }
 
 type APIConfig struct {
@@ -38,6 +56,10 @@ type LoadedConfig struct {
 	Path           string
 	APITokenSource string
 	HasConfigToken bool
+
+	// Warnings describes suspicious but usable settings, such as a
+	// notion_version that is not a date.
+	Warnings []string
 }
 
 type APIOverrides struct {
@@ -63,8 +85,14 @@ const (
 	APITokenSourceEnv    = "env"
 )
 
+// AuditLogEnabled reports whether audit_log is set to true.
+func (c Config) AuditLogEnabled() bool {
+	return c.AuditLog != nil && *c.AuditLog
+}
+
 func Default() Config {
 	return Config{
+		SchemaVersion: CurrentSchemaVersion,
 		API: APIConfig{
 			BaseURL:       defaultAPIBaseURL,
 			NotionVersion: defaultNotionAPIVer,
@@ -198,6 +226,11 @@ func SaveState(state State) error {
 	if err := os.MkdirAll(dir, 0o700); err != nil {
 		return fmt.Errorf("create state dir: %w", err)
 	}
+	unlock, err := LockFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	if err := os.Chmod(dir, 0o700); err != nil && !errors.Is(err, os.ErrNotExist) {
 		return fmt.Errorf("secure state dir: %w", err)
 	}
@@ -351,13 +384,14 @@ func LoadWithMeta(overrides APIOverrides) (LoadedConfig, error) {
 		source = APITokenSourceEnv
 	}
 
-	normalize(&cfg)
+	warnings := normalize(&cfg)
 	return LoadedConfig{
 		Config:         cfg,
 		Profile:        paths.Profile,
 		Path:           path,
 		APITokenSource: source,
 		HasConfigToken: strings.TrimSpace(fileCfg.API.Token) != "",
+		Warnings:       warnings,
 	}, nil
 }
 
@@ -370,8 +404,20 @@ func SaveForProfile(profile string, cfg Config) error {
 	if err != nil {
 		return err
 	}
+	unlock, err := LockFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return saveFile(path, cfg)
+}
 
+// saveFile writes cfg to path. Callers hold the lock for path.
+func saveFile(path string, cfg Config) error {
 	normalize(&cfg)
+	if err := ValidateBaseURL(cfg.API.BaseURL); err != nil {
+		return err
+	}
 
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0o700); err != nil {
@@ -385,8 +431,13 @@ func SaveForProfile(profile string, cfg Config) error {
 	if err != nil {
 		return fmt.Errorf("marshal config: %w", err)
 	}
-	data = append(data, '\n')
+	return writeConfigFile(path, append(data, '\n'))
+}
 
+// writeConfigFile atomically replaces the config file at path with data,
+// keeping the file and its directory private to the user.
+func writeConfigFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
 	tmp, err := os.CreateTemp(dir, configFileName+".*.tmp")
 	if err != nil {
 		return fmt.Errorf("create temp config: %w", err)
@@ -424,12 +475,9 @@ func SetAPIToken(token string) error {
 }
 
 func SetAPITokenForProfile(profile, token string) error {
-	cfg, err := loadForMutation(profile)
-	if err != nil {
-		return err
-	}
-	cfg.API.Token = strings.TrimSpace(token)
-	return SaveForProfile(profile, cfg)
+	return updateConfig(profile, func(cfg *Config) {
+		cfg.API.Token = strings.TrimSpace(token)
+	})
 }
 
 func UnsetAPIToken() error {
@@ -437,20 +485,33 @@ func UnsetAPIToken() error {
 }
 
 func UnsetAPITokenForProfile(profile string) error {
-	cfg, err := loadForMutation(profile)
+	return updateConfig(profile, func(cfg *Config) {
+		cfg.API.Token = ""
+	})
+}
+
+// updateConfig applies update to the profile's saved config while holding
+// its lock, so concurrent updates from other processes are not lost.
+func updateConfig(profile string, update func(*Config)) error {
+	path, err := PathForProfile(profile)
 	if err != nil {
 		return err
 	}
-	cfg.API.Token = ""
-	return SaveForProfile(profile, cfg)
-}
+	unlock, err := LockFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-func loadForMutation(profile string) (Config, error) {
-	path, err := PathForProfile(profile)
+	cfg, err := loadForMutation(path)
 	if err != nil {
-		return Config{}, err
+		return err
 	}
+	update(&cfg)
+	return saveFile(path, cfg)
+}
 
+func loadForMutation(path string) (Config, error) {
 	cfg := Default()
 	fileCfg, err := loadFile(path)
 	if err != nil {
@@ -480,6 +541,9 @@ func loadFile(path string) (Config, error) {
 }
 
 func merge(base, overlay Config) Config {
+	if overlay.SchemaVersion > base.SchemaVersion {
+		base.SchemaVersion = overlay.SchemaVersion
+	}
 	if strings.TrimSpace(overlay.API.BaseURL) != "" {
 		base.API.BaseURL = overlay.API.BaseURL
 	}
@@ -489,6 +553,14 @@ func merge(base, overlay Config) Config {
 	if strings.TrimSpace(overlay.API.Token) != "" {
 		base.API.Token = overlay.API.Token
 	}
+	if overlay.AuditLog != nil {
+		enabled := *overlay.AuditLog
+		base.AuditLog = &enabled
+	}
+	if s := strings.TrimSpace(overlay.DefaultParent); s != "" {
+		base.DefaultParent = s
+	}
+	base.AppendOnlyPages = append(base.AppendOnlyPages, overlay.AppendOnlyPages...)
 	return base
 }
 
@@ -508,9 +580,11 @@ func applyOverrides(cfg *Config, overrides APIOverrides) {
 	}
 }
 
-func normalize(cfg *Config) {
+// normalize trims and defaults cfg in place and returns warnings for values
+// that are kept but look wrong.
+func normalize(cfg *Config) []string {
 	if cfg == nil {
-		return
+		return nil
 	}
 	cfg.API.BaseURL = strings.TrimRight(strings.TrimSpace(cfg.API.BaseURL), "/")
 	if cfg.API.BaseURL == "" {
@@ -521,4 +595,35 @@ func normalize(cfg *Config) {
 		cfg.API.NotionVersion = defaultNotionAPIVer
 	}
 	cfg.API.Token = strings.TrimSpace(cfg.API.Token)
+
+	var warnings []string
+	if w := NotionVersionWarning(cfg.API.NotionVersion); w != "" {
+		warnings = append(warnings, w)
+	}
+	return warnings
+}
+
+// NotionVersionWarning returns a warning when version is not a
+// YYYY-MM-DD date, the format of every Notion-Version, or "" when it is.
+// It is a warning rather than an error so a new version format never
+// locks users out, but a typo otherwise surfaces only as API 400s.
+func NotionVersionWarning(version string) string {
+	if _, err := time.Parse("2006-01-02", version); err == nil {
+		return ""
+	}
+	return fmt.Sprintf("api.notion_version %q is not a YYYY-MM-DD date (for example %s); the API will likely reject requests", version, defaultNotionAPIVer)
+}
+
+// ValidateBaseURL checks that raw is an absolute http(s) URL with a host,
+// so a mistyped api.base_url fails when it is set rather than on the first
+// API call.
+func ValidateBaseURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid api.base_url %q: must be an http(s) URL such as %s", raw, defaultAPIBaseURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("invalid api.base_url %q: must not include a query or fragment", raw)
+	}
+	return nil
 }
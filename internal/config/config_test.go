@@ -1,10 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -126,6 +128,133 @@ func TestSaveSecuresConfigFile(t *testing.T) {
 	}
 }
 
+func TestSaveConcurrentWriters(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cfg := Default()
+			cfg.API.Token = fmt.Sprintf("token-%d-%s", i, strings.Repeat("x", 4096))
+			if err := Save(cfg); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := LoadWithMeta(APIOverrides{})
+	if err != nil {
+		t.Fatalf("LoadWithMeta after concurrent saves: %v", err)
+	}
+	if !strings.HasPrefix(loaded.Config.API.Token, "token-") {
+		t.Fatalf("API token = %.20q, want one of the saved tokens", loaded.Config.API.Token)
+	}
+}
+
+func TestSaveSurvivesInterruptedWrite(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := Default()
+	cfg.API.Token = "secret-token"
+	if err := Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	path, err := Path()
+	if err != nil {
+		t.Fatalf("Path: %v", err)
+	}
+
+	// A crash mid-write leaves a truncated temp file beside the config,
+	// never a truncated config.
+	if err := os.WriteFile(path+".123.tmp", []byte(`{"api": {"tok`), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	loaded, err := LoadWithMeta(APIOverrides{})
+	if err != nil {
+		t.Fatalf("LoadWithMeta after interrupted write: %v", err)
+	}
+	if loaded.Config.API.Token != "secret-token" {
+		t.Fatalf("API token = %q, want secret-token", loaded.Config.API.Token)
+	}
+
+	// A write whose rename fails cleans up its temp file.
+	if err := os.Remove(path + ".123.tmp"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	blocked := filepath.Join(filepath.Dir(path), "blocked")
+	if err := os.MkdirAll(filepath.Join(blocked, "child"), 0o700); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := writeConfigFile(blocked, []byte("{}")); err == nil {
+		t.Fatal("expected replacing a directory to fail")
+	}
+	leftovers, err := filepath.Glob(path + ".*.tmp")
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+	if len(leftovers) != 0 {
+		t.Fatalf("temp files left behind: %v", leftovers)
+	}
+}
+
+func TestLoadWithMetaWarnsOnMalformedNotionVersion(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	loaded, err := LoadWithMeta(APIOverrides{NotionVersion: "2026-03-11"})
+	if err != nil {
+		t.Fatalf("LoadWithMeta: %v", err)
+	}
+	if len(loaded.Warnings) != 0 {
+		t.Fatalf("Warnings = %q, want none", loaded.Warnings)
+	}
+
+	loaded, err = LoadWithMeta(APIOverrides{NotionVersion: "v2026-03-11"})
+	if err != nil {
+		t.Fatalf("LoadWithMeta: %v", err)
+	}
+	if len(loaded.Warnings) != 1 || !strings.Contains(loaded.Warnings[0], `"v2026-03-11"`) {
+		t.Fatalf("Warnings = %q, want one notion_version warning", loaded.Warnings)
+	}
+	if loaded.Config.API.NotionVersion != "v2026-03-11" {
+		t.Fatalf("NotionVersion = %q, want it kept", loaded.Config.API.NotionVersion)
+	}
+}
+
+func TestSaveRejectsInvalidBaseURL(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for _, baseURL := range []string{"api.notion.com/v1", "ftp://api.notion.com/v1", "https://", "https://api.notion.com/v1?x=1"} {
+		cfg := Default()
+		cfg.API.BaseURL = baseURL
+		err := Save(cfg)
+		if err == nil || !strings.Contains(err.Error(), "invalid api.base_url") {
+			t.Fatalf("Save(%q) error = %v, want invalid api.base_url", baseURL, err)
+		}
+	}
+
+	path, err := Path()
+	if err != nil {
+		t.Fatalf("Path: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("invalid config was written: %v", err)
+	}
+
+	cfg := Default()
+	cfg.API.BaseURL = " http://localhost:8080/v1/ "
+	if err := Save(cfg); err != nil {
+		t.Fatalf("Save valid URL: %v", err)
+	}
+}
+
 func TestPathsForProfileDefaultAndNamed(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
 
@@ -254,3 +383,89 @@ func TestListProfilesIncludesActiveDefaultAndNamedProfiles(t *testing.T) {
 		t.Fatalf("profiles = %#v, want %#v", got, want)
 	}
 }
+
+func TestAuditLogRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	entries, err := ReadAuditEntries(0)
+	if err != nil {
+		t.Fatalf("ReadAuditEntries: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no entries, got %d", len(entries))
+	}
+
+	for _, action := range []string{"page.create", "page.edit", "page.sync"} {
+		if err := AppendAuditEntry(AuditEntry{Action: action, Target: "page-id"}); err != nil {
+			t.Fatalf("AppendAuditEntry: %v", err)
+		}
+	}
+
+	entries, err = ReadAuditEntries(2)
+	if err != nil {
+		t.Fatalf("ReadAuditEntries: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("len(entries) = %d, want 2", len(entries))
+	}
+	if entries[0].Action != "page.edit" || entries[1].Action != "page.sync" {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	if entries[1].Time.IsZero() {
+		t.Fatalf("expected timestamp to be set")
+	}
+
+	path, err := AuditLogPath()
+	if err != nil {
+		t.Fatalf("AuditLogPath: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("audit log mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestLoadWithMetaReadsAuditLogFlag(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path, err := Path()
+	if err != nil {
+		t.Fatalf("Path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(`{"audit_log": true}`), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	loaded, err := LoadWithMeta(APIOverrides{})
+	if err != nil {
+		t.Fatalf("LoadWithMeta: %v", err)
+	}
+	if !loaded.Config.AuditLogEnabled() {
+		t.Fatalf("expected audit log to be enabled")
+	}
+}
+
+func TestMergeAuditLogExplicitFalseOverrides(t *testing.T) {
+	enabled, disabled := true, false
+	for _, tt := range []struct {
+		name    string
+		base    *bool
+		overlay *bool
+		want    bool
+	}{
+		{name: "unset keeps base", base: &enabled, overlay: nil, want: true},
+		{name: "false overrides true", base: &enabled, overlay: &disabled, want: false},
+		{name: "true overrides false", base: &disabled, overlay: &enabled, want: true},
+		{name: "both unset", want: false},
+	} {
+		got := merge(Config{AuditLog: tt.base}, Config{AuditLog: tt.overlay})
+		if got.AuditLogEnabled() != tt.want {
+			t.Errorf("%s: AuditLogEnabled = %v, want %v", tt.name, got.AuditLogEnabled(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LockFile takes an exclusive advisory lock guarding path, blocking until
+// any other notion-cli process holding it lets go, and returns a function
+// that releases it. The lock lives in a separate path+".lock" file because
+// the guarded file is replaced by rename on every write. Where the platform
+// or filesystem cannot lock, the returned function is a no-op and callers
+// proceed unlocked, as they did before locking existed.
+//
+// Locks are not reentrant: code holding the lock for path must not call
+// LockFile for path again.
+func LockFile(path string) (func(), error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create lock dir: %w", err)
+	}
+	f, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open lock file: %w", err)
+	}
+	locked, err := lockHandle(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("lock %s: %w", filepath.Base(path), err)
+	}
+	return func() {
+		if locked {
+			_ = unlockHandle(f)
+		}
+		_ = f.Close()
+	}, nil
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package config
+
+import "os"
+
+// lockHandle does not lock on platforms without flock; concurrent writers
+// there still get atomic (but possibly lost) updates.
+func lockHandle(*os.File) (bool, error) {
+	return false, nil
+}
+
+func unlockHandle(*os.File) error {
+	return nil
+}
@@ -0,0 +1,45 @@
+//go:build unix
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLockFileExcludesOtherHolders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles", "work", "config.json")
+
+	unlock, err := LockFile(path)
+	if err != nil {
+		t.Fatalf("LockFile: %v", err)
+	}
+
+	acquired := make(chan func())
+	go func() {
+		second, err := LockFile(path)
+		if err != nil {
+			t.Errorf("second LockFile: %v", err)
+			close(acquired)
+			return
+		}
+		acquired <- second
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second LockFile succeeded while the lock was held")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case second := <-acquired:
+		if second != nil {
+			second()
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("second LockFile did not acquire the lock after release")
+	}
+}
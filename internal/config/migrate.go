@@ -0,0 +1,151 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// CurrentSchemaVersion is the config layout written by this version of the
+// CLI. Files without a schema_version predate versioning.
+const CurrentSchemaVersion = 1
+
+// MigrationResult describes what Migrate changed, or would change, in one
+// profile's config file.
+type MigrationResult struct {
+	Profile string   `json:"profile"`
+	Path    string   `json:"path"`
+	Changes []string `json:"changes"`
+	Written bool     `json:"written"`
+}
+
+// Migrate upgrades the profile's config file to CurrentSchemaVersion. Unlike
+// SaveForProfile it edits the file's JSON object in place, so keys this
+// version does not know about are kept. The file is only rewritten when
+// something changed (and dryRun is false), so running it again is a no-op.
+// A missing file is left alone.
+func Migrate(profile string, dryRun bool) (MigrationResult, error) {
+	paths, err := PathsForProfile(profile)
+	if err != nil {
+		return MigrationResult{}, err
+	}
+	result := MigrationResult{Profile: paths.Profile, Path: paths.ConfigPath, Changes: []string{}}
+	if !dryRun {
+		unlock, err := LockFile(paths.ConfigPath)
+		if err != nil {
+			return result, err
+		}
+		defer unlock()
+	}
+
+	data, err := os.ReadFile(paths.ConfigPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return result, nil
+		}
+		return result, fmt.Errorf("read config: %w", err)
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return result, nil
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return result, fmt.Errorf("parse config: %w", err)
+	}
+	if raw == nil {
+		return result, fmt.Errorf("parse config: expected a JSON object")
+	}
+
+	result.Changes = migrateRaw(raw)
+	if len(result.Changes) == 0 || dryRun {
+		return result, nil
+	}
+
+	out, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return result, fmt.Errorf("marshal config: %w", err)
+	}
+	if err := writeConfigFile(paths.ConfigPath, append(out, '\n')); err != nil {
+		return result, err
+	}
+	result.Written = true
+	return result, nil
+}
+
+// migrateRaw applies each upgrade step to a decoded config file and returns
+// a description of every change made.
+func migrateRaw(raw map[string]any) []string {
+	var changes []string
+
+	if api, ok := raw["api"].(map[string]any); ok {
+		for _, key := range []string{"base_url", "notion_version", "token"} {
+			changes = append(changes, tidyString(api, key, "api.")...)
+		}
+		if s, ok := api["base_url"].(string); ok && strings.HasSuffix(s, "/") {
+			api["base_url"] = strings.TrimRight(s, "/")
+			changes = append(changes, "api.base_url: removed trailing slash")
+		}
+		if len(api) == 0 {
+			delete(raw, "api")
+			changes = append(changes, "api: removed empty section")
+		}
+	}
+	changes = append(changes, tidyString(raw, "default_parent", "")...)
+
+	if list, ok := raw["append_only_pages"].([]any); ok {
+		kept := make([]any, 0, len(list))
+		for _, v := range list {
+			s, isString := v.(string)
+			s = strings.TrimSpace(s)
+			if !isString || s == "" || slices.Contains(kept, any(s)) {
+				continue
+			}
+			kept = append(kept, s)
+		}
+		switch {
+		case len(kept) == 0:
+			delete(raw, "append_only_pages")
+			changes = append(changes, "append_only_pages: removed empty list")
+		case !slices.Equal(kept, list):
+			raw["append_only_pages"] = kept
+			changes = append(changes, "append_only_pages: removed invalid, blank, or duplicate entries")
+		}
+	}
+
+	if v, ok := raw["schema_version"].(float64); !ok || v < CurrentSchemaVersion {
+		raw["schema_version"] = CurrentSchemaVersion
+		changes = append(changes, fmt.Sprintf("schema_version: set to %d", CurrentSchemaVersion))
+	}
+	return changes
+}
+
+// tidyString trims surrounding whitespace from m[key] and removes the key
+// when it is empty or null, since the CLI treats both as unset.
+func tidyString(m map[string]any, key, prefix string) []string {
+	v, ok := m[key]
+	if !ok {
+		return nil
+	}
+	if v == nil {
+		delete(m, key)
+		return []string{prefix + key + ": removed null value"}
+	}
+	s, ok := v.(string)
+	if !ok {
+		return nil
+	}
+	trimmed := strings.TrimSpace(s)
+	switch {
+	case trimmed == "":
+		delete(m, key)
+		return []string{prefix + key + ": removed empty value"}
+	case trimmed != s:
+		m[key] = trimmed
+		return []string{prefix + key + ": trimmed whitespace"}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeRawConfig(t *testing.T, profile, content string) string {
+	t.Helper()
+	path, err := PathForProfile(profile)
+	if err != nil {
+		t.Fatalf("PathForProfile: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func TestMigrateUpgradesAndPreservesUnknownFields(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := writeRawConfig(t, "", `{
+  "api": {"base_url": " https://proxy.example.com/v1/ ", "token": "", "extra": true},
+  "default_parent": "  abc  ",
+  "append_only_pages": ["p1", "p1", " ", "p2"],
+  "custom": {"nested": 1}
+}`)
+
+	result, err := Migrate("", false)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if !result.Written || result.Path != path {
+		t.Fatalf("result = %+v, want written to %s", result, path)
+	}
+	want := []string{
+		"api.base_url: trimmed whitespace",
+		"api.token: removed empty value",
+		"api.base_url: removed trailing slash",
+		"default_parent: trimmed whitespace",
+		"append_only_pages: removed invalid, blank, or duplicate entries",
+		"schema_version: set to 1",
+	}
+	if !slices.Equal(result.Changes, want) {
+		t.Fatalf("changes = %q, want %q", result.Changes, want)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("parse migrated config: %v", err)
+	}
+	if _, ok := raw["custom"].(map[string]any); !ok {
+		t.Fatalf("unknown top-level field dropped: %s", data)
+	}
+	api := raw["api"].(map[string]any)
+	if api["extra"] != true || api["base_url"] != "https://proxy.example.com/v1" {
+		t.Fatalf("api = %v", api)
+	}
+	if _, ok := api["token"]; ok {
+		t.Fatalf("empty token kept: %v", api)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.SchemaVersion != CurrentSchemaVersion || cfg.DefaultParent != "abc" || !slices.Equal(cfg.AppendOnlyPages, []string{"p1", "p2"}) {
+		t.Fatalf("loaded config = %+v", cfg)
+	}
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := writeRawConfig(t, "work", `{"api": {"base_url": "https://api.notion.com/v1/"}}`)
+
+	if _, err := Migrate("work", false); err != nil {
+		t.Fatalf("first Migrate: %v", err)
+	}
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+
+	result, err := Migrate("work", false)
+	if err != nil {
+		t.Fatalf("second Migrate: %v", err)
+	}
+	if result.Written || len(result.Changes) != 0 {
+		t.Fatalf("second run = %+v, want no changes", result)
+	}
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if string(before) != string(after) {
+		t.Fatalf("second run rewrote config:\n%s\nvs\n%s", before, after)
+	}
+}
+
+func TestMigrateDryRunLeavesFileAlone(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	content := `{"audit_log": true}`
+	path := writeRawConfig(t, "", content)
+
+	result, err := Migrate("", true)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if result.Written || len(result.Changes) != 1 {
+		t.Fatalf("result = %+v, want one unwritten change", result)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if string(data) != content {
+		t.Fatalf("dry run modified config: %s", data)
+	}
+}
+
+func TestMigrateMissingFileIsNoop(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	result, err := Migrate("", false)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if result.Written || len(result.Changes) != 0 {
+		t.Fatalf("result = %+v, want no-op", result)
+	}
+	if _, err := os.Stat(result.Path); !os.IsNotExist(err) {
+		t.Fatalf("missing config was created: %v", err)
+	}
+}
@@ -209,6 +209,9 @@ type FetchResult struct {
 	Content string
 	Title   string
 	URL     string
+
+	// ToolResult is the unprocessed notion-fetch tool result.
+	ToolResult *mcp.CallToolResult
 }
 
 type fetchResponse struct {
@@ -241,10 +244,10 @@ func (c *Client) fetch(ctx context.Context, id string, includeDiscussions bool)
 
 	var resp fetchResponse
 	if err := json.Unmarshal([]byte(text), &resp); err == nil && resp.Text != "" {
-		return &FetchResult{Content: resp.Text, Title: resp.Title, URL: resp.URL}, nil
+		return &FetchResult{Content: resp.Text, Title: resp.Title, URL: resp.URL, ToolResult: result}, nil
 	}
 
-	return &FetchResult{Content: text}, nil
+	return &FetchResult{Content: text, ToolResult: result}, nil
 }
 
 func buildFetchToolArgs(id string, includeDiscussions bool) map[string]any {
@@ -262,7 +265,21 @@ type CreatePageRequest struct {
 	ParentDatabaseID string
 	Title            string
 	Content          string
-	Properties       map[string]string
+	Properties       map[string]any
+
+	// Icon (an emoji or image URL) and Cover (an image URL) are set as
+	// part of the create call, so the page never exists without them.
+	Icon  string
+	Cover string
+
+	// TitleProperty is the name of the parent database's title property.
+	// It defaults to "title".
+	TitleProperty string
+
+	// Workspace creates the page at the top level of the workspace. That is
+	// also what happens when no parent is set; this makes it explicit and
+	// rules out a parent being set as well.
+	Workspace bool
 }
 
 type CreatePageResponse struct {
@@ -271,11 +288,46 @@ type CreatePageResponse struct {
 }
 
 func (c *Client) CreatePage(ctx context.Context, req CreatePageRequest) (*CreatePageResponse, error) {
+	args, err := buildCreatePageToolArgs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.CallTool(ctx, "notion-create-pages", args)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkToolError(result); err != nil {
+		return nil, err
+	}
+
+	text := extractText(result)
+
+	var resp CreatePageResponse
+	if err := json.Unmarshal([]byte(text), &resp); err == nil && resp.URL != "" {
+		return &resp, nil
+	}
+
+	url := extractURLFromText(text)
+	return &CreatePageResponse{URL: url}, nil
+}
+
+// buildCreatePageToolArgs builds the notion-create-pages arguments for req.
+// Without a parent the tool creates a private page at the workspace root.
+func buildCreatePageToolArgs(req CreatePageRequest) (map[string]any, error) {
+	if req.Workspace && (req.ParentPageID != "" || req.ParentDatabaseID != "") {
+		return nil, fmt.Errorf("a workspace-level page cannot also have a parent page or database")
+	}
+
 	props := map[string]any{}
 	for k, v := range req.Properties {
 		props[k] = v
 	}
-	props["title"] = req.Title
+	titleProperty := req.TitleProperty
+	if titleProperty == "" {
+		titleProperty = "title"
+	}
+	props[titleProperty] = req.Title
 
 	pageSpec := map[string]any{
 		"properties": props,
@@ -284,6 +336,12 @@ func (c *Client) CreatePage(ctx context.Context, req CreatePageRequest) (*Create
 	if req.Content != "" {
 		pageSpec["content"] = req.Content
 	}
+	if req.Icon != "" {
+		pageSpec["icon"] = req.Icon
+	}
+	if req.Cover != "" {
+		pageSpec["cover"] = req.Cover
+	}
 
 	args := map[string]any{
 		"pages": []any{pageSpec},
@@ -298,24 +356,7 @@ func (c *Client) CreatePage(ctx context.Context, req CreatePageRequest) (*Create
 			"data_source_id": req.ParentDatabaseID,
 		}
 	}
-
-	result, err := c.CallTool(ctx, "notion-create-pages", args)
-	if err != nil {
-		return nil, err
-	}
-	if err := checkToolError(result); err != nil {
-		return nil, err
-	}
-
-	text := extractText(result)
-
-	var resp CreatePageResponse
-	if err := json.Unmarshal([]byte(text), &resp); err == nil && resp.URL != "" {
-		return &resp, nil
-	}
-
-	url := extractURLFromText(text)
-	return &CreatePageResponse{URL: url}, nil
+	return args, nil
 }
 
 func extractURLFromText(text string) string {
@@ -338,15 +379,24 @@ func (c *Client) ResolveDataSourceID(ctx context.Context, id string) (string, er
 		return id, nil // assume it's already a data source ID
 	}
 
-	// Look for collection://UUID pattern in the content
-	re := regexp.MustCompile(`collection://([a-fA-F0-9-]{32,36})`)
-	if m := re.FindStringSubmatch(result.Content); m != nil {
-		return m[1], nil
+	if dsID := DataSourceIDFromContent(result.Content); dsID != "" {
+		return dsID, nil
 	}
 
 	return id, nil // fallback to original ID
 }
 
+var dataSourceURLRe = regexp.MustCompile(`collection://([a-fA-F0-9-]{32,36})`)
+
+// DataSourceIDFromContent returns the first data source ID referenced by a
+// collection:// URL in fetched database content, or "" if there is none.
+func DataSourceIDFromContent(content string) string {
+	if m := dataSourceURLRe.FindStringSubmatch(content); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
 type UpdatePageRequest struct {
 	PageID  string
 	Command string // "replace_content", "update_content", "insert_content_after", "update_properties", "apply_template", "update_verification"
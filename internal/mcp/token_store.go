@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
@@ -69,6 +70,12 @@ func (s *FileTokenStore) SaveToken(ctx context.Context, token *transport.Token)
 	if err := os.MkdirAll(dir, 0700); err != nil {
 		return err
 	}
+	// Other notion-cli processes may be refreshing the same token.
+	unlock, err := config.LockFile(s.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	// Preserve existing client_id if present
 	var existing storedToken
@@ -90,13 +97,22 @@ func (s *FileTokenStore) SaveToken(ctx context.Context, token *transport.Token)
 		return err
 	}
 
-	return os.WriteFile(s.path, data, 0600)
+	return writeTokenFile(s.path, data)
 }
 
 func (s *FileTokenStore) Clear() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if _, err := os.Stat(filepath.Dir(s.path)); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	unlock, err := config.LockFile(s.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
 		return err
 	}
@@ -152,6 +168,12 @@ func (s *FileTokenStore) SaveClientID(ctx context.Context, clientID string) erro
 	if err := os.MkdirAll(dir, 0700); err != nil {
 		return err
 	}
+	// Other notion-cli processes may be refreshing the same token.
+	unlock, err := config.LockFile(s.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	var stored storedToken
 	data, err := os.ReadFile(s.path)
@@ -166,5 +188,38 @@ func (s *FileTokenStore) SaveClientID(ctx context.Context, clientID string) erro
 		return err
 	}
 
-	return os.WriteFile(s.path, data, 0600)
+	return writeTokenFile(s.path, data)
+}
+
+// writeTokenFile atomically replaces the token file at path with data, so an
+// interrupted write never leaves a truncated token behind. The file stays
+// private to the user.
+func writeTokenFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp token: %w", err)
+	}
+
+	tmpPath := tmp.Name()
+	cleanup := func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		cleanup()
+		return fmt.Errorf("secure temp token: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		cleanup()
+		return fmt.Errorf("write temp token: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		cleanup()
+		return fmt.Errorf("close temp token: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		cleanup()
+		return fmt.Errorf("replace token: %w", err)
+	}
+	return nil
 }
@@ -1,9 +1,15 @@
 package mcp
 
 import (
+	"context"
+	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+
+	"github.com/mark3labs/mcp-go/client/transport"
 )
 
 func TestNewFileTokenStoreUsesProfilePath(t *testing.T) {
@@ -21,3 +27,118 @@ func TestNewFileTokenStoreUsesProfilePath(t *testing.T) {
 		t.Fatalf("token path = %q, want profiles/work segment", store.Path())
 	}
 }
+
+func TestSaveTokenConcurrentWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+
+	// Separate stores don't share a mutex, like separate processes.
+	const writers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*10)
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store := &FileTokenStore{path: path}
+			for j := range 10 {
+				token := &transport.Token{AccessToken: fmt.Sprintf("token-%d-%d-%s", i, j, strings.Repeat("x", 4096))}
+				if err := store.SaveToken(context.Background(), token); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("SaveToken: %v", err)
+	}
+
+	token, err := (&FileTokenStore{path: path}).GetToken(context.Background())
+	if err != nil {
+		t.Fatalf("GetToken after concurrent writes: %v", err)
+	}
+	if !strings.HasPrefix(token.AccessToken, "token-") {
+		t.Fatalf("AccessToken = %.20q, want one of the written tokens", token.AccessToken)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Fatalf("token perm = %o, want 600", perm)
+	}
+	leftovers, err := filepath.Glob(path + ".*.tmp")
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+	if len(leftovers) != 0 {
+		t.Fatalf("temp files left behind: %v", leftovers)
+	}
+}
+
+func TestSaveTokenAndClientIDDoNotLoseUpdates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+
+	// SaveToken keeps the stored client ID and SaveClientID keeps the token,
+	// so whatever order these land in, both must survive.
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			token := &transport.Token{AccessToken: fmt.Sprintf("token-%d", i)}
+			if err := (&FileTokenStore{path: path}).SaveToken(context.Background(), token); err != nil {
+				t.Errorf("SaveToken: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if err := (&FileTokenStore{path: path}).SaveClientID(context.Background(), fmt.Sprintf("client-%d", i)); err != nil {
+				t.Errorf("SaveClientID: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	store := &FileTokenStore{path: path}
+	token, err := store.GetToken(context.Background())
+	if err != nil {
+		t.Fatalf("GetToken: %v", err)
+	}
+	clientID, err := store.GetClientID(context.Background())
+	if err != nil {
+		t.Fatalf("GetClientID: %v", err)
+	}
+	if token.AccessToken == "" || clientID == "" {
+		t.Fatalf("lost update: token = %q, client ID = %q", token.AccessToken, clientID)
+	}
+
+	if err := store.Clear(); err != nil {
+		t.Fatalf("Clear: %v", err)
+	}
+	if _, err := store.GetToken(context.Background()); err != ErrNoToken {
+		t.Fatalf("GetToken after Clear = %v, want ErrNoToken", err)
+	}
+}
+
+func TestGetTokenIgnoresInterruptedWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	store := &FileTokenStore{path: path}
+	if err := store.SaveToken(context.Background(), &transport.Token{AccessToken: "good"}); err != nil {
+		t.Fatalf("SaveToken: %v", err)
+	}
+
+	// A crash mid-write leaves a truncated temp file, never a truncated token.
+	if err := os.WriteFile(path+".123.tmp", []byte(`{"access_tok`), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	token, err := store.GetToken(context.Background())
+	if err != nil {
+		t.Fatalf("GetToken: %v", err)
+	}
+	if token.AccessToken != "good" {
+		t.Fatalf("AccessToken = %q, want good", token.AccessToken)
+	}
+}
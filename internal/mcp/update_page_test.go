@@ -107,3 +107,39 @@ func TestBuildUpdatePageToolArgsInsertContentAfter(t *testing.T) {
 		t.Fatalf("unexpected args\nwant: %#v\ngot:  %#v", want, got)
 	}
 }
+
+func TestBuildCreatePageToolArgsWorkspace(t *testing.T) {
+	got, err := buildCreatePageToolArgs(CreatePageRequest{Title: "Top Level", Workspace: true})
+	if err != nil {
+		t.Fatalf("buildCreatePageToolArgs: %v", err)
+	}
+	want := map[string]any{
+		"pages": []any{map[string]any{
+			"properties": map[string]any{"title": "Top Level"},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected args\nwant: %#v\ngot:  %#v", want, got)
+	}
+
+	if _, err := buildCreatePageToolArgs(CreatePageRequest{Title: "T", Workspace: true, ParentPageID: "page-123"}); err == nil {
+		t.Fatal("expected an error for a workspace page with a parent")
+	}
+}
+
+func TestBuildCreatePageToolArgsIconAndCover(t *testing.T) {
+	got, err := buildCreatePageToolArgs(CreatePageRequest{Title: "T", Icon: "🚀", Cover: "https://example.com/c.png"})
+	if err != nil {
+		t.Fatalf("buildCreatePageToolArgs: %v", err)
+	}
+	want := map[string]any{
+		"pages": []any{map[string]any{
+			"properties": map[string]any{"title": "T"},
+			"icon":       "🚀",
+			"cover":      "https://example.com/c.png",
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected args\nwant: %#v\ngot:  %#v", want, got)
+	}
+}
@@ -0,0 +1,86 @@
+package output
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+// calloutColors maps Notion's callout colors to the terminal color of the
+// bar drawn before the callout, so a red warning stands apart from a gray
+// aside. The default color has no bar.
+var calloutColors = []struct {
+	name string
+	attr color.Attribute
+}{
+	{"gray", color.FgHiBlack},
+	{"brown", color.FgYellow},
+	{"orange", color.FgHiYellow},
+	{"yellow", color.FgYellow},
+	{"green", color.FgGreen},
+	{"blue", color.FgBlue},
+	{"purple", color.FgMagenta},
+	{"pink", color.FgHiMagenta},
+	{"red", color.FgRed},
+}
+
+// calloutMarkerBase is the first of the private-use runes standing in for
+// a callout's color while the markdown goes through glamour, which would
+// mangle ANSI escapes written into its input.
+const calloutMarkerBase = '\uE000'
+
+// calloutColorMarker returns the marker for a Notion color attribute such
+// as "red_bg" or "blue", or "" for the default or an unknown color.
+func calloutColorMarker(notionColor string) string {
+	name := strings.TrimSuffix(strings.TrimSuffix(notionColor, "_background"), "_bg")
+	for i, c := range calloutColors {
+		if c.name == name {
+			return string(calloutMarkerBase+rune(i)) + " "
+		}
+	}
+	return ""
+}
+
+// colorCalloutMarkers replaces callout markers in rendered output with a
+// colored bar, or removes them, and the space after them, when colored is
+// false.
+func colorCalloutMarkers(s string, colored bool) string {
+	if !strings.ContainsFunc(s, isCalloutMarker) {
+		return s
+	}
+	var b strings.Builder
+	skipSpace := false
+	for _, r := range s {
+		switch {
+		case isCalloutMarker(r) && colored:
+			b.WriteString(color.New(calloutColors[r-calloutMarkerBase].attr).Sprint("▌"))
+		case isCalloutMarker(r):
+			skipSpace = true
+		case skipSpace && r == ' ':
+			skipSpace = false
+		default:
+			skipSpace = false
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// stripCalloutMarker removes the color marker from the start of a callout's
+// first line, "> " followed by the marker and a space.
+func stripCalloutMarker(line string) string {
+	rest, ok := strings.CutPrefix(line, "> ")
+	if !ok {
+		return line
+	}
+	r, size := utf8.DecodeRuneInString(rest)
+	if !isCalloutMarker(r) {
+		return line
+	}
+	return "> " + strings.TrimPrefix(rest[size:], " ")
+}
+
+func isCalloutMarker(r rune) bool {
+	return r >= calloutMarkerBase && r < calloutMarkerBase+rune(len(calloutColors))
+}
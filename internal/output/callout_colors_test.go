@@ -0,0 +1,60 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCalloutColors(t *testing.T) {
+	content := "<callout icon=\"⚠️\" color=\"red_bg\">\n\tDo not deploy on Friday\n</callout>\n" +
+		"<callout icon=\"💡\" color=\"gray_bg\">\n\tAn aside\n</callout>\n" +
+		"<callout icon=\"📌\">\n\tPlain\n</callout>"
+	md, _ := notionToMarkdownWithComments(content, nil, true)
+	if strings.Count(md, "> "+calloutColorMarker("red")+"⚠️ ") != 1 {
+		t.Fatalf("missing red marker:\n%q", md)
+	}
+	if strings.Count(md, "> "+calloutColorMarker("gray_background")+"💡 ") != 1 {
+		t.Fatalf("missing gray marker:\n%q", md)
+	}
+	if !strings.Contains(md, "> 📌 ") {
+		t.Fatalf("default callout changed:\n%q", md)
+	}
+	if stripCalloutMarker("> "+calloutColorMarker("red_bg")+"⚠️ x") != "> ⚠️ x" {
+		t.Fatalf("stripCalloutMarker did not remove the marker")
+	}
+
+	colored := colorCalloutMarkers(md, true)
+	if !strings.Contains(colored, "▌") || strings.ContainsFunc(colored, isCalloutMarker) {
+		t.Fatalf("colored output = %q", colored)
+	}
+	plain := colorCalloutMarkers(md, false)
+	if !strings.Contains(plain, "> ⚠️ \n") || strings.Contains(plain, "▌") || strings.ContainsFunc(plain, isCalloutMarker) {
+		t.Fatalf("plain output = %q", plain)
+	}
+}
+
+func TestPlainRenderDropsCalloutMarkers(t *testing.T) {
+	r, err := NewPlainMarkdownRenderer()
+	if err != nil {
+		t.Fatalf("NewPlainMarkdownRenderer: %v", err)
+	}
+	md, _ := notionToMarkdownWithComments("<callout icon=\"⚠️\" color=\"yellow_bg\">\n\tCareful\n</callout>", nil, true)
+	out, err := r.Render(md)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if !strings.Contains(out, "| ⚠️\n") || strings.ContainsFunc(out, isCalloutMarker) {
+		t.Fatalf("output = %q", out)
+	}
+}
+
+func TestPageMarkdownHasNoCalloutMarkers(t *testing.T) {
+	content := "<page url=\"https://www.notion.so/p1\">\n<content>\n<callout icon=\"⚠️\" color=\"red_bg\">\n\tCareful\n</callout>\n<columns>\n<column>\n<callout icon=\"💡\" color=\"blue_bg\">Tip</callout>\n</column>\n</columns>\n</content>\n</page>"
+	md := PageMarkdown(content)
+	if !strings.Contains(md, "> ⚠️ ") || !strings.Contains(md, "> 💡 Tip") {
+		t.Fatalf("callouts missing from exported markdown:\n%q", md)
+	}
+	if strings.ContainsFunc(md, isCalloutMarker) {
+		t.Fatalf("exported markdown contains callout markers:\n%q", md)
+	}
+}
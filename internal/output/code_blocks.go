@@ -0,0 +1,138 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// codeBlock is a fenced code block lifted out of Notion content before HTML
+// parsing, so its body is not mangled as markup and its language survives.
+type codeBlock struct {
+	Language string
+	Lines    []string
+	Caption  string
+}
+
+var (
+	codeBlockPlaceholderRe = regexp.MustCompile(`NOTIONCLICODEBLOCK(\d+)X`)
+	codeCaptionRe          = regexp.MustCompile(`^\s*<caption>(.*)</caption>\s*$`)
+)
+
+// notionCodeLanguages maps Notion's code block language names to the lexer
+// names glamour (chroma) understands.
+var notionCodeLanguages = map[string]string{
+	"plain text":    "text",
+	"c++":           "cpp",
+	"c#":            "csharp",
+	"f#":            "fsharp",
+	"objective-c":   "objectivec",
+	"visual basic":  "vbnet",
+	"markup":        "html",
+	"java/c/c++/c#": "java",
+	"shell":         "sh",
+}
+
+func normalizeCodeLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if mapped, ok := notionCodeLanguages[lang]; ok {
+		return mapped
+	}
+	return strings.ReplaceAll(lang, " ", "")
+}
+
+// extractCodeBlocks replaces fenced code blocks with placeholder lines and
+// returns the blocks in order. Placeholders drop the block's indentation so
+// nested code does not turn into an indented markdown code block. A
+// <caption> line directly after the closing fence is attached to the block.
+func extractCodeBlocks(content string) (string, []codeBlock) {
+	lines := strings.Split(content, "\n")
+	var out []string
+	var blocks []codeBlock
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimLeft(line, " \t")
+		fence := ""
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			fence = "```"
+		case strings.HasPrefix(trimmed, "~~~"):
+			fence = "~~~"
+		}
+		if fence == "" {
+			out = append(out, line)
+			continue
+		}
+
+		end := -1
+		for j := i + 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == fence {
+				end = j
+				break
+			}
+		}
+		if end == -1 {
+			out = append(out, line)
+			continue
+		}
+
+		indent := line[:len(line)-len(trimmed)]
+		block := codeBlock{Language: normalizeCodeLanguage(strings.TrimPrefix(trimmed, fence))}
+		for _, codeLine := range lines[i+1 : end] {
+			block.Lines = append(block.Lines, strings.TrimPrefix(codeLine, indent))
+		}
+		if end+1 < len(lines) {
+			if match := codeCaptionRe.FindStringSubmatch(lines[end+1]); match != nil {
+				block.Caption = strings.TrimSpace(match[1])
+				end++
+			}
+		}
+
+		out = append(out, fmt.Sprintf("NOTIONCLICODEBLOCK%dX", len(blocks)))
+		blocks = append(blocks, block)
+		i = end
+	}
+
+	return strings.Join(out, "\n"), blocks
+}
+
+// restoreCodeBlocks swaps placeholders back for fenced code. Any text before
+// the placeholder on its line (such as a "> " quote prefix) is repeated on
+// every line of the block.
+func restoreCodeBlocks(rendered string, blocks []codeBlock) string {
+	if len(blocks) == 0 {
+		return rendered
+	}
+
+	lines := strings.Split(rendered, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		loc := codeBlockPlaceholderRe.FindStringSubmatchIndex(line)
+		if loc == nil {
+			out = append(out, line)
+			continue
+		}
+		var idx int
+		_, _ = fmt.Sscanf(line[loc[2]:loc[3]], "%d", &idx)
+		if idx < 0 || idx >= len(blocks) {
+			out = append(out, line)
+			continue
+		}
+
+		prefix := line[:loc[0]]
+		block := blocks[idx]
+		out = append(out, prefix+"```"+block.Language)
+		for _, codeLine := range block.Lines {
+			out = append(out, prefix+codeLine)
+		}
+		out = append(out, prefix+"```")
+		if block.Caption != "" {
+			out = append(out, prefix, prefix+"*"+block.Caption+"*")
+		}
+		if rest := strings.TrimSpace(line[loc[1]:]); rest != "" {
+			out = append(out, prefix+rest)
+		}
+	}
+	return strings.Join(out, "\n")
+}
@@ -1,6 +1,10 @@
 package output
 
-import "testing"
+import (
+	"bytes"
+	"testing"
+	"time"
+)
 
 func TestCommentAuthorName(t *testing.T) {
 	if got := commentAuthorName(Comment{CreatedBy: "user-123", CreatedByName: "Person Example"}); got != "Person Example" {
@@ -19,3 +23,28 @@ func TestCommentStatusLabel(t *testing.T) {
 		t.Fatalf("expected empty label, got %q", got)
 	}
 }
+
+func TestPrintCommentThreadsGroupsRepliesUnderDiscussion(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	comments := []Comment{
+		{DiscussionID: "d1", Context: "Launch date", CreatedByName: "Alice", CreatedTime: created, Content: "Is this final?"},
+		{DiscussionID: "d2", CreatedByName: "Carol", Resolved: true, Content: "Typo fixed"},
+		{DiscussionID: "d1", CreatedByName: "Bob", Content: "Yes.\nShipping Monday."},
+	}
+
+	var buf bytes.Buffer
+	printCommentThreads(&buf, comments)
+
+	want := "On: Launch date\n" +
+		"Alice · " + formatTime(created) + "\n" +
+		"Is this final?\n" +
+		"  ↳ Bob\n" +
+		"    Yes.\n" +
+		"    Shipping Monday.\n" +
+		"\n" +
+		"Carol · resolved\n" +
+		"Typo fixed\n"
+	if buf.String() != want {
+		t.Fatalf("output =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,97 @@
+package output
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DatabaseSchema is the structure of a database parsed from a fetch response.
+type DatabaseSchema struct {
+	Columns []DatabaseColumn `json:"columns"`
+	Views   []DatabaseView   `json:"views"`
+
+	HasSchema bool `json:"-"`
+	HasViews  bool `json:"-"`
+}
+
+type DatabaseColumn struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Options []string `json:"options,omitempty"`
+}
+
+type DatabaseView struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+var databaseViewRe = regexp.MustCompile(`<view url="[^"]*">`)
+
+// ParseDatabaseSchema extracts columns (sorted by name) and views from the
+// <data-source-state> and <views> sections of a database fetch.
+func ParseDatabaseSchema(content string) DatabaseSchema {
+	schema := DatabaseSchema{
+		Columns: []DatabaseColumn{},
+		Views:   []DatabaseView{},
+	}
+
+	if start := strings.Index(content, "<data-source-state>"); start != -1 {
+		if end := strings.Index(content[start:], "</data-source-state>"); end != -1 {
+			stateJSON := strings.TrimSpace(content[start+len("<data-source-state>") : start+end])
+			var state struct {
+				Name   string `json:"name"`
+				Schema map[string]struct {
+					Name    string `json:"name"`
+					Type    string `json:"type"`
+					Options []struct {
+						Name string `json:"name"`
+					} `json:"options,omitempty"`
+				} `json:"schema"`
+			}
+			if err := json.Unmarshal([]byte(stateJSON), &state); err == nil {
+				schema.HasSchema = true
+				keys := make([]string, 0, len(state.Schema))
+				for key := range state.Schema {
+					keys = append(keys, key)
+				}
+				sort.Strings(keys)
+				for _, key := range keys {
+					prop := state.Schema[key]
+					col := DatabaseColumn{Name: prop.Name, Type: prop.Type}
+					if col.Name == "" {
+						col.Name = key
+					}
+					for _, opt := range prop.Options {
+						col.Options = append(col.Options, opt.Name)
+					}
+					schema.Columns = append(schema.Columns, col)
+				}
+			}
+		}
+	}
+
+	if strings.Contains(content, "<views>") {
+		schema.HasViews = true
+		for _, loc := range databaseViewRe.FindAllStringIndex(content, -1) {
+			start := loc[1]
+			end := strings.Index(content[start:], "</view>")
+			if end == -1 {
+				continue
+			}
+			var view DatabaseView
+			if err := json.Unmarshal([]byte(strings.TrimSpace(content[start:start+end])), &view); err == nil {
+				schema.Views = append(schema.Views, view)
+			}
+		}
+	}
+
+	return schema
+}
+
+// DatabaseTitle returns the database title announced in a fetch response.
+func DatabaseTitle(content string) string {
+	meta, _ := parseNotionResponse(content)
+	return meta.Title
+}
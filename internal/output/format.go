@@ -1,19 +1,25 @@
 package output
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
 )
 
 func PrintPages(pages []Page, asJSON bool) error {
+	if asJSON && pageOutput != PageOutputJSON {
+		return writePageFields(os.Stdout, pages, pageOutput)
+	}
 	if asJSON {
 		return printJSON(pages)
 	}
+	if listTemplate != nil {
+		return printTemplated(pages)
+	}
 
 	if len(pages) == 0 {
 		fmt.Println("No pages found.")
@@ -34,6 +40,9 @@ func PrintPages(pages []Page, asJSON bool) error {
 }
 
 func PrintPage(page Page, asJSON bool) error {
+	if asJSON && pageOutput != PageOutputJSON {
+		return writePageFields(os.Stdout, []Page{page}, pageOutput)
+	}
 	if asJSON {
 		return printJSON(page)
 	}
@@ -89,10 +98,13 @@ func PrintViewedPage(page Page, comments []Comment, asJSON bool) error {
 	return RenderPageWithComments(page.Content, comments)
 }
 
+// PrintPlainViewedPage prints a page without ANSI styling or wrapping.
+func PrintPlainViewedPage(page Page, comments []Comment) error {
+	return RenderPlainPageWithComments(page.Content, comments)
+}
+
 func printPageViewJSON(w io.Writer, page Page, comments []Comment) error {
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
-	return enc.Encode(pageViewJSON{Page: page, Comments: comments})
+	return WriteStructured(w, pageViewJSON{Page: page, Comments: comments})
 }
 
 func PrintDatabases(dbs []Database, asJSON bool) error {
@@ -118,10 +130,45 @@ func PrintDatabases(dbs []Database, asJSON bool) error {
 	return nil
 }
 
+// PrintBlocks lists blocks one per line as type, full ID, and a text
+// snippet, indenting nested children. A trailing "+" marks blocks with
+// children that were not listed.
+func PrintBlocks(blocks []Block, asJSON bool) error {
+	if asJSON {
+		return printJSON(blocks)
+	}
+
+	if len(blocks) == 0 {
+		fmt.Println("No child blocks found.")
+		return nil
+	}
+	printBlockTree(os.Stdout, blocks, 0)
+	return nil
+}
+
+func printBlockTree(w io.Writer, blocks []Block, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, b := range blocks {
+		typ := b.Type
+		if b.HasChildren && len(b.Children) == 0 {
+			typ += "+"
+		}
+		line := fmt.Sprintf("%s%-20s %s", indent, typ, b.ID)
+		if text := strings.Join(strings.Fields(b.Text), " "); text != "" {
+			line += "  " + Truncate(text, 60)
+		}
+		_, _ = fmt.Fprintln(w, line)
+		printBlockTree(w, b.Children, depth+1)
+	}
+}
+
 func PrintSearchResults(results []SearchResult, asJSON bool) error {
 	if asJSON {
 		return printJSON(results)
 	}
+	if listTemplate != nil {
+		return printTemplated(results)
+	}
 
 	if len(results) == 0 {
 		fmt.Println("No results found.")
@@ -152,36 +199,78 @@ func PrintComments(comments []Comment, asJSON bool) error {
 		return nil
 	}
 
+	printCommentThreads(color.Output, comments)
+	return nil
+}
+
+// printCommentThreads prints comments grouped by discussion, in the order
+// each discussion first appears. The first comment of a discussion starts
+// the thread and later comments are indented beneath it as replies.
+func printCommentThreads(w io.Writer, comments []Comment) {
 	authorStyle := color.New(color.Bold)
 	contextStyle := color.New(color.Faint)
 	timeStyle := color.New(color.Faint)
 
-	for i, c := range comments {
+	for i, thread := range commentThreads(comments) {
 		if i > 0 {
-			fmt.Println()
+			_, _ = fmt.Fprintln(w)
 		}
 
-		if c.Context != "" {
-			_, _ = contextStyle.Printf("On: %s\n", c.Context)
+		if thread[0].Context != "" {
+			_, _ = contextStyle.Fprintf(w, "On: %s\n", thread[0].Context)
 		}
 
-		_, _ = authorStyle.Print(commentAuthorName(c))
-		timeLabel := formatTime(c.CreatedTime)
-		statusLabel := commentStatusLabel(c)
-		switch {
-		case timeLabel != "" && statusLabel != "":
-			_, _ = timeStyle.Printf(" · %s · %s\n", timeLabel, statusLabel)
-		case timeLabel != "":
-			_, _ = timeStyle.Printf(" · %s\n", timeLabel)
-		case statusLabel != "":
-			_, _ = timeStyle.Printf(" · %s\n", statusLabel)
-		default:
-			fmt.Println()
+		for j, c := range thread {
+			indent := ""
+			if j > 0 {
+				indent = "  "
+				_, _ = timeStyle.Fprint(w, indent+"↳ ")
+			}
+
+			_, _ = authorStyle.Fprint(w, commentAuthorName(c))
+			labels := make([]string, 0, 2)
+			if t := formatTime(c.CreatedTime); t != "" {
+				labels = append(labels, t)
+			}
+			if j == 0 {
+				if status := commentStatusLabel(c); status != "" {
+					labels = append(labels, status)
+				}
+			}
+			if len(labels) > 0 {
+				_, _ = timeStyle.Fprintf(w, " · %s", strings.Join(labels, " · "))
+			}
+			_, _ = fmt.Fprintln(w)
+
+			if j > 0 {
+				indent = "    "
+			}
+			for _, line := range strings.Split(c.Content, "\n") {
+				_, _ = fmt.Fprintln(w, strings.TrimRight(indent+line, " "))
+			}
 		}
-		fmt.Println(c.Content)
 	}
+}
 
-	return nil
+// commentThreads groups comments by discussion ID, keeping the order in
+// which discussions and comments appear. Comments without a discussion ID
+// are treated as their own thread.
+func commentThreads(comments []Comment) [][]Comment {
+	var threads [][]Comment
+	index := make(map[string]int)
+	for _, c := range comments {
+		if c.DiscussionID == "" {
+			threads = append(threads, []Comment{c})
+			continue
+		}
+		if i, ok := index[c.DiscussionID]; ok {
+			threads[i] = append(threads[i], c)
+			continue
+		}
+		index[c.DiscussionID] = len(threads)
+		threads = append(threads, []Comment{c})
+	}
+	return threads
 }
 
 func commentAuthorName(c Comment) string {
@@ -229,12 +318,6 @@ func (e *UserError) Error() string {
 	return e.Message
 }
 
-func printJSON(v any) error {
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(v)
-}
-
 func formatTime(t time.Time) string {
 	if t.IsZero() {
 		return ""
@@ -6,6 +6,7 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/glamour"
 	"github.com/fatih/color"
@@ -14,6 +15,7 @@ import (
 
 type MarkdownRenderer struct {
 	renderer *glamour.TermRenderer
+	plain    bool
 }
 
 func NewMarkdownRenderer() (*MarkdownRenderer, error) {
@@ -36,6 +38,20 @@ func NewMarkdownRenderer() (*MarkdownRenderer, error) {
 	return &MarkdownRenderer{renderer: r}, nil
 }
 
+// NewPlainMarkdownRenderer returns a renderer that uses glamour's notty
+// style and never wraps, so output contains no ANSI escapes even on a TTY.
+func NewPlainMarkdownRenderer() (*MarkdownRenderer, error) {
+	r, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle("notty"),
+		glamour.WithWordWrap(0),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating markdown renderer: %w", err)
+	}
+
+	return &MarkdownRenderer{renderer: r, plain: true}, nil
+}
+
 func (m *MarkdownRenderer) Render(content string) (string, error) {
 	content = preprocessNotionMarkdown(content)
 
@@ -44,9 +60,21 @@ func (m *MarkdownRenderer) Render(content string) (string, error) {
 		return "", fmt.Errorf("rendering markdown: %w", err)
 	}
 
+	out = colorCalloutMarkers(out, !m.plain && !color.NoColor)
+	if m.plain {
+		out = trimTrailingWhitespace(out)
+	}
 	return strings.TrimSpace(out), nil
 }
 
+func trimTrailingWhitespace(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (m *MarkdownRenderer) RenderAndPrint(content string) error {
 	out, err := m.Render(content)
 	if err != nil {
@@ -63,9 +91,10 @@ func preprocessNotionMarkdown(content string) string {
 	calloutContent := []string{}
 
 	for _, line := range lines {
-		if strings.HasPrefix(line, "> ℹ️") || strings.HasPrefix(line, "> ⚠️") ||
-			strings.HasPrefix(line, "> 💡") || strings.HasPrefix(line, "> 📌") ||
-			strings.HasPrefix(line, "> ❗") || strings.HasPrefix(line, "> 🔥") {
+		probe := stripCalloutMarker(line)
+		if strings.HasPrefix(probe, "> ℹ️") || strings.HasPrefix(probe, "> ⚠️") ||
+			strings.HasPrefix(probe, "> 💡") || strings.HasPrefix(probe, "> 📌") ||
+			strings.HasPrefix(probe, "> ❗") || strings.HasPrefix(probe, "> 🔥") {
 			inCallout = true
 			calloutContent = append(calloutContent, line)
 			continue
@@ -107,30 +136,184 @@ func RenderPage(content string) error {
 }
 
 func RenderPageWithComments(content string, comments []Comment) error {
-	isTTY := term.IsTerminal(int(os.Stdout.Fd()))
+	return renderPageWithComments(content, comments, false)
+}
+
+// RenderPlainPageWithComments renders a page like RenderPageWithComments but
+// without colors, ANSI styling, or line wrapping, for screen readers and logs.
+func RenderPlainPageWithComments(content string, comments []Comment) error {
+	color.NoColor = true
+	return renderPageWithComments(content, comments, true)
+}
+
+// maxBodyLines limits how many lines of a rendered page body are shown;
+// 0 means no limit.
+var maxBodyLines int
+
+// SetMaxBodyLines truncates rendered page bodies after n lines (0 for no
+// limit). The limit applies to the cleaned markdown before it is styled, so
+// terminal wrapping does not change where the cut happens.
+func SetMaxBodyLines(n int) {
+	maxBodyLines = n
+}
+
+// hidePageHeader suppresses the metadata header above rendered pages.
+var hidePageHeader bool
+
+// SetHidePageHeader controls whether rendered pages start with the title and
+// metadata header, or with the body alone.
+func SetHidePageHeader(hide bool) {
+	hidePageHeader = hide
+}
+
+// maxBodyChars limits how many characters of a rendered page body are
+// shown; 0 means no limit.
+var maxBodyChars int
+
+// SetMaxBodyChars truncates rendered page bodies after n characters (0 for
+// no limit). Unlike SetMaxBodyLines, it counts the rendered text, leaving
+// out styling and the spaces that pad each line.
+func SetMaxBodyChars(n int) {
+	maxBodyChars = n
+}
+
+// truncateLines keeps the first max lines of body and reports how many were
+// dropped. A code fence left open by the cut is closed so the remainder
+// still renders as a complete block.
+func truncateLines(body string, max int) (string, int) {
+	lines := strings.Split(body, "\n")
+	if max <= 0 || len(lines) <= max {
+		return body, 0
+	}
+	return closeOpenFence(strings.Join(lines[:max], "\n")), len(lines) - max
+}
+
+// ansiEscapeRE matches a terminal styling sequence at the start of a string.
+var ansiEscapeRE = regexp.MustCompile(`^\x1b\[[0-9;?]*[A-Za-z]`)
+
+// truncateChars keeps the first max characters of rendered text and reports
+// how many were kept, or -1 when nothing was cut, and how many there were.
+// Styling and the spaces padding each line are not counted. The cut is
+// moved back to the end of a line when one is in reach so a paragraph is
+// not split mid-word.
+func truncateChars(rendered string, max int) (string, int, int) {
+	lines := strings.Split(rendered, "\n")
+	counts := make([]int, len(lines))
+	total := 0
+	for i, line := range lines {
+		counts[i] = utf8.RuneCountInString(strings.TrimRight(stripANSI(line), " \t"))
+		total += counts[i]
+	}
+	if max <= 0 || total <= max {
+		return rendered, -1, total
+	}
+
+	kept, shown := 0, 0
+	for shown+counts[kept] <= max {
+		shown += counts[kept]
+		kept++
+	}
+	out := strings.Join(lines[:kept], "\n")
+	if shown*2 < max {
+		if kept > 0 {
+			out += "\n"
+		}
+		out += cutVisible(lines[kept], max-shown)
+		shown = max
+	}
+	if strings.Contains(out, "\x1b[") {
+		out += "\x1b[0m"
+	}
+	return out, shown, total
+}
+
+// stripANSI removes terminal styling from s.
+func stripANSI(s string) string {
+	var b strings.Builder
+	for s != "" {
+		if loc := ansiEscapeRE.FindStringIndex(s); loc != nil {
+			s = s[loc[1]:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s)
+		b.WriteRune(r)
+		s = s[size:]
+	}
+	return b.String()
+}
+
+// cutVisible keeps the first n characters of line that are not styling,
+// along with the styling before them.
+func cutVisible(line string, n int) string {
+	var b strings.Builder
+	for line != "" && n > 0 {
+		if loc := ansiEscapeRE.FindStringIndex(line); loc != nil {
+			b.WriteString(line[:loc[1]])
+			line = line[loc[1]:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(line)
+		b.WriteRune(r)
+		line = line[size:]
+		n--
+	}
+	return b.String()
+}
+
+func closeOpenFence(body string) string {
+	open := false
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			open = !open
+		}
+	}
+	if open {
+		return body + "\n```"
+	}
+	return body
+}
+
+func renderPageWithComments(content string, comments []Comment, plain bool) error {
+	isTTY := !plain && term.IsTerminal(int(os.Stdout.Fd()))
 	meta, body := parseNotionResponse(content)
 	usedInlineComments := make(map[string]bool)
 	if rawBody, ok := extractNotionContentBody(content); ok {
-		body, usedInlineComments = notionToMarkdownWithComments(rawBody, comments)
+		body, usedInlineComments = notionToMarkdownWithComments(rawBody, comments, true)
 	}
 
-	if meta != nil {
+	showHeader := meta != nil && !hidePageHeader
+	if showHeader {
 		renderPageHeader(meta, isTTY)
 	}
 
+	body, truncated := truncateLines(body, maxBodyLines)
+	shownChars, totalChars := -1, 0
 	if body != "" {
-		r, err := NewMarkdownRenderer()
+		newRenderer := NewMarkdownRenderer
+		if plain {
+			newRenderer = NewPlainMarkdownRenderer
+		}
+		r, err := newRenderer()
 		if err != nil {
 			return err
 		}
-		if err := r.RenderAndPrint(body); err != nil {
+		rendered, err := r.Render(body)
+		if err != nil {
 			return err
 		}
+		rendered, shownChars, totalChars = truncateChars(rendered, maxBodyChars)
+		fmt.Println(rendered)
+	}
+	switch {
+	case shownChars >= 0:
+		_, _ = color.New(color.Faint).Printf("... (truncated, showing %d of %d characters)\n", shownChars, totalChars)
+	case truncated > 0:
+		_, _ = color.New(color.Faint).Printf("... (truncated, %d more lines)\n", truncated)
 	}
 
 	remainingComments := remainingPageComments(comments, usedInlineComments)
 	if len(remainingComments) > 0 {
-		if meta != nil || body != "" {
+		if showHeader || body != "" {
 			fmt.Println()
 			_, _ = color.New(color.Faint).Println("─── Comments ───")
 			fmt.Println()
@@ -257,61 +440,62 @@ func parseNotionResponse(content string) (*pageMetadata, string) {
 	return meta, content
 }
 
-func formatDatabaseContent(content string) string {
+// PageMarkdown returns the body of a fetched page converted from Notion
+// markup to plain markdown, without the metadata header.
+func PageMarkdown(content string) string {
+	_, body := parseNotionResponse(content)
+	return strings.TrimSpace(body)
+}
+
+// PageNotionMarkup returns the body of a fetched page as unconverted Notion
+// markup, the text that content edits are matched against.
+func PageNotionMarkup(content string) (string, bool) {
+	return extractNotionContentBody(content)
+}
+
+// IsDatabaseContent reports whether fetched content describes a database
+// rather than a page.
+func IsDatabaseContent(content string) bool {
+	if _, ok := extractNotionContentBody(content); ok {
+		return false
+	}
+	return strings.Contains(content, "<database")
+}
+
+// FormatDatabaseSummary renders fetched database content as plain markdown
+// (title, schema, and views) without terminal styling.
+func FormatDatabaseSummary(content string) string {
+	meta, body := parseNotionResponse(content)
 	var out strings.Builder
+	if meta.Title != "" {
+		out.WriteString("# " + meta.Title + "\n\n")
+	}
+	out.WriteString(body)
+	return strings.TrimSpace(out.String())
+}
 
-	// Extract and format schema
-	if start := strings.Index(content, "<data-source-state>"); start != -1 {
-		if end := strings.Index(content[start:], "</data-source-state>"); end != -1 {
-			stateJSON := strings.TrimSpace(content[start+len("<data-source-state>") : start+end])
-			var state struct {
-				Name   string `json:"name"`
-				Schema map[string]struct {
-					Name    string `json:"name"`
-					Type    string `json:"type"`
-					Options []struct {
-						Name string `json:"name"`
-					} `json:"options,omitempty"`
-				} `json:"schema"`
-			}
-			if err := json.Unmarshal([]byte(stateJSON), &state); err == nil {
-				out.WriteString("## Schema\n\n")
-				out.WriteString("| Column | Type |\n")
-				out.WriteString("|--------|------|\n")
-				for _, prop := range state.Schema {
-					typeStr := prop.Type
-					if len(prop.Options) > 0 {
-						opts := make([]string, 0, len(prop.Options))
-						for _, opt := range prop.Options {
-							opts = append(opts, opt.Name)
-						}
-						typeStr = fmt.Sprintf("%s (%s)", prop.Type, strings.Join(opts, ", "))
-					}
-					fmt.Fprintf(&out, "| %s | %s |\n", prop.Name, typeStr)
-				}
-				out.WriteString("\n")
+func formatDatabaseContent(content string) string {
+	var out strings.Builder
+	schema := ParseDatabaseSchema(content)
+
+	if schema.HasSchema {
+		out.WriteString("## Schema\n\n")
+		out.WriteString("| Column | Type |\n")
+		out.WriteString("|--------|------|\n")
+		for _, col := range schema.Columns {
+			typeStr := col.Type
+			if len(col.Options) > 0 {
+				typeStr = fmt.Sprintf("%s (%s)", col.Type, strings.Join(col.Options, ", "))
 			}
+			fmt.Fprintf(&out, "| %s | %s |\n", col.Name, typeStr)
 		}
+		out.WriteString("\n")
 	}
 
-	// Extract and format views
-	if strings.Contains(content, "<views>") {
+	if schema.HasViews {
 		out.WriteString("## Views\n\n")
-		viewRe := regexp.MustCompile(`<view url="[^"]*">`)
-		viewStarts := viewRe.FindAllStringIndex(content, -1)
-		for _, loc := range viewStarts {
-			start := loc[1]
-			end := strings.Index(content[start:], "</view>")
-			if end != -1 {
-				viewJSON := strings.TrimSpace(content[start : start+end])
-				var view struct {
-					Name string `json:"name"`
-					Type string `json:"type"`
-				}
-				if err := json.Unmarshal([]byte(viewJSON), &view); err == nil {
-					fmt.Fprintf(&out, "- **%s** (%s)\n", view.Name, view.Type)
-				}
-			}
+		for _, view := range schema.Views {
+			fmt.Fprintf(&out, "- **%s** (%s)\n", view.Name, view.Type)
 		}
 		out.WriteString("\n")
 	}
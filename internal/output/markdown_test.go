@@ -1,6 +1,8 @@
 package output
 
 import (
+	"io"
+	"os"
 	"strings"
 	"testing"
 )
@@ -106,7 +108,7 @@ func TestNotionToMarkdownWithComments_InlineDiscussion(t *testing.T) {
 		DiscussionID:  "discussion://page/block/discussion-1",
 		CreatedByName: "Person Example",
 		Content:       "Inline comment body",
-	}})
+	}}, false)
 
 	if !strings.Contains(markdown, "anchored text") {
 		t.Fatalf("expected anchor text in markdown, got %q", markdown)
@@ -139,3 +141,251 @@ func TestRemainingPageComments_FiltersInlineComments(t *testing.T) {
 		t.Fatalf("expected page-level comment to remain, got %#v", remaining[0])
 	}
 }
+
+func TestFormatDatabaseSummary(t *testing.T) {
+	content := `<database url="{{https://www.notion.so/db123}}">
+The title of this Database is: Tasks
+<data-source-state>
+{"name":"Tasks","schema":{"Status":{"name":"Status","type":"select","options":[{"name":"Todo"},{"name":"Done"}]},"Name":{"name":"Name","type":"title"}}}
+</data-source-state>
+<views>
+<view url="{{view://1}}">
+{"name":"All tasks","type":"table"}
+</view>
+</views>
+</database>`
+
+	if !IsDatabaseContent(content) {
+		t.Fatalf("expected database content to be detected")
+	}
+	if IsDatabaseContent(`<page><content>Mentions <database url="x">Tasks</database></content></page>`) {
+		t.Fatalf("page content with an inline database should not be treated as a database")
+	}
+
+	got := FormatDatabaseSummary(content)
+	for _, want := range []string{
+		"# Tasks",
+		"| Name | title |",
+		"| Status | select (Todo, Done) |",
+		"- **All tasks** (table)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("summary missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "| Name |") > strings.Index(got, "| Status |") {
+		t.Fatalf("expected schema columns to be sorted:\n%s", got)
+	}
+}
+
+func TestNotionToMarkdown_PreservesCodeBlocks(t *testing.T) {
+	content := "Intro\n```Plain Text\nif a <b> {color=\"red\"}\n\n\n\nend\n```\n<caption>Example output</caption>\n<callout icon=\"💡\">\n\t```c++\n\tint x = 1 < 2;\n\t```\n</callout>\nAfter `inline` code"
+
+	got := notionToMarkdown(content)
+
+	for _, want := range []string{
+		"```text\nif a <b> {color=\"red\"}\n\n\n\nend\n```",
+		"*Example output*",
+		"> ```cpp\n> int x = 1 < 2;\n> ```",
+		"After `inline` code",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "<caption>") {
+		t.Fatalf("caption tag leaked into output:\n%s", got)
+	}
+}
+
+func TestPlainMarkdownRendererEmitsNoANSI(t *testing.T) {
+	r, err := NewPlainMarkdownRenderer()
+	if err != nil {
+		t.Fatalf("NewPlainMarkdownRenderer: %v", err)
+	}
+
+	long := strings.Repeat("word ", 60)
+	out, err := r.Render("# Title\n\n**bold** and `code`\n\n" + long)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Fatalf("plain output contains ANSI escapes: %q", out)
+	}
+	if !strings.Contains(out, strings.TrimSpace(long)) {
+		t.Fatalf("plain output wrapped long line: %q", out)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimRight(line, " \t") != line {
+			t.Fatalf("plain output has trailing whitespace: %q", line)
+		}
+	}
+}
+
+func TestTruncateLines(t *testing.T) {
+	body := "# Title\n\nOne\nTwo\nThree"
+	if got, dropped := truncateLines(body, 0); got != body || dropped != 0 {
+		t.Fatalf("no limit = %q, %d", got, dropped)
+	}
+	if got, dropped := truncateLines(body, 10); got != body || dropped != 0 {
+		t.Fatalf("under limit = %q, %d", got, dropped)
+	}
+	if got, dropped := truncateLines(body, 3); got != "# Title\n\nOne" || dropped != 2 {
+		t.Fatalf("truncated = %q, %d", got, dropped)
+	}
+}
+
+func TestTruncateLinesClosesOpenCodeFence(t *testing.T) {
+	body := "Intro\n```go\nfunc main() {\n}\n```\nAfter"
+	got, dropped := truncateLines(body, 3)
+	if got != "Intro\n```go\nfunc main() {\n```" || dropped != 3 {
+		t.Fatalf("truncated = %q, %d", got, dropped)
+	}
+}
+
+func TestTruncateChars(t *testing.T) {
+	body := "First line of text\nSecond line\nThird"
+	if got, shown, total := truncateChars(body, 0); got != body || shown != -1 || total != 34 {
+		t.Fatalf("no limit = %q, %d, %d", got, shown, total)
+	}
+	if got, shown, _ := truncateChars(body, 100); got != body || shown != -1 {
+		t.Fatalf("under limit = %q, %d", got, shown)
+	}
+	if got, shown, _ := truncateChars(body, 32); got != "First line of text\nSecond line" || shown != 29 {
+		t.Fatalf("truncated at line end = %q, %d", got, shown)
+	}
+	if got, shown, _ := truncateChars("héllo wörld", 5); got != "héllo" || shown != 5 {
+		t.Fatalf("truncated mid-line = %q, %d", got, shown)
+	}
+}
+
+func TestTruncateCharsCountsRenderedText(t *testing.T) {
+	rendered := "  \x1b[1mBold\x1b[0m heading    \n  plain text      "
+	got, shown, total := truncateChars(rendered, 8)
+	if total != 26 || shown != 8 {
+		t.Fatalf("shown, total = %d, %d; want 8, 26", shown, total)
+	}
+	if want := "  \x1b[1mBold\x1b[0m h\x1b[0m"; got != want {
+		t.Fatalf("truncated = %q, want %q", got, want)
+	}
+}
+
+func TestNotionToMarkdown_AnnotatesColumnWidths(t *testing.T) {
+	content := "<columns>\n<column width=\"0.3\">\nLeft\n</column>\n<column width=\"70%\">\nRight\n</column>\n<column>\nPlain\n</column>\n</columns>"
+	got := notionToMarkdown(content)
+
+	for _, want := range []string{"── column (30%) ──\n\nLeft", "── column (70%) ──\n\nRight"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in output:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "── column") != 2 {
+		t.Fatalf("expected no separator for a column without a width:\n%s", got)
+	}
+}
+
+func TestNotionToMarkdown_RendersSyncedBlocks(t *testing.T) {
+	content := "Before\n<synced_block url=\"{{https://www.notion.so/abc#b1}}\">\n\tShared **text**\n\t- item\n</synced_block>\n" +
+		"<synced_block_reference url=\"{{https://www.notion.so/abc#b1}}\">\n\tCopied text\n</synced_block_reference>\n" +
+		"<synced_block_reference url=\"{{https://www.notion.so/def#b2}}\"/>\nAfter"
+	got := notionToMarkdown(content)
+
+	for _, want := range []string{
+		"*🔄 Synced block*\n\nShared **text**\n- item",
+		"*🔄 Synced from [original](https://www.notion.so/abc#b1)*\n\nCopied text",
+		"*🔄 Synced from [original](https://www.notion.so/def#b2)*",
+		"After",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in output:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "synced_block") || strings.Contains(got, "\tShared") {
+		t.Fatalf("raw tags or indentation left in output:\n%s", got)
+	}
+}
+
+func TestNotionToMarkdown_RendersLinkedDatabaseViews(t *testing.T) {
+	content := "<database url=\"{{https://www.notion.so/db1}}\" inline=\"true\"/>\n" +
+		"<data-source url=\"{{collection://ds1}}\">Tasks</data-source>\nAfter"
+	got := notionToMarkdown(content)
+
+	for _, want := range []string{
+		"**[📊 database](https://www.notion.so/db1)**",
+		"**[📊 Tasks](collection://ds1)**",
+		"After",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in output:\n%s", want, got)
+		}
+	}
+}
+
+func TestNotionToMarkdown_RendersDatabaseMentions(t *testing.T) {
+	content := "See <mention-database url=\"{{https://www.notion.so/db1}}\">Roadmap</mention-database> and " +
+		"<mention-database url=\"{{https://www.notion.so/db2}}\"/> for details."
+	got := notionToMarkdown(content)
+
+	want := "See [📊 Roadmap](https://www.notion.so/db1) and [📊 database](https://www.notion.so/db2) for details."
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestNotionToMarkdown_RendersUserAndDateMentions(t *testing.T) {
+	SetMentionUserNames(map[string]string{"u2": "Grace Hopper"})
+	defer SetMentionUserNames(nil)
+
+	content := "Ping <mention-user url=\"{{user://u1}}\">Ada Lovelace</mention-user>, " +
+		"<mention-user url=\"{{user://u2}}\"/> and <mention-user url=\"user://u3\"/> " +
+		"by <mention-date start=\"2024-05-01\"/>, from <mention-date start=\"2024-05-01\" startTime=\"09:30\" end=\"2024-05-03\"/> " +
+		"<mention-reminder date=\"2024-06-10\" time=\"14:00\"/>."
+	got := notionToMarkdown(content)
+
+	want := "Ping @Ada Lovelace, @Grace Hopper and @user by May 1, 2024, from May 1, 2024 09:30 → May 3, 2024 ⏰ Jun 10, 2024 14:00."
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	ids := UnnamedMentionUserIDs(content + " <mention-user url=\"{{user://u2}}\"/>")
+	if len(ids) != 2 || ids[0] != "u2" || ids[1] != "u3" {
+		t.Fatalf("UnnamedMentionUserIDs = %v, want [u2 u3]", ids)
+	}
+}
+
+func TestRenderPageHidesHeader(t *testing.T) {
+	content := `<page url="{{https://www.notion.so/abc}}"><properties>{"title":"Quarterly Plan"}</properties><content>
+Body text
+</content></page>`
+
+	render := func(hide bool) string {
+		SetHidePageHeader(hide)
+		defer SetHidePageHeader(false)
+
+		orig := os.Stdout
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("Pipe: %v", err)
+		}
+		os.Stdout = w
+		renderErr := RenderPlainPageWithComments(content, nil)
+		os.Stdout = orig
+		_ = w.Close()
+		out, _ := io.ReadAll(r)
+		if renderErr != nil {
+			t.Fatalf("render: %v", renderErr)
+		}
+		return string(out)
+	}
+
+	if out := render(false); !strings.Contains(out, "Quarterly Plan") {
+		t.Fatalf("header missing by default:\n%s", out)
+	}
+	out := render(true)
+	if strings.Contains(out, "Quarterly Plan") || strings.Contains(out, "notion.so/abc") {
+		t.Fatalf("header printed with SetHidePageHeader(true):\n%s", out)
+	}
+	if !strings.Contains(out, "Body text") {
+		t.Fatalf("body missing:\n%s", out)
+	}
+}
@@ -0,0 +1,93 @@
+package output
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// mentionUserNames maps user IDs to display names for user mentions whose
+// markup carries no name.
+var mentionUserNames map[string]string
+
+// SetMentionUserNames supplies names for user mentions that arrive without
+// one, keyed by user ID.
+func SetMentionUserNames(names map[string]string) {
+	mentionUserNames = names
+}
+
+var mentionUserRe = regexp.MustCompile(`<mention-user\s+url="(?:\{\{)?user://([^"}]+)(?:\}\})?"[^>]*?(?:/>|>\s*</mention-user>)`)
+
+// UnnamedMentionUserIDs returns the distinct IDs of users mentioned in
+// content without a name, in order of first mention.
+func UnnamedMentionUserIDs(content string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, m := range mentionUserRe.FindAllStringSubmatch(content, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			ids = append(ids, m[1])
+		}
+	}
+	return ids
+}
+
+// renderMentionUser renders a user mention as @Name, looking the name up
+// by ID when the markup has none.
+func (ctx *renderContext) renderMentionUser(n *html.Node) {
+	name := strings.TrimPrefix(strings.TrimSpace(getTextContent(n)), "@")
+	if name == "" {
+		id := strings.TrimPrefix(cleanNotionURL(getAttr(n, "url")), "user://")
+		name = mentionUserNames[id]
+	}
+	if name == "" {
+		name = "user"
+	}
+	ctx.out.WriteString("@" + name)
+}
+
+// renderMentionDate renders a date mention, or a range, as a readable date.
+func (ctx *renderContext) renderMentionDate(n *html.Node) {
+	// The HTML parser lowercases attribute names, so startTime is starttime.
+	text := formatMentionDate(getAttr(n, "start"), getAttr(n, "starttime"))
+	if text == "" {
+		text = strings.TrimSpace(getTextContent(n))
+	}
+	if end := formatMentionDate(getAttr(n, "end"), getAttr(n, "endtime")); end != "" {
+		text += " → " + end
+	}
+	if text != "" {
+		ctx.out.WriteString(text)
+	}
+}
+
+// renderMentionReminder renders a reminder mention as its date and time.
+func (ctx *renderContext) renderMentionReminder(n *html.Node) {
+	if text := formatMentionDate(getAttr(n, "date"), getAttr(n, "time")); text != "" {
+		ctx.out.WriteString("⏰ " + text)
+	}
+}
+
+// formatMentionDate formats a YYYY-MM-DD date, with an optional HH:mm
+// time, as "May 1, 2024" or "May 1, 2024 14:30". Other values are
+// returned as given.
+func formatMentionDate(date, clock string) string {
+	date = strings.TrimSpace(date)
+	if date == "" {
+		return ""
+	}
+	if t, err := time.Parse(time.RFC3339, date); err == nil {
+		return t.Format("Jan 2, 2006 15:04")
+	}
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	formatted := t.Format("Jan 2, 2006")
+	if clock = strings.TrimSpace(clock); clock != "" {
+		formatted += " " + clock
+	}
+	return formatted
+}
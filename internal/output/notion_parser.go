@@ -1,7 +1,10 @@
 package output
 
 import (
+	"fmt"
+	"math"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"golang.org/x/net/html"
@@ -10,26 +13,34 @@ import (
 // notionToMarkdown converts Notion's XML-like content to Markdown.
 // It uses an HTML parser which is lenient with malformed markup.
 func notionToMarkdown(content string) string {
-	rendered, _ := notionToMarkdownWithComments(content, nil)
+	rendered, _ := notionToMarkdownWithComments(content, nil, false)
 	return rendered
 }
 
-func notionToMarkdownWithComments(content string, comments []Comment) (string, map[string]bool) {
+// notionToMarkdownWithComments converts content like notionToMarkdown,
+// placing inline comments after the blocks they are anchored to. With
+// colorCallouts, colored callouts carry a marker for MarkdownRenderer to
+// turn into a colored bar; only terminal rendering should set it.
+func notionToMarkdownWithComments(content string, comments []Comment, colorCallouts bool) (string, map[string]bool) {
+	// Lift fenced code out first so its contents are not parsed as markup
+	// and the fence language is kept for syntax highlighting.
+	content, codeBlocks := extractCodeBlocks(content)
+
 	// Preprocess: remove self-closing tags that HTML parser mishandles
 	// These become nested containers otherwise
 	content = regexp.MustCompile(`<empty-block\s*/>`).ReplaceAllString(content, "")
 	content = regexp.MustCompile(`<unknown[^>]*/>`).ReplaceAllString(content, "")
 	content = regexp.MustCompile(`<omitted\s*/>`).ReplaceAllString(content, "")
 
-	// Convert self-closing mention-page to paired tags for proper parsing
-	content = regexp.MustCompile(`<mention-page([^>]*)/>`).ReplaceAllString(content, "<mention-page$1></mention-page>")
+	// Convert self-closing tags to paired tags for proper parsing
+	content = selfClosingTagRe.ReplaceAllString(content, "<$1$2></$1>")
 
 	// Wrap in a root element to ensure valid parsing
 	wrapped := "<root>" + content + "</root>"
 
 	doc, err := html.Parse(strings.NewReader(wrapped))
 	if err != nil {
-		return content, nil
+		return restoreCodeBlocks(content, codeBlocks), nil
 	}
 
 	var out strings.Builder
@@ -38,6 +49,7 @@ func notionToMarkdownWithComments(content string, comments []Comment) (string, m
 		inQuote:         false,
 		inlineComments:  buildInlineCommentIndex(comments),
 		usedDiscussions: make(map[string]bool),
+		colorCallouts:   colorCallouts,
 	}
 
 	// Find <root> element (will be under html > body) and process its children
@@ -62,6 +74,7 @@ func notionToMarkdownWithComments(content string, comments []Comment) (string, m
 
 	// Clean up excess blank lines
 	result = regexp.MustCompile(`\n{3,}`).ReplaceAllString(result, "\n\n")
+	result = restoreCodeBlocks(result, codeBlocks)
 
 	return strings.TrimSpace(result), ctx.usedDiscussions
 }
@@ -71,6 +84,7 @@ type renderContext struct {
 	inQuote         bool
 	inlineComments  map[string][]Comment
 	usedDiscussions map[string]bool
+	colorCallouts   bool
 }
 
 func (ctx *renderContext) renderNode(n *html.Node) {
@@ -87,6 +101,10 @@ func (ctx *renderContext) renderNode(n *html.Node) {
 	}
 }
 
+// selfClosingTagRe matches self-closing tags the HTML parser would
+// otherwise leave open, swallowing the content after them.
+var selfClosingTagRe = regexp.MustCompile(`<(mention-page|mention-database|mention-user|mention-date|mention-reminder|database|data-source|synced_block_reference)([^>]*?)\s*/>`)
+
 // Precompiled regexes for text cleaning
 var (
 	colorAnnotationRe = regexp.MustCompile(`\s*\{color="[^"]+"\}`)
@@ -128,10 +146,20 @@ func (ctx *renderContext) renderElement(n *html.Node) {
 		ctx.renderColumn(n)
 	case "page":
 		ctx.renderPageLink(n)
-	case "database":
+	case "database", "data-source":
 		ctx.renderDatabaseLink(n)
+	case "synced_block", "synced_block_reference":
+		ctx.renderSyncedBlock(n)
 	case "mention-page":
 		ctx.renderMentionPage(n)
+	case "mention-database":
+		ctx.renderMentionDatabase(n)
+	case "mention-user":
+		ctx.renderMentionUser(n)
+	case "mention-date":
+		ctx.renderMentionDate(n)
+	case "mention-reminder":
+		ctx.renderMentionReminder(n)
 	case "span":
 		ctx.renderSpan(n)
 	case "empty-block", "unknown", "omitted":
@@ -245,6 +273,7 @@ func (ctx *renderContext) renderNodeToString(n *html.Node) string {
 		inQuote:         ctx.inQuote,
 		inlineComments:  ctx.inlineComments,
 		usedDiscussions: ctx.usedDiscussions,
+		colorCallouts:   ctx.colorCallouts,
 	}
 	for c := n.FirstChild; c != nil; c = c.NextSibling {
 		childCtx.renderNode(c)
@@ -303,7 +332,11 @@ func (ctx *renderContext) renderCallout(n *html.Node) {
 		icon = "💡"
 	}
 
-	ctx.out.WriteString("\n> " + icon + " ")
+	marker := ""
+	if ctx.colorCallouts {
+		marker = calloutColorMarker(getAttr(n, "color"))
+	}
+	ctx.out.WriteString("\n> " + marker + icon + " ")
 
 	// Render children in quote context
 	oldQuote := ctx.inQuote
@@ -322,16 +355,45 @@ func (ctx *renderContext) renderColumns(n *html.Node) {
 func (ctx *renderContext) renderColumn(n *html.Node) {
 	// Collect column content
 	var colOut strings.Builder
-	colCtx := &renderContext{out: &colOut}
+	colCtx := &renderContext{out: &colOut, colorCallouts: ctx.colorCallouts}
 	colCtx.renderChildren(n)
 
-	// Dedent and add to output
+	// Dedent and add to output, labelled with the column's share of the
+	// row when the markup records one.
 	content := dedentContent(colOut.String())
 	ctx.out.WriteString("\n")
+	if percent, ok := columnWidthPercent(n); ok {
+		fmt.Fprintf(ctx.out, "── column (%d%%) ──\n\n", percent)
+	}
 	ctx.out.WriteString(content)
 	ctx.out.WriteString("\n")
 }
 
+// columnWidthPercent reads a column's width ratio from its width,
+// width-ratio, or width_ratio attribute. Ratios (0.3) and percentages
+// ("30%") are both accepted.
+func columnWidthPercent(n *html.Node) (int, bool) {
+	for _, name := range []string{"width", "width-ratio", "width_ratio"} {
+		value := strings.TrimSpace(getAttr(n, name))
+		if value == "" {
+			continue
+		}
+		isPercent := strings.HasSuffix(value, "%")
+		f, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err != nil || f <= 0 {
+			return 0, false
+		}
+		if !isPercent {
+			if f > 1 {
+				return 0, false
+			}
+			f *= 100
+		}
+		return int(math.Round(f)), true
+	}
+	return 0, false
+}
+
 func (ctx *renderContext) renderPageLink(n *html.Node) {
 	url := cleanNotionURL(getAttr(n, "url"))
 	title := getTextContent(n)
@@ -360,6 +422,32 @@ func (ctx *renderContext) renderDatabaseLink(n *html.Node) {
 	ctx.out.WriteString("\n**[📊 " + title + "](" + url + ")**\n")
 }
 
+// renderSyncedBlock renders a synced block's content inline under a note
+// saying it is synced. A reference to a block synced from elsewhere links
+// to the original; its content is shown when the response includes it.
+func (ctx *renderContext) renderSyncedBlock(n *html.Node) {
+	var inner strings.Builder
+	innerCtx := &renderContext{
+		out:             &inner,
+		inlineComments:  ctx.inlineComments,
+		usedDiscussions: ctx.usedDiscussions,
+		colorCallouts:   ctx.colorCallouts,
+	}
+	innerCtx.renderChildren(n)
+
+	note := "*🔄 Synced block*"
+	if n.Data == "synced_block_reference" {
+		note = "*🔄 Synced from elsewhere*"
+		if url := cleanNotionURL(getAttr(n, "url")); url != "" {
+			note = "*🔄 Synced from [original](" + url + ")*"
+		}
+	}
+	ctx.out.WriteString("\n" + note + "\n\n")
+	if content := dedentContent(inner.String()); content != "" {
+		ctx.out.WriteString(content + "\n")
+	}
+}
+
 func (ctx *renderContext) renderMentionPage(n *html.Node) {
 	url := cleanNotionURL(getAttr(n, "url"))
 	title := getTextContent(n)
@@ -381,6 +469,17 @@ func (ctx *renderContext) renderMentionPage(n *html.Node) {
 	}
 }
 
+// renderMentionDatabase renders an inline database mention as a link,
+// marked like block-level database links.
+func (ctx *renderContext) renderMentionDatabase(n *html.Node) {
+	url := cleanNotionURL(getAttr(n, "url"))
+	title := getTextContent(n)
+	if title == "" {
+		title = "database"
+	}
+	ctx.out.WriteString("[📊 " + title + "](" + url + ")")
+}
+
 func (ctx *renderContext) renderLink(n *html.Node) {
 	href := getAttr(n, "href")
 	text := getTextContent(n)
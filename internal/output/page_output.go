@@ -0,0 +1,53 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PageOutput selects what PrintPage and PrintPages print in place of JSON.
+type PageOutput int
+
+const (
+	// PageOutputJSON prints the pages as JSON.
+	PageOutputJSON PageOutput = iota
+	// PageOutputURL prints one page URL per line.
+	PageOutputURL
+	// PageOutputID prints one page ID per line.
+	PageOutputID
+)
+
+var pageOutput = PageOutputJSON
+
+// SetPageOutput makes the JSON output of PrintPage and PrintPages a bare
+// URL or ID per page, for scripts that only need that field.
+func SetPageOutput(mode PageOutput) {
+	pageOutput = mode
+}
+
+// PageOutputMode returns the mode set by SetPageOutput.
+func PageOutputMode() PageOutput {
+	return pageOutput
+}
+
+// writePageFields writes the URL or ID of each page on its own line. Pages
+// known only by ID, such as ones updated in place, get their notion.so URL.
+func writePageFields(w io.Writer, pages []Page, mode PageOutput) error {
+	for _, page := range pages {
+		value := page.ID
+		if mode == PageOutputURL {
+			value = page.URL
+			if value == "" && page.ID != "" {
+				value = "https://www.notion.so/" + strings.ReplaceAll(page.ID, "-", "")
+			}
+		}
+		if value == "" {
+			continue
+		}
+		if _, err := fmt.Fprintln(w, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
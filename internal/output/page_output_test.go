@@ -0,0 +1,30 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWritePageFields(t *testing.T) {
+	pages := []Page{
+		{ID: "a1", URL: "https://www.notion.so/Roadmap-a1"},
+		{ID: "1234-abcd"},
+		{},
+	}
+	tests := []struct {
+		mode PageOutput
+		want string
+	}{
+		{PageOutputURL, "https://www.notion.so/Roadmap-a1\nhttps://www.notion.so/1234abcd\n"},
+		{PageOutputID, "a1\n1234-abcd\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := writePageFields(&buf, pages, tt.mode); err != nil {
+			t.Fatalf("writePageFields: %v", err)
+		}
+		if buf.String() != tt.want {
+			t.Fatalf("mode %d: output = %q, want %q", tt.mode, buf.String(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package output
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	markupOpenTagRe  = regexp.MustCompile(`^<([a-zA-Z][\w-]*)(\s[^<>]*)?>$`)
+	markupCloseTagRe = regexp.MustCompile(`^</([a-zA-Z][\w-]*)>$`)
+)
+
+// PrettyNotionMarkup indents the tag structure of a raw MCP fetch response
+// for inspection. Lines that are only an opening tag increase the depth and
+// lines that are only the matching closing tag decrease it; every other line,
+// including markdown and fenced code, is kept as-is behind the indentation.
+func PrettyNotionMarkup(content string) string {
+	lines := strings.Split(content, "\n")
+	out := make([]string, 0, len(lines))
+	var open []string
+	inFence := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		indent := strings.Repeat("  ", len(open))
+
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		if inFence || strings.HasPrefix(trimmed, "```") {
+			out = append(out, indent+line)
+			continue
+		}
+
+		if m := markupCloseTagRe.FindStringSubmatch(trimmed); m != nil {
+			if i := lastIndex(open, m[1]); i >= 0 {
+				open = open[:i]
+				indent = strings.Repeat("  ", len(open))
+			}
+			out = append(out, indent+trimmed)
+			continue
+		}
+
+		if m := markupOpenTagRe.FindStringSubmatch(trimmed); m != nil && !strings.HasSuffix(trimmed, "/>") && m[1] != "br" {
+			out = append(out, indent+trimmed)
+			open = append(open, m[1])
+			continue
+		}
+
+		if trimmed == "" {
+			out = append(out, "")
+			continue
+		}
+		out = append(out, indent+line)
+	}
+
+	return strings.Join(out, "\n")
+}
+
+func lastIndex(stack []string, name string) int {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// rawEscapes unescapes the sequences that show up literally in some raw
+// responses. An escaped backslash is matched first so "\\n" stays as is.
+var rawEscapes = strings.NewReplacer(`\\`, `\\`, `\r\n`, "\n", `\n`, "\n", `\t`, "\t")
+
+// NormalizeRawMarkup makes a raw MCP fetch response easier to read: CRLF and
+// lone CR line endings become LF, and outside fenced code literal \n and \t
+// escapes become real newlines and tabs. Code blocks keep their escapes, and
+// HTML entities are left alone so escaped text can't turn into tags.
+func NormalizeRawMarkup(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.ReplaceAll(content, "\r", "\n")
+
+	lines := strings.Split(content, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence {
+			lines[i] = rawEscapes.Replace(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
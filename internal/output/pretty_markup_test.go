@@ -0,0 +1,45 @@
+package output
+
+import "testing"
+
+func TestPrettyNotionMarkupIndentsTagStructure(t *testing.T) {
+	raw := "<page url=\"{{https://www.notion.so/p1}}\">\n" +
+		"<properties>\n{\"title\":\"Doc\"}\n</properties>\n" +
+		"<content>\n" +
+		"# Heading\n" +
+		"<callout icon=\"💡\">\n\tTip text\n</callout>\n" +
+		"<empty-block/>\n" +
+		"```html\n<div>\n```\n" +
+		"- item\n\t- nested\n" +
+		"</content>\n" +
+		"</page>"
+
+	want := "<page url=\"{{https://www.notion.so/p1}}\">\n" +
+		"  <properties>\n    {\"title\":\"Doc\"}\n  </properties>\n" +
+		"  <content>\n" +
+		"    # Heading\n" +
+		"    <callout icon=\"💡\">\n      \tTip text\n    </callout>\n" +
+		"    <empty-block/>\n" +
+		"    ```html\n    <div>\n    ```\n" +
+		"    - item\n    \t- nested\n" +
+		"  </content>\n" +
+		"</page>"
+
+	if got := PrettyNotionMarkup(raw); got != want {
+		t.Fatalf("PrettyNotionMarkup mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPrettyNotionMarkupIgnoresUnmatchedClosingTag(t *testing.T) {
+	if got := PrettyNotionMarkup("</content>\ntext"); got != "</content>\ntext" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestNormalizeRawMarkup(t *testing.T) {
+	input := "<page>\r\nLine one\\nLine two\\tTabbed\rPath C:\\\\new\r\n```\r\nfmt.Println(\"a\\n\")\r\n```\r\n</page>"
+	want := "<page>\nLine one\nLine two\tTabbed\nPath C:\\\\new\n```\nfmt.Println(\"a\\n\")\n```\n</page>"
+	if got := NormalizeRawMarkup(input); got != want {
+		t.Fatalf("NormalizeRawMarkup =\n%q\nwant\n%q", got, want)
+	}
+}
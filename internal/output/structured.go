@@ -0,0 +1,79 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the --format output format.
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+)
+
+// structuredFormat is how structured output (--json or --format) is encoded.
+var structuredFormat = FormatJSON
+
+// SetFormat selects the encoding for structured output. Table leaves
+// commands to their human format unless they are given --json.
+func SetFormat(f Format) {
+	if f == FormatYAML {
+		structuredFormat = FormatYAML
+		return
+	}
+	structuredFormat = FormatJSON
+}
+
+// Structured reports whether f asks for machine-readable output.
+func (f Format) Structured() bool {
+	return f == FormatJSON || f == FormatYAML
+}
+
+// WriteStructured encodes v to w as indented JSON, or as YAML when
+// --format yaml is selected. YAML is produced from the JSON encoding so
+// field names and omitempty behave the same in both formats.
+func WriteStructured(w io.Writer, v any) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	if structuredFormat != FormatYAML {
+		_, err := w.Write(buf.Bytes())
+		return err
+	}
+
+	// JSON is valid YAML, so parsing it as a node keeps key order; clearing
+	// the flow style turns it into block YAML.
+	var node yaml.Node
+	if err := yaml.Unmarshal(buf.Bytes(), &node); err != nil {
+		return fmt.Errorf("convert to YAML: %w", err)
+	}
+	clearYAMLStyle(&node)
+	yamlEnc := yaml.NewEncoder(w)
+	yamlEnc.SetIndent(2)
+	if err := yamlEnc.Encode(&node); err != nil {
+		return err
+	}
+	return yamlEnc.Close()
+}
+
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
+
+func printJSON(v any) error {
+	return WriteStructured(os.Stdout, v)
+}
@@ -0,0 +1,38 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteStructuredYAMLKeepsFieldOrderAndOmitEmpty(t *testing.T) {
+	SetFormat(FormatYAML)
+	t.Cleanup(func() { SetFormat(FormatTable) })
+
+	type item struct {
+		ID      string `json:"id"`
+		Title   string `json:"title"`
+		URL     string `json:"url,omitempty"`
+		Content string `json:"content"`
+	}
+	var buf bytes.Buffer
+	if err := WriteStructured(&buf, item{ID: "abc", Title: "Notes", Content: "line one\nline two"}); err != nil {
+		t.Fatalf("WriteStructured: %v", err)
+	}
+	want := "id: abc\ntitle: Notes\ncontent: |-\n  line one\n  line two\n"
+	if buf.String() != want {
+		t.Fatalf("YAML =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteStructuredDefaultsToJSON(t *testing.T) {
+	SetFormat(FormatTable)
+
+	var buf bytes.Buffer
+	if err := WriteStructured(&buf, map[string]any{"ok": true}); err != nil {
+		t.Fatalf("WriteStructured: %v", err)
+	}
+	if buf.String() != "{\n  \"ok\": true\n}\n" {
+		t.Fatalf("JSON = %q", buf.String())
+	}
+}
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// listTemplate, when set, replaces the table printed by PrintPages and
+// PrintSearchResults.
+var listTemplate *template.Template
+
+// templateEscapes turns the escapes a shell passes through literally inside
+// single quotes into the characters they name.
+var templateEscapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n")
+
+// templateFuncs are the helpers available to --template, on top of the
+// text/template builtins.
+var templateFuncs = template.FuncMap{
+	"truncate": func(n int, s string) string { return Truncate(s, n) },
+	"lower":    strings.ToLower,
+	"upper":    strings.ToUpper,
+}
+
+// ParseListTemplate parses a --template for list output. Each page or
+// search result is executed against the template and followed by a newline.
+// \t and \n in text are read as a tab and a newline.
+func ParseListTemplate(text string) (*template.Template, error) {
+	return template.New("list").Funcs(templateFuncs).Parse(templateEscapes.Replace(text))
+}
+
+// SetListTemplate makes PrintPages and PrintSearchResults render each item
+// through t instead of a table. A nil t restores the table.
+func SetListTemplate(t *template.Template) {
+	listTemplate = t
+}
+
+// writeTemplated renders each item through t, one per line.
+func writeTemplated[T any](w io.Writer, t *template.Template, items []T) error {
+	bw := bufio.NewWriter(w)
+	for _, item := range items {
+		if err := t.Execute(bw, item); err != nil {
+			return err
+		}
+		if err := bw.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func printTemplated[T any](items []T) error {
+	return writeTemplated(os.Stdout, listTemplate, items)
+}
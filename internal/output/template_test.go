@@ -0,0 +1,37 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteTemplatedPages(t *testing.T) {
+	tmpl, err := ParseListTemplate(`{{.ID}}\t{{lower .Title}}\t{{truncate 8 .URL}}`)
+	if err != nil {
+		t.Fatalf("ParseListTemplate: %v", err)
+	}
+
+	pages := []Page{
+		{ID: "a1", Title: "Roadmap", URL: "https://notion.so/a1"},
+		{ID: "b2", Title: "Q3 Plan", URL: "x"},
+	}
+	var buf bytes.Buffer
+	if err := writeTemplated(&buf, tmpl, pages); err != nil {
+		t.Fatalf("writeTemplated: %v", err)
+	}
+	want := "a1\troadmap\thttps:/…\nb2\tq3 plan\tx\n"
+	if buf.String() != want {
+		t.Fatalf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteTemplatedUnknownField(t *testing.T) {
+	tmpl, err := ParseListTemplate("{{.Nope}}")
+	if err != nil {
+		t.Fatalf("ParseListTemplate: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := writeTemplated(&buf, tmpl, []SearchResult{{ID: "a"}}); err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+}
@@ -13,6 +13,35 @@ type Page struct {
 	Archived       bool
 	Icon           string
 	Content        string
+
+	// UploadedAssets lists the local images uploaded for the page, when any.
+	UploadedAssets []UploadedAsset `json:"uploaded_assets,omitempty"`
+	// Backlinks lists pages found linking to this one, for page view --backlinks.
+	Backlinks []Backlink `json:"backlinks,omitempty"`
+	// Attachments lists the files saved by page view --download-to.
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// Attachment is a file block's file, saved to Path, or skipped with Error.
+type Attachment struct {
+	Name  string `json:"name"`
+	Path  string `json:"path,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Backlink is a page whose content links to another page.
+type Backlink struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	URL   string `json:"url,omitempty"`
+}
+
+// UploadedAsset is a local file uploaded through the official API and placed
+// on a page.
+type UploadedAsset struct {
+	Path         string `json:"path"`
+	FileUploadID string `json:"file_upload_id"`
+	Caption      string `json:"caption,omitempty"`
 }
 
 type Database struct {
@@ -32,6 +61,10 @@ type SearchResult struct {
 	URL        string
 	ParentType string
 	ParentID   string
+
+	// Content and ContentError are only set by search --with-content.
+	Content      string `json:",omitempty"`
+	ContentError string `json:",omitempty"`
 }
 
 type Comment struct {
@@ -45,3 +78,13 @@ type Comment struct {
 	CreatedByName  string
 	Content        string
 }
+
+// Block is one child block listed by page children. Children is only set
+// when the listing recursed into it.
+type Block struct {
+	ID          string  `json:"id"`
+	Type        string  `json:"type"`
+	Text        string  `json:"text,omitempty"`
+	HasChildren bool    `json:"has_children"`
+	Children    []Block `json:"children,omitempty"`
+}
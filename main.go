@@ -7,6 +7,7 @@ import (
 	"github.com/lox/notion-cli/cmd"
 	"github.com/lox/notion-cli/internal/cli"
 	"github.com/lox/notion-cli/internal/config"
+	"github.com/lox/notion-cli/internal/output"
 )
 
 var version = "dev"
@@ -28,12 +29,18 @@ func main() {
 	ctx.FatalIfErrorf(err)
 	cli.SetAccessToken(c.Token)
 	cli.SetProfile(profile)
+	cli.SetFollowRedirects(c.FollowRedirects)
+	format := output.Format(c.Format)
+	output.SetFormat(format)
 	err = ctx.Run(&cmd.Context{
 		Profile:          profile,
+		JSON:             format.Structured(),
 		Token:            c.Token,
 		APIToken:         c.APIToken,
 		APIBaseURL:       c.APIBaseURL,
 		APINotionVersion: c.APINotionVersion,
+		NonInteractive:   c.NonInteractive,
+		ChunkSize:        c.ChunkSize,
 	})
 	ctx.FatalIfErrorf(err)
 	os.Exit(0)
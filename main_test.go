@@ -1,6 +1,19 @@
 package main
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/lox/notion-cli/cmd"
+)
+
+func TestCLIModelBuilds(t *testing.T) {
+	if _, err := kong.New(&cmd.CLI{}, kong.Vars{"version": "test"}); err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+}
 
 func TestShouldPrintVersionAndExit(t *testing.T) {
 	tests := []struct {
@@ -26,3 +39,70 @@ func TestShouldPrintVersionAndExit(t *testing.T) {
 		})
 	}
 }
+
+func TestPageViewIncludeCommentsAlias(t *testing.T) {
+	cli := &cmd.CLI{}
+	parser, err := kong.New(cli, kong.Vars{"version": "test"})
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse([]string{"page", "view", "p", "--include-comments"}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if !cli.Page.View.Comments {
+		t.Fatal("comments disabled with --include-comments")
+	}
+}
+
+func TestTopLevelPageShortcuts(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(file, []byte("# Notes\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	cli := &cmd.CLI{}
+	parser, err := kong.New(cli, kong.Vars{"version": "test"})
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	kctx, err := parser.Parse([]string{"sync", file, "--append-only"})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if kctx.Command() != "sync <file>" {
+		t.Fatalf("command = %q, want sync <file>", kctx.Command())
+	}
+	if cli.Sync.File != file || !cli.Sync.AppendOnly {
+		t.Fatalf("sync flags not parsed: %+v", cli.Sync)
+	}
+
+	if _, err := parser.Parse([]string{"view", "p", "--no-comments"}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if cli.View.Page != "p" || cli.View.Comments {
+		t.Fatalf("view flags not parsed: page=%q comments=%v", cli.View.Page, cli.View.Comments)
+	}
+}
+
+func TestProfileFlagOverridesEnv(t *testing.T) {
+	t.Setenv("NOTION_PROFILE", "ci")
+
+	cli := &cmd.CLI{}
+	parser, err := kong.New(cli, kong.Vars{"version": "test"})
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse([]string{"version"}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if cli.Profile != "ci" {
+		t.Fatalf("profile from env = %q, want ci", cli.Profile)
+	}
+
+	if _, err := parser.Parse([]string{"--profile", "work", "version"}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if cli.Profile != "work" {
+		t.Fatalf("profile with flag = %q, want work", cli.Profile)
+	}
+}